@@ -0,0 +1,694 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"jones-county-xc/backend/db"
+)
+
+const (
+	roleAdmin   = "admin"
+	roleAthlete = "athlete"
+	roleParent  = "parent"
+
+	defaultInviteDays = 14
+	maxInviteDays     = 90
+	minPasswordLength = 8
+
+	// accountTokenPrefix marks account tokens; admin tokens are bare hex.
+	accountTokenPrefix = "acct."
+	ctxAccount         = "account"
+
+	inviteAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9._@+-]{3,64}$`)
+
+type AccountResponse struct {
+	ID         int64   `json:"id"`
+	Username   string  `json:"username"`
+	Role       string  `json:"role"`
+	AthleteIDs []int64 `json:"athleteIds"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+type InviteResponse struct {
+	ID        int64   `json:"id"`
+	Code      string  `json:"code"`
+	AthleteID int64   `json:"athleteId"`
+	Role      string  `json:"role"`
+	CreatedAt string  `json:"createdAt"`
+	ExpiresAt string  `json:"expiresAt"`
+	UsedAt    *string `json:"usedAt"`
+	AccountID *int64  `json:"accountId"`
+}
+
+// ProfileResponse holds the profile fields an athlete or parent may edit.
+// They are only shown to the linked accounts and the admin.
+type ProfileResponse struct {
+	AthleteID        int64   `json:"athleteId"`
+	Bio              *string `json:"bio"`
+	EmergencyContact *string `json:"emergencyContact"`
+	EmergencyPhone   *string `json:"emergencyPhone"`
+}
+
+type MeResponse struct {
+	Account  AccountResponse   `json:"account"`
+	Athletes []AthleteResponse `json:"athletes"`
+}
+
+// authAccount is the signed-in account, stored in the request context by
+// AccountMiddleware. It is nil for the admin.
+type authAccount struct {
+	db.Account
+	AthleteIDs []int64
+}
+
+// --- Tokens and middleware ---
+
+// accountToken signs an account ID together with its password hash, so
+// changing the password invalidates every earlier token.
+func accountToken(a db.Account) string {
+	mac := hmac.New(sha256.New, []byte(tokenSecret))
+	mac.Write([]byte("account:" + strconv.FormatInt(a.ID, 10) + ":" + a.PasswordHash))
+	return accountTokenPrefix + strconv.FormatInt(a.ID, 10) + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// accountFromToken returns the account a token was issued to, or false if
+// the token is not a valid account token.
+func (s *Server) accountFromToken(ctx context.Context, token string) (*authAccount, bool) {
+	rest, ok := strings.CutPrefix(token, accountTokenPrefix)
+	if !ok {
+		return nil, false
+	}
+	idPart, _, ok := strings.Cut(rest, ".")
+	if !ok {
+		return nil, false
+	}
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	account, err := s.store.GetAccountByID(ctx, id)
+	if err != nil || !hmac.Equal([]byte(token), []byte(accountToken(account))) {
+		return nil, false
+	}
+	athleteIDs, err := s.store.GetAccountAthleteIDs(ctx, id)
+	if err != nil {
+		return nil, false
+	}
+	return &authAccount{Account: account, AthleteIDs: athleteIDs}, true
+}
+
+// AccountMiddleware accepts the admin token or an athlete/parent account
+// token. The account, if any, is stored in the context under ctxAccount.
+func (s *Server) AccountMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if !strings.HasPrefix(header, "Bearer ") {
+			c.AbortWithStatusJSON(401, gin.H{"message": "unauthorized"})
+			return
+		}
+		token := strings.TrimPrefix(header, "Bearer ")
+		if validateToken(token) {
+			c.Next()
+			return
+		}
+		account, ok := s.accountFromToken(c.Request.Context(), token)
+		if !ok {
+			c.AbortWithStatusJSON(401, gin.H{"message": "unauthorized"})
+			return
+		}
+		c.Set(ctxAccount, account)
+		c.Next()
+	}
+}
+
+// RequireAthleteOwner must follow AccountMiddleware. The admin always
+// passes; an account passes only when the athlete the request is about,
+// as found by owner, is linked to it. owner writes its own error response
+// and returns false when the athlete cannot be determined.
+func RequireAthleteOwner(owner func(c *gin.Context) (int64, bool)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		account := currentAccount(c)
+		if account == nil {
+			c.Next()
+			return
+		}
+		athleteID, ok := owner(c)
+		if !ok {
+			c.Abort()
+			return
+		}
+		if !containsID(account.AthleteIDs, athleteID) {
+			c.AbortWithStatusJSON(403, gin.H{"message": "forbidden"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// athleteParamOwner reads the athlete from the :id route parameter.
+func athleteParamOwner(c *gin.Context) (int64, bool) {
+	var athleteID int64
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return 0, false
+	}
+	return athleteID, true
+}
+
+// workoutOwner reads the athlete who logged the workout in :id.
+func (s *Server) workoutOwner(c *gin.Context) (int64, bool) {
+	ctx := c.Request.Context()
+	var workoutID int64
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &workoutID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid workout ID"})
+		return 0, false
+	}
+	workout, err := s.store.GetWorkoutByID(ctx, workoutID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "workout not found"})
+		return 0, false
+	}
+	if err != nil {
+		serverError(c, err)
+		return 0, false
+	}
+	return workout.AthleteID, true
+}
+
+// currentAccount returns the signed-in account, or nil for the admin.
+func currentAccount(c *gin.Context) *authAccount {
+	if v, ok := c.Get(ctxAccount); ok {
+		return v.(*authAccount)
+	}
+	return nil
+}
+
+// loginAccount checks an account's credentials for Login.
+func (s *Server) loginAccount(ctx context.Context, username, password string) (db.Account, bool) {
+	account, err := s.store.GetAccountByUsername(ctx, username)
+	if err != nil {
+		return db.Account{}, false
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(account.PasswordHash), []byte(password)); err != nil {
+		return db.Account{}, false
+	}
+	return account, true
+}
+
+// --- Self-service handlers ---
+
+// Register creates an athlete or parent account from an unused invite code.
+// The account is linked to the invite's athlete and signed in.
+func (s *Server) Register(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input struct {
+		Code     string `json:"code"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if !usernamePattern.MatchString(input.Username) || strings.EqualFold(input.Username, adminUsername) {
+		c.JSON(400, gin.H{"error": "username must be 3-64 letters, digits or . _ @ + -"})
+		return
+	}
+	if len(input.Password) < minPasswordLength {
+		c.JSON(400, gin.H{"error": fmt.Sprintf("password must be at least %d characters", minPasswordLength)})
+		return
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	invite, msg := openInvite(ctx, tx, input.Code)
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+	account, err := tx.CreateAccount(ctx, db.CreateAccountParams{
+		Username:     input.Username,
+		PasswordHash: string(hash),
+		Role:         invite.Role,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			c.JSON(409, gin.H{"error": "username is already taken"})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+	msg, err = redeemInvite(ctx, tx, invite, account.ID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	c.JSON(201, gin.H{
+		"token":   accountToken(account),
+		"role":    account.Role,
+		"account": accountResponse(account, []int64{invite.AthleteID}),
+	})
+}
+
+// GetMe returns the signed-in account and its linked athletes. The admin
+// has no account, so this is 404 for the admin token.
+func (s *Server) GetMe(c *gin.Context) {
+	ctx := c.Request.Context()
+	account := currentAccount(c)
+	if account == nil {
+		c.JSON(404, gin.H{"error": "the admin has no account"})
+		return
+	}
+
+	statuses, err := s.athleteCurrentStatuses(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	response := MeResponse{
+		Account:  accountResponse(account.Account, account.AthleteIDs),
+		Athletes: make([]AthleteResponse, 0, len(account.AthleteIDs)),
+	}
+	for _, id := range account.AthleteIDs {
+		athlete, err := s.store.GetAthleteByID(ctx, id)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			serverError(c, err)
+			return
+		}
+		events, err := s.store.GetAthleteEventNames(ctx, id)
+		if err != nil {
+			serverError(c, err)
+			return
+		}
+		status := statuses[id]
+		if status == "" {
+			status = statusActive
+		}
+		response.Athletes = append(response.Athletes, athleteResponse(athlete, events, status))
+	}
+	c.JSON(200, response)
+}
+
+// LinkAthlete lets a parent account redeem another parent invite, for
+// example for a second child on the team.
+func (s *Server) LinkAthlete(c *gin.Context) {
+	ctx := c.Request.Context()
+	account := currentAccount(c)
+	if account == nil || account.Role != roleParent {
+		c.JSON(403, gin.H{"error": "only parent accounts can link more athletes"})
+		return
+	}
+	var input struct {
+		Code string `json:"code"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	invite, msg := openInvite(ctx, tx, input.Code)
+	if msg == "" && invite.Role != roleParent {
+		msg = "invite code is not a parent invite"
+	}
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+	msg, err = redeemInvite(ctx, tx, invite, account.ID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+	athleteIDs, err := tx.GetAccountAthleteIDs(ctx, account.ID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, accountResponse(account.Account, athleteIDs))
+}
+
+// GetAthleteProfile returns an athlete's private profile fields.
+func (s *Server) GetAthleteProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+	if _, err := s.store.GetAthleteByID(ctx, athleteID); err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "athlete not found"})
+		return
+	} else if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	profile, err := s.store.GetAthleteProfile(ctx, athleteID)
+	if err == sql.ErrNoRows {
+		profile, err = db.AthleteProfile{AthleteID: athleteID}, nil
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, profileResponse(profile))
+}
+
+// UpdateAthleteProfile replaces the profile fields athletes and parents may
+// edit themselves. Name, grade, gender and the rest stay with the coach.
+func (s *Server) UpdateAthleteProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+	if _, err := s.store.GetAthleteByID(ctx, athleteID); err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "athlete not found"})
+		return
+	} else if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	var input struct {
+		Bio              *string `json:"bio"`
+		EmergencyContact *string `json:"emergencyContact"`
+		EmergencyPhone   *string `json:"emergencyPhone"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Bio != nil && len(*input.Bio) > 1000 {
+		c.JSON(400, gin.H{"error": "bio must be at most 1000 characters"})
+		return
+	}
+
+	profile, err := s.store.UpsertAthleteProfile(ctx, db.UpsertAthleteProfileParams{
+		AthleteID:        athleteID,
+		Bio:              ptrToNullString(input.Bio),
+		EmergencyContact: ptrToNullString(input.EmergencyContact),
+		EmergencyPhone:   ptrToNullString(input.EmergencyPhone),
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, profileResponse(profile))
+}
+
+// --- Admin handlers ---
+
+// CreateInvite issues an invite code for an athlete. Body: {"role":
+// "athlete"|"parent", "expiresInDays": 14}.
+func (s *Server) CreateInvite(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+	if _, err := s.store.GetAthleteByID(ctx, athleteID); err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "athlete not found"})
+		return
+	} else if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	var input struct {
+		Role          string `json:"role"`
+		ExpiresInDays *int   `json:"expiresInDays"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Role != roleAthlete && input.Role != roleParent {
+		c.JSON(400, gin.H{"error": "role must be athlete or parent"})
+		return
+	}
+	days := defaultInviteDays
+	if input.ExpiresInDays != nil {
+		days = *input.ExpiresInDays
+	}
+	if days < 1 || days > maxInviteDays {
+		c.JSON(400, gin.H{"error": fmt.Sprintf("expiresInDays must be between 1 and %d", maxInviteDays)})
+		return
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	invite, err := s.store.CreateInvite(ctx, db.CreateInviteParams{
+		Code:      code,
+		AthleteID: athleteID,
+		Role:      input.Role,
+		ExpiresAt: time.Now().UTC().AddDate(0, 0, days).Format(time.RFC3339),
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(201, inviteResponse(invite))
+}
+
+// GetInvites lists every invite, newest first.
+func (s *Server) GetInvites(c *gin.Context) {
+	ctx := c.Request.Context()
+	invites, err := s.store.GetAllInvites(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]InviteResponse, len(invites))
+	for i, inv := range invites {
+		response[i] = inviteResponse(inv)
+	}
+	c.JSON(200, response)
+}
+
+// DeleteInvite revokes an unused invite.
+func (s *Server) DeleteInvite(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var inviteID int64
+	if _, err := fmt.Sscanf(id, "%d", &inviteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid invite ID"})
+		return
+	}
+
+	n, err := s.store.DeleteInvite(ctx, inviteID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "unused invite not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "invite deleted"})
+}
+
+func (s *Server) GetAccounts(c *gin.Context) {
+	ctx := c.Request.Context()
+	accounts, err := s.store.GetAllAccounts(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]AccountResponse, len(accounts))
+	for i, a := range accounts {
+		athleteIDs, err := s.store.GetAccountAthleteIDs(ctx, a.ID)
+		if err != nil {
+			serverError(c, err)
+			return
+		}
+		response[i] = accountResponse(a, athleteIDs)
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) DeleteAccount(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var accountID int64
+	if _, err := fmt.Sscanf(id, "%d", &accountID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	n, err := s.store.DeleteAccount(ctx, accountID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "account not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "account deleted"})
+}
+
+// --- Helpers ---
+
+// openInvite looks up an invite that can still be redeemed. It returns an
+// error message, or "" if the invite is usable.
+func openInvite(ctx context.Context, q db.Querier, code string) (db.Invite, string) {
+	invite, err := q.GetInviteByCode(ctx, normalizeInviteCode(code))
+	if err != nil || invite.UsedAt.Valid {
+		return db.Invite{}, "invite code is invalid or already used"
+	}
+	expires, err := time.Parse(time.RFC3339, invite.ExpiresAt)
+	if err != nil || time.Now().After(expires) {
+		return db.Invite{}, "invite code has expired"
+	}
+	return invite, ""
+}
+
+// redeemInvite marks an invite used and links its athlete to the account.
+// The update only matches an unused invite, so two requests racing for the
+// same code cannot both succeed.
+func redeemInvite(ctx context.Context, q db.Querier, invite db.Invite, accountID int64) (string, error) {
+	n, err := q.UseInvite(ctx, db.UseInviteParams{
+		AccountID: sql.NullInt64{Int64: accountID, Valid: true},
+		ID:        invite.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if n == 0 {
+		return "invite code is invalid or already used", nil
+	}
+	return "", q.AddAccountAthlete(ctx, db.AddAccountAthleteParams{
+		AccountID: accountID,
+		AthleteID: invite.AthleteID,
+	})
+}
+
+// newInviteCode returns a random code such as "K7QX-M2PD". Ambiguous
+// characters (0/O, 1/I) are left out so codes can be read aloud.
+func newInviteCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	code := make([]byte, len(buf))
+	for i, b := range buf {
+		code[i] = inviteAlphabet[int(b)%len(inviteAlphabet)]
+	}
+	return string(code[:4]) + "-" + string(code[4:]), nil
+}
+
+// normalizeInviteCode accepts codes typed in any case, with or without the
+// dash or spaces.
+func normalizeInviteCode(code string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		if strings.ContainsRune(inviteAlphabet, r) {
+			b.WriteRune(r)
+		}
+	}
+	s := b.String()
+	if len(s) != 8 {
+		return s
+	}
+	return s[:4] + "-" + s[4:]
+}
+
+func accountResponse(a db.Account, athleteIDs []int64) AccountResponse {
+	if athleteIDs == nil {
+		athleteIDs = []int64{}
+	}
+	return AccountResponse{
+		ID:         a.ID,
+		Username:   a.Username,
+		Role:       a.Role,
+		AthleteIDs: athleteIDs,
+		CreatedAt:  a.CreatedAt,
+	}
+}
+
+func inviteResponse(i db.Invite) InviteResponse {
+	return InviteResponse{
+		ID:        i.ID,
+		Code:      i.Code,
+		AthleteID: i.AthleteID,
+		Role:      i.Role,
+		CreatedAt: i.CreatedAt,
+		ExpiresAt: i.ExpiresAt,
+		UsedAt:    nullStringToPtr(i.UsedAt),
+		AccountID: nullInt64ToPtr(i.AccountID),
+	}
+}
+
+func profileResponse(p db.AthleteProfile) ProfileResponse {
+	return ProfileResponse{
+		AthleteID:        p.AthleteID,
+		Bio:              nullStringToPtr(p.Bio),
+		EmergencyContact: nullStringToPtr(p.EmergencyContact),
+		EmergencyPhone:   nullStringToPtr(p.EmergencyPhone),
+	}
+}
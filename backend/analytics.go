@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/pace"
+	"jones-county-xc/backend/stats"
+)
+
+// maxTrendGain bounds how far a trend-line prediction may run ahead of the
+// season best, so a steep early-season slope doesn't extrapolate into
+// impossible times.
+const maxTrendGain = 0.03
+
+type SeasonAnalytics struct {
+	Season              string   `json:"season"`
+	Races               int      `json:"races"`
+	Best5K              string   `json:"best5k"`
+	Average5K           string   `json:"average5k"`
+	StdDevSeconds       float64  `json:"stdDevSeconds"`
+	TrendSecondsPerWeek *float64 `json:"trendSecondsPerWeek"`
+	ImprovementSeconds  *float64 `json:"improvementSeconds"`
+	ImprovementPercent  *float64 `json:"improvementPercent"`
+}
+
+type PredictionResponse struct {
+	MeetID         int64   `json:"meetId"`
+	MeetName       string  `json:"meetName"`
+	MeetDate       *string `json:"meetDate"`
+	CourseID       int64   `json:"courseId"`
+	CourseName     string  `json:"courseName"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	PredictedTime  string  `json:"predictedTime"`
+	Basis          string  `json:"basis"`
+}
+
+type AthleteAnalyticsResponse struct {
+	AthleteID   int64                `json:"athleteId"`
+	AthleteName string               `json:"athleteName"`
+	Seasons     []SeasonAnalytics    `json:"seasons"`
+	Predictions []PredictionResponse `json:"predictions"`
+}
+
+// seasonRaces holds one season's results as 5K-equivalent seconds, with the
+// day offset of each race from the season's first dated race.
+type seasonRaces struct {
+	season  string
+	seconds []float64
+	days    []float64
+	dated   []float64
+	start   time.Time
+	trend   stats.Line
+	trended bool
+}
+
+// GetAthleteAnalytics reports per-season best, average, consistency and
+// trend for an athlete, plus predicted times for upcoming meets on known
+// courses. Every result is normalized to a Riegel 5K equivalent first so
+// races on different distances are comparable; results from meets without a
+// course are skipped.
+func (s *Server) GetAthleteAnalytics(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+
+	athlete, err := s.store.GetAthleteByID(ctx, athleteID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "athlete not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	results, err := s.store.GetResultsByAthlete(ctx, sql.NullInt64{Int64: athleteID, Valid: true})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	bySeason := map[string]*seasonRaces{}
+	var order []string
+	for _, r := range results {
+		if !r.Time.Valid || !r.DistanceMeters.Valid {
+			continue
+		}
+		conv, err := pace.Convert(r.Time.String, r.DistanceMeters.Float64)
+		if err != nil {
+			continue
+		}
+		season := meetSeason(r.MeetDate)
+		sr, ok := bySeason[season]
+		if !ok {
+			sr = &seasonRaces{season: season}
+			bySeason[season] = sr
+			order = append(order, season)
+		}
+		sr.seconds = append(sr.seconds, conv.Equivalent5K)
+
+		if date, ok := parseMeetDate(r.MeetDate); ok {
+			if sr.start.IsZero() {
+				sr.start = date
+			}
+			sr.days = append(sr.days, date.Sub(sr.start).Hours()/24)
+			sr.dated = append(sr.dated, conv.Equivalent5K)
+		}
+	}
+	sort.Strings(order)
+
+	response := AthleteAnalyticsResponse{
+		AthleteID:   athlete.ID,
+		AthleteName: athlete.Name,
+		Seasons:     []SeasonAnalytics{},
+		Predictions: []PredictionResponse{},
+	}
+
+	var prevBest float64
+	for _, season := range order {
+		sr := bySeason[season]
+		best := slices.Min(sr.seconds)
+		sa := SeasonAnalytics{
+			Season:        season,
+			Races:         len(sr.seconds),
+			Best5K:        pace.Format(best),
+			Average5K:     pace.Format(stats.Mean(sr.seconds)),
+			StdDevSeconds: roundTenths(stats.StdDev(sr.seconds)),
+		}
+		if line, ok := stats.LinearFit(sr.days, sr.dated); ok {
+			sr.trend, sr.trended = line, true
+			perWeek := roundTenths(line.Slope * 7)
+			sa.TrendSecondsPerWeek = &perWeek
+		}
+		if prevBest > 0 {
+			gain := roundTenths(prevBest - best)
+			percent := roundTenths(gain / prevBest * 100)
+			sa.ImprovementSeconds = &gain
+			sa.ImprovementPercent = &percent
+		}
+		prevBest = best
+		response.Seasons = append(response.Seasons, sa)
+	}
+
+	if len(order) > 0 {
+		predictions, err := s.predictUpcoming(ctx, bySeason[order[len(order)-1]])
+		if err != nil {
+			serverError(c, err)
+			return
+		}
+		response.Predictions = predictions
+	}
+	c.JSON(200, response)
+}
+
+// predictUpcoming projects the latest season onto each upcoming meet on a
+// course. For meets in the same season with a trend line, the 5K equivalent
+// is read off the line at the meet date, but never more than maxTrendGain
+// faster than the season best; otherwise the season best is used. Riegel
+// converts it to the course distance.
+func (s *Server) predictUpcoming(ctx context.Context, sr *seasonRaces) ([]PredictionResponse, error) {
+	today := time.Now().Format("2006-01-02")
+	meets, err := s.store.GetUpcomingCourseMeets(ctx, sql.NullString{String: today, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	best := slices.Min(sr.seconds)
+	predictions := make([]PredictionResponse, 0, len(meets))
+	for _, m := range meets {
+		equivalent, basis := best, "best"
+		date, dated := parseMeetDate(m.Date)
+		if dated && sr.trended && meetSeason(m.Date) == sr.season {
+			days := date.Sub(sr.start).Hours() / 24
+			equivalent = math.Max(sr.trend.At(days), best*(1-maxTrendGain))
+			basis = "trend"
+		}
+
+		predictions = append(predictions, PredictionResponse{
+			MeetID:         m.ID,
+			MeetName:       m.Name,
+			MeetDate:       nullStringToPtr(m.Date),
+			CourseID:       m.CourseID,
+			CourseName:     m.CourseName,
+			DistanceMeters: m.DistanceMeters,
+			PredictedTime:  pace.Format(pace.Riegel(equivalent, pace.Meters5K, m.DistanceMeters)),
+			Basis:          basis,
+		})
+	}
+	return predictions, nil
+}
+
+func parseMeetDate(date sql.NullString) (time.Time, bool) {
+	if !date.Valid || len(date.String) < 10 {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("2006-01-02", date.String[:10])
+	return t, err == nil
+}
+
+func roundTenths(x float64) float64 {
+	return math.Round(x*10) / 10
+}
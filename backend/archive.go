@@ -0,0 +1,1402 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+)
+
+// A data archive is a single JSON document holding all of the team's data:
+// the roster, meets, results and everything hanging off them. It is meant
+// for moving a season between installs or handing it to the next coach, so
+// logins, invites, email subscriptions, webhooks and the delivery queues are
+// left out.
+//
+// Every row carries the ID it had in the exporting database and refers to
+// other rows by those IDs. Import assigns new IDs and rewrites references.
+
+const (
+	archiveFormat  = "jones-county-xc-archive"
+	archiveVersion = 1
+
+	// maxArchiveDetails caps the duplicate descriptions in an import report.
+	maxArchiveDetails = 100
+)
+
+type Archive struct {
+	Format           string                   `json:"format"`
+	Version          int                      `json:"version"`
+	SchemaVersion    int                      `json:"schemaVersion"`
+	ExportedAt       string                   `json:"exportedAt"`
+	Events           []string                 `json:"events"`
+	Courses          []ArchiveCourse          `json:"courses"`
+	Athletes         []ArchiveAthlete         `json:"athletes"`
+	Meets            []ArchiveMeet            `json:"meets"`
+	Races            []ArchiveRace            `json:"races"`
+	Entries          []ArchiveEntry           `json:"entries"`
+	Bibs             []ArchiveBib             `json:"bibs"`
+	Results          []ArchiveResult          `json:"results"`
+	RecordBreaks     []ArchiveRecordBreak     `json:"recordBreaks"`
+	HistoricalMarks  []ArchiveHistoricalMark  `json:"historicalMarks"`
+	WorkoutPlans     []ArchiveWorkoutPlan     `json:"workoutPlans"`
+	Workouts         []ArchiveWorkout         `json:"workouts"`
+	PracticeSessions []ArchivePracticeSession `json:"practiceSessions"`
+	Attendance       []ArchiveAttendance      `json:"attendance"`
+}
+
+type ArchiveCourse struct {
+	ID             int64   `json:"id"`
+	Name           string  `json:"name"`
+	Venue          *string `json:"venue"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	Surface        *string `json:"surface"`
+	ElevationNotes *string `json:"elevationNotes"`
+}
+
+type ArchiveAthlete struct {
+	ID             int64                  `json:"id"`
+	Name           string                 `json:"name"`
+	PersonalRecord *string                `json:"personalRecord"`
+	Gender         *string                `json:"gender"`
+	GraduationYear *int64                 `json:"graduationYear"`
+	JerseyNumber   *int64                 `json:"jerseyNumber"`
+	Events         []string               `json:"events"`
+	Profile        *ArchiveAthleteProfile `json:"profile"`
+	Statuses       []ArchiveAthleteStatus `json:"statuses"`
+}
+
+type ArchiveAthleteProfile struct {
+	Bio              *string `json:"bio"`
+	EmergencyContact *string `json:"emergencyContact"`
+	EmergencyPhone   *string `json:"emergencyPhone"`
+}
+
+type ArchiveAthleteStatus struct {
+	Status    string  `json:"status"`
+	StartDate string  `json:"startDate"`
+	EndDate   *string `json:"endDate"`
+	Note      *string `json:"note"`
+}
+
+type ArchiveMeet struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	Date         *string `json:"date"`
+	Location     *string `json:"location"`
+	CourseID     *int64  `json:"courseId"`
+	Status       string  `json:"status"`
+	StartTime    *string `json:"startTime"`
+	BusDeparture *string `json:"busDeparture"`
+	HostSchool   *string `json:"hostSchool"`
+}
+
+type ArchiveRace struct {
+	ID             int64   `json:"id"`
+	MeetID         int64   `json:"meetId"`
+	Name           string  `json:"name"`
+	Gender         *string `json:"gender"`
+	ScorerLimit    int64   `json:"scorerLimit"`
+	AlternateLimit int64   `json:"alternateLimit"`
+	EntryDeadline  *string `json:"entryDeadline"`
+}
+
+type ArchiveEntry struct {
+	RaceID    int64  `json:"raceId"`
+	AthleteID int64  `json:"athleteId"`
+	Role      string `json:"role"`
+	Position  int64  `json:"position"`
+}
+
+type ArchiveBib struct {
+	MeetID    int64 `json:"meetId"`
+	AthleteID int64 `json:"athleteId"`
+	Bib       int64 `json:"bib"`
+}
+
+type ArchiveResult struct {
+	ID        int64          `json:"id"`
+	AthleteID *int64         `json:"athleteId"`
+	MeetID    *int64         `json:"meetId"`
+	Time      *string        `json:"time"`
+	Place     *int64         `json:"place"`
+	Splits    []ArchiveSplit `json:"splits"`
+}
+
+type ArchiveSplit struct {
+	Index          int64   `json:"index"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	Time           string  `json:"time"`
+}
+
+type ArchiveRecordBreak struct {
+	Category       string  `json:"category"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	Gender         *string `json:"gender"`
+	Grade          *int64  `json:"grade"`
+	ResultID       *int64  `json:"resultId"`
+	AthleteID      *int64  `json:"athleteId"`
+	AthleteName    string  `json:"athleteName"`
+	Time           string  `json:"time"`
+	PreviousHolder *string `json:"previousHolder"`
+	PreviousTime   *string `json:"previousTime"`
+	BrokenAt       string  `json:"brokenAt"`
+}
+
+type ArchiveHistoricalMark struct {
+	AthleteName    string  `json:"athleteName"`
+	Gender         *string `json:"gender"`
+	Grade          *int64  `json:"grade"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	Time           string  `json:"time"`
+	Date           *string `json:"date"`
+	MeetName       *string `json:"meetName"`
+	Notes          *string `json:"notes"`
+}
+
+type ArchiveWorkoutPlan struct {
+	ID              int64    `json:"id"`
+	Date            string   `json:"date"`
+	Type            string   `json:"type"`
+	Title           string   `json:"title"`
+	DistanceMiles   *float64 `json:"distanceMiles"`
+	DurationSeconds *int64   `json:"durationSeconds"`
+	Description     *string  `json:"description"`
+	AthleteIDs      []int64  `json:"athleteIds"`
+}
+
+type ArchiveWorkout struct {
+	AthleteID       int64    `json:"athleteId"`
+	PlanID          *int64   `json:"planId"`
+	Date            string   `json:"date"`
+	Type            string   `json:"type"`
+	DistanceMiles   *float64 `json:"distanceMiles"`
+	DurationSeconds *int64   `json:"durationSeconds"`
+	Rpe             *int64   `json:"rpe"`
+	Notes           *string  `json:"notes"`
+	CreatedAt       string   `json:"createdAt"`
+}
+
+type ArchivePracticeSession struct {
+	ID     int64   `json:"id"`
+	Date   string  `json:"date"`
+	Type   string  `json:"type"`
+	MeetID *int64  `json:"meetId"`
+	Notes  *string `json:"notes"`
+}
+
+type ArchiveAttendance struct {
+	SessionID int64   `json:"sessionId"`
+	AthleteID int64   `json:"athleteId"`
+	Status    string  `json:"status"`
+	Note      *string `json:"note"`
+}
+
+// ImportReport counts what an import created and which rows it skipped
+// because they were already present. Keys are the archive's section names.
+type ImportReport struct {
+	DryRun     bool           `json:"dryRun"`
+	Created    map[string]int `json:"created"`
+	Duplicates map[string]int `json:"duplicates"`
+	Details    []string       `json:"details"`
+}
+
+// archiveError is a problem with the archive itself rather than the
+// database, reported as 400.
+type archiveError struct{ msg string }
+
+func (e *archiveError) Error() string { return e.msg }
+
+var errArchiveDuplicates = errors.New("the archive contains rows that already exist")
+
+// --- Export ---
+
+// buildArchive reads everything in one transaction so that the archive is a
+// consistent snapshot even while the server is taking writes.
+func (s *Server) buildArchive(ctx context.Context) (Archive, error) {
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		return Archive{}, err
+	}
+	defer tx.Rollback()
+
+	version, err := latestSchemaVersion()
+	if err != nil {
+		return Archive{}, err
+	}
+	a := Archive{
+		Format:        archiveFormat,
+		Version:       archiveVersion,
+		SchemaVersion: version,
+		ExportedAt:    time.Now().UTC().Format(time.RFC3339),
+	}
+
+	events, err := tx.GetAllEvents(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.Events = make([]string, 0, len(events))
+	for _, e := range events {
+		a.Events = append(a.Events, e.Name)
+	}
+
+	courses, err := tx.GetAllCourses(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.Courses = make([]ArchiveCourse, 0, len(courses))
+	for _, c := range courses {
+		a.Courses = append(a.Courses, ArchiveCourse{
+			ID:             c.ID,
+			Name:           c.Name,
+			Venue:          nullStringToPtr(c.Venue),
+			DistanceMeters: c.DistanceMeters,
+			Surface:        nullStringToPtr(c.Surface),
+			ElevationNotes: nullStringToPtr(c.ElevationNotes),
+		})
+	}
+
+	athleteEvents, err := tx.GetAllAthleteEvents(ctx)
+	if err != nil {
+		return a, err
+	}
+	eventsByAthlete := map[int64][]string{}
+	for _, ae := range athleteEvents {
+		eventsByAthlete[ae.AthleteID] = append(eventsByAthlete[ae.AthleteID], ae.Name)
+	}
+	profiles, err := tx.GetAllAthleteProfiles(ctx)
+	if err != nil {
+		return a, err
+	}
+	profileByAthlete := map[int64]*ArchiveAthleteProfile{}
+	for _, p := range profiles {
+		profileByAthlete[p.AthleteID] = &ArchiveAthleteProfile{
+			Bio:              nullStringToPtr(p.Bio),
+			EmergencyContact: nullStringToPtr(p.EmergencyContact),
+			EmergencyPhone:   nullStringToPtr(p.EmergencyPhone),
+		}
+	}
+	statuses, err := tx.GetAllAthleteStatuses(ctx)
+	if err != nil {
+		return a, err
+	}
+	statusesByAthlete := map[int64][]ArchiveAthleteStatus{}
+	for _, st := range statuses {
+		statusesByAthlete[st.AthleteID] = append(statusesByAthlete[st.AthleteID], ArchiveAthleteStatus{
+			Status:    st.Status,
+			StartDate: st.StartDate,
+			EndDate:   nullStringToPtr(st.EndDate),
+			Note:      nullStringToPtr(st.Note),
+		})
+	}
+	athletes, err := tx.GetAllAthletes(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.Athletes = make([]ArchiveAthlete, 0, len(athletes))
+	for _, ath := range athletes {
+		events := eventsByAthlete[ath.ID]
+		if events == nil {
+			events = []string{}
+		}
+		statuses := statusesByAthlete[ath.ID]
+		if statuses == nil {
+			statuses = []ArchiveAthleteStatus{}
+		}
+		a.Athletes = append(a.Athletes, ArchiveAthlete{
+			ID:             ath.ID,
+			Name:           ath.Name,
+			PersonalRecord: nullStringToPtr(ath.PersonalRecord),
+			Gender:         nullStringToPtr(ath.Gender),
+			GraduationYear: nullInt64ToPtr(ath.GraduationYear),
+			JerseyNumber:   nullInt64ToPtr(ath.JerseyNumber),
+			Events:         events,
+			Profile:        profileByAthlete[ath.ID],
+			Statuses:       statuses,
+		})
+	}
+
+	meets, err := tx.GetAllMeets(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.Meets = make([]ArchiveMeet, 0, len(meets))
+	for _, m := range meets {
+		a.Meets = append(a.Meets, ArchiveMeet{
+			ID:           m.ID,
+			Name:         m.Name,
+			Date:         nullStringToPtr(m.Date),
+			Location:     nullStringToPtr(m.Location),
+			CourseID:     nullInt64ToPtr(m.CourseID),
+			Status:       m.Status,
+			StartTime:    nullStringToPtr(m.StartTime),
+			BusDeparture: nullStringToPtr(m.BusDeparture),
+			HostSchool:   nullStringToPtr(m.HostSchool),
+		})
+	}
+
+	races, err := tx.GetAllRaces(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.Races = make([]ArchiveRace, 0, len(races))
+	for _, r := range races {
+		a.Races = append(a.Races, ArchiveRace{
+			ID:             r.ID,
+			MeetID:         r.MeetID,
+			Name:           r.Name,
+			Gender:         nullStringToPtr(r.Gender),
+			ScorerLimit:    r.ScorerLimit,
+			AlternateLimit: r.AlternateLimit,
+			EntryDeadline:  nullStringToPtr(r.EntryDeadline),
+		})
+	}
+
+	entries, err := tx.GetAllEntries(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.Entries = make([]ArchiveEntry, 0, len(entries))
+	for _, e := range entries {
+		a.Entries = append(a.Entries, ArchiveEntry{
+			RaceID:    e.RaceID,
+			AthleteID: e.AthleteID,
+			Role:      e.Role,
+			Position:  e.Position,
+		})
+	}
+
+	bibs, err := tx.GetAllBibs(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.Bibs = make([]ArchiveBib, 0, len(bibs))
+	for _, b := range bibs {
+		a.Bibs = append(a.Bibs, ArchiveBib{MeetID: b.MeetID, AthleteID: b.AthleteID, Bib: b.Bib})
+	}
+
+	splits, err := tx.GetAllSplits(ctx)
+	if err != nil {
+		return a, err
+	}
+	splitsByResult := map[int64][]ArchiveSplit{}
+	for _, sp := range splits {
+		splitsByResult[sp.ResultID] = append(splitsByResult[sp.ResultID], ArchiveSplit{
+			Index:          sp.SplitIndex,
+			DistanceMeters: sp.DistanceMeters,
+			Time:           sp.Time,
+		})
+	}
+	results, err := tx.GetAllResults(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.Results = make([]ArchiveResult, 0, len(results))
+	for _, r := range results {
+		splits := splitsByResult[r.ID]
+		if splits == nil {
+			splits = []ArchiveSplit{}
+		}
+		a.Results = append(a.Results, ArchiveResult{
+			ID:        r.ID,
+			AthleteID: nullInt64ToPtr(r.AthleteID),
+			MeetID:    nullInt64ToPtr(r.MeetID),
+			Time:      nullStringToPtr(r.Time),
+			Place:     nullInt64ToPtr(r.Place),
+			Splits:    splits,
+		})
+	}
+
+	breaks, err := tx.GetRecordBreaks(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.RecordBreaks = make([]ArchiveRecordBreak, 0, len(breaks))
+	// GetRecordBreaks is newest first; archive them in the order they fell.
+	for i := len(breaks) - 1; i >= 0; i-- {
+		b := breaks[i]
+		a.RecordBreaks = append(a.RecordBreaks, ArchiveRecordBreak{
+			Category:       b.Category,
+			DistanceMeters: b.DistanceMeters,
+			Gender:         nullStringToPtr(b.Gender),
+			Grade:          nullInt64ToPtr(b.Grade),
+			ResultID:       nullInt64ToPtr(b.ResultID),
+			AthleteID:      nullInt64ToPtr(b.AthleteID),
+			AthleteName:    b.AthleteName,
+			Time:           b.Time,
+			PreviousHolder: nullStringToPtr(b.PreviousHolder),
+			PreviousTime:   nullStringToPtr(b.PreviousTime),
+			BrokenAt:       b.BrokenAt,
+		})
+	}
+
+	marks, err := tx.GetAllHistoricalMarks(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.HistoricalMarks = make([]ArchiveHistoricalMark, 0, len(marks))
+	for _, m := range marks {
+		a.HistoricalMarks = append(a.HistoricalMarks, ArchiveHistoricalMark{
+			AthleteName:    m.AthleteName,
+			Gender:         nullStringToPtr(m.Gender),
+			Grade:          nullInt64ToPtr(m.Grade),
+			DistanceMeters: m.DistanceMeters,
+			Time:           m.Time,
+			Date:           nullStringToPtr(m.Date),
+			MeetName:       nullStringToPtr(m.MeetName),
+			Notes:          nullStringToPtr(m.Notes),
+		})
+	}
+
+	planAthletes, err := tx.GetAllWorkoutPlanAthletes(ctx)
+	if err != nil {
+		return a, err
+	}
+	athletesByPlan := map[int64][]int64{}
+	for _, pa := range planAthletes {
+		athletesByPlan[pa.PlanID] = append(athletesByPlan[pa.PlanID], pa.AthleteID)
+	}
+	plans, err := tx.GetAllWorkoutPlans(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.WorkoutPlans = make([]ArchiveWorkoutPlan, 0, len(plans))
+	for _, p := range plans {
+		ids := athletesByPlan[p.ID]
+		if ids == nil {
+			ids = []int64{}
+		}
+		a.WorkoutPlans = append(a.WorkoutPlans, ArchiveWorkoutPlan{
+			ID:              p.ID,
+			Date:            p.Date,
+			Type:            p.Type,
+			Title:           p.Title,
+			DistanceMiles:   nullFloat64ToPtr(p.DistanceMiles),
+			DurationSeconds: nullInt64ToPtr(p.DurationSeconds),
+			Description:     nullStringToPtr(p.Description),
+			AthleteIDs:      ids,
+		})
+	}
+
+	workouts, err := tx.GetAllWorkouts(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.Workouts = make([]ArchiveWorkout, 0, len(workouts))
+	for _, w := range workouts {
+		a.Workouts = append(a.Workouts, ArchiveWorkout{
+			AthleteID:       w.AthleteID,
+			PlanID:          nullInt64ToPtr(w.PlanID),
+			Date:            w.Date,
+			Type:            w.Type,
+			DistanceMiles:   nullFloat64ToPtr(w.DistanceMiles),
+			DurationSeconds: nullInt64ToPtr(w.DurationSeconds),
+			Rpe:             nullInt64ToPtr(w.Rpe),
+			Notes:           nullStringToPtr(w.Notes),
+			CreatedAt:       w.CreatedAt,
+		})
+	}
+
+	sessions, err := tx.GetAllPracticeSessions(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.PracticeSessions = make([]ArchivePracticeSession, 0, len(sessions))
+	for _, ps := range sessions {
+		a.PracticeSessions = append(a.PracticeSessions, ArchivePracticeSession{
+			ID:     ps.ID,
+			Date:   ps.Date,
+			Type:   ps.Type,
+			MeetID: nullInt64ToPtr(ps.MeetID),
+			Notes:  nullStringToPtr(ps.Notes),
+		})
+	}
+
+	attendance, err := tx.GetAllAttendance(ctx)
+	if err != nil {
+		return a, err
+	}
+	a.Attendance = make([]ArchiveAttendance, 0, len(attendance))
+	for _, at := range attendance {
+		a.Attendance = append(a.Attendance, ArchiveAttendance{
+			SessionID: at.SessionID,
+			AthleteID: at.AthleteID,
+			Status:    at.Status,
+			Note:      nullStringToPtr(at.Note),
+		})
+	}
+
+	return a, nil
+}
+
+// --- Import ---
+
+// decodeArchive reads an archive and checks that this build understands it.
+func decodeArchive(r io.Reader) (Archive, error) {
+	var a Archive
+	if err := json.NewDecoder(r).Decode(&a); err != nil {
+		return a, &archiveError{"invalid archive: " + err.Error()}
+	}
+	if a.Format != archiveFormat {
+		return a, &archiveError{fmt.Sprintf("not an archive: format must be %q", archiveFormat)}
+	}
+	if a.Version < 1 || a.Version > archiveVersion {
+		return a, &archiveError{fmt.Sprintf("archive version %d is not supported; this build reads version %d", a.Version, archiveVersion)}
+	}
+	return a, nil
+}
+
+// importArchive loads an archive in a single transaction. Rows that match
+// existing data are skipped and counted as duplicates, and references to
+// them point at the existing rows. With failOnDuplicate any duplicate rolls
+// the import back; dryRun always does.
+func (s *Server) importArchive(ctx context.Context, a Archive, dryRun, failOnDuplicate bool) (ImportReport, error) {
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	defer tx.Rollback()
+
+	im := newArchiveImporter(tx)
+	im.report.DryRun = dryRun
+	if err := im.load(ctx); err != nil {
+		return im.report, err
+	}
+	if err := im.run(ctx, a); err != nil {
+		return im.report, err
+	}
+	if failOnDuplicate && len(im.report.Details) > 0 {
+		return im.report, errArchiveDuplicates
+	}
+	if dryRun {
+		return im.report, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return im.report, err
+	}
+	return im.report, nil
+}
+
+// archiveImporter maps archive IDs to local IDs and remembers the natural
+// keys of existing rows so that duplicates can be spotted.
+type archiveImporter struct {
+	q      db.Querier
+	report ImportReport
+
+	courses  map[int64]int64
+	athletes map[int64]int64
+	meets    map[int64]int64
+	races    map[int64]int64
+	results  map[int64]int64
+	plans    map[int64]int64
+	sessions map[int64]int64
+
+	// existing maps a natural key to the local ID of the row it belongs to.
+	existing map[string]int64
+}
+
+func newArchiveImporter(q db.Querier) *archiveImporter {
+	return &archiveImporter{
+		q: q,
+		report: ImportReport{
+			Created:    map[string]int{},
+			Duplicates: map[string]int{},
+			Details:    []string{},
+		},
+		courses:  map[int64]int64{},
+		athletes: map[int64]int64{},
+		meets:    map[int64]int64{},
+		races:    map[int64]int64{},
+		results:  map[int64]int64{},
+		plans:    map[int64]int64{},
+		sessions: map[int64]int64{},
+		existing: map[string]int64{},
+	}
+}
+
+// Natural keys. Names compare case-insensitively.
+
+func courseKey(name string, distance float64) string {
+	return "course|" + strings.ToLower(strings.TrimSpace(name)) + "|" + strconv.FormatFloat(distance, 'f', -1, 64)
+}
+
+func athleteKey(name string, graduationYear *int64) string {
+	return "athlete|" + strings.ToLower(strings.TrimSpace(name)) + "|" + optionalInt64String(graduationYear)
+}
+
+func meetKey(name string, date *string) string {
+	return "meet|" + strings.ToLower(strings.TrimSpace(name)) + "|" + stringOrEmpty(date)
+}
+
+func raceKey(meetID int64, name string) string {
+	return fmt.Sprintf("race|%d|%s", meetID, strings.ToLower(strings.TrimSpace(name)))
+}
+
+func entryKey(raceID, athleteID int64) string {
+	return fmt.Sprintf("entry|%d|%d", raceID, athleteID)
+}
+
+func bibAthleteKey(meetID, athleteID int64) string {
+	return fmt.Sprintf("bib|%d|athlete|%d", meetID, athleteID)
+}
+
+func bibNumberKey(meetID, bib int64) string {
+	return fmt.Sprintf("bib|%d|number|%d", meetID, bib)
+}
+
+func resultKey(athleteID, meetID *int64, t *string) string {
+	return "result|" + optionalInt64String(athleteID) + "|" + optionalInt64String(meetID) + "|" + stringOrEmpty(t)
+}
+
+func recordBreakKey(category string, distance float64, gender *string, grade *int64, athleteName, t string) string {
+	return "record|" + category + "|" + strconv.FormatFloat(distance, 'f', -1, 64) + "|" + stringOrEmpty(gender) + "|" +
+		optionalInt64String(grade) + "|" + strings.ToLower(athleteName) + "|" + t
+}
+
+func historicalMarkKey(athleteName string, distance float64, t string, date *string) string {
+	return "mark|" + strings.ToLower(strings.TrimSpace(athleteName)) + "|" + strconv.FormatFloat(distance, 'f', -1, 64) + "|" + t + "|" + stringOrEmpty(date)
+}
+
+func planKey(date, title string) string {
+	return "plan|" + date + "|" + strings.ToLower(strings.TrimSpace(title))
+}
+
+func workoutKey(athleteID int64, date, kind, createdAt string) string {
+	return fmt.Sprintf("workout|%d|%s|%s|%s", athleteID, date, kind, createdAt)
+}
+
+func sessionKey(date, kind string, meetID *int64) string {
+	return "session|" + date + "|" + kind + "|" + optionalInt64String(meetID)
+}
+
+func attendanceKey(sessionID, athleteID int64) string {
+	return fmt.Sprintf("attendance|%d|%d", sessionID, athleteID)
+}
+
+// load indexes the rows already in the database.
+func (im *archiveImporter) load(ctx context.Context) error {
+	courses, err := im.q.GetAllCourses(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range courses {
+		im.existing[courseKey(c.Name, c.DistanceMeters)] = c.ID
+	}
+	athletes, err := im.q.GetAllAthletes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, a := range athletes {
+		im.existing[athleteKey(a.Name, nullInt64ToPtr(a.GraduationYear))] = a.ID
+	}
+	meets, err := im.q.GetAllMeets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range meets {
+		im.existing[meetKey(m.Name, nullStringToPtr(m.Date))] = m.ID
+	}
+	races, err := im.q.GetAllRaces(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range races {
+		im.existing[raceKey(r.MeetID, r.Name)] = r.ID
+	}
+	entries, err := im.q.GetAllEntries(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		im.existing[entryKey(e.RaceID, e.AthleteID)] = e.ID
+	}
+	bibs, err := im.q.GetAllBibs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, b := range bibs {
+		im.existing[bibAthleteKey(b.MeetID, b.AthleteID)] = b.ID
+		im.existing[bibNumberKey(b.MeetID, b.Bib)] = b.ID
+	}
+	results, err := im.q.GetAllResults(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		im.existing[resultKey(nullInt64ToPtr(r.AthleteID), nullInt64ToPtr(r.MeetID), nullStringToPtr(r.Time))] = r.ID
+	}
+	breaks, err := im.q.GetRecordBreaks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, b := range breaks {
+		im.existing[recordBreakKey(b.Category, b.DistanceMeters, nullStringToPtr(b.Gender), nullInt64ToPtr(b.Grade), b.AthleteName, b.Time)] = b.ID
+	}
+	marks, err := im.q.GetAllHistoricalMarks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range marks {
+		im.existing[historicalMarkKey(m.AthleteName, m.DistanceMeters, m.Time, nullStringToPtr(m.Date))] = m.ID
+	}
+	plans, err := im.q.GetAllWorkoutPlans(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range plans {
+		im.existing[planKey(p.Date, p.Title)] = p.ID
+	}
+	workouts, err := im.q.GetAllWorkouts(ctx)
+	if err != nil {
+		return err
+	}
+	for _, w := range workouts {
+		im.existing[workoutKey(w.AthleteID, w.Date, w.Type, w.CreatedAt)] = w.ID
+	}
+	sessions, err := im.q.GetAllPracticeSessions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range sessions {
+		im.existing[sessionKey(s.Date, s.Type, nullInt64ToPtr(s.MeetID))] = s.ID
+	}
+	attendance, err := im.q.GetAllAttendance(ctx)
+	if err != nil {
+		return err
+	}
+	for _, at := range attendance {
+		im.existing[attendanceKey(at.SessionID, at.AthleteID)] = at.ID
+	}
+	return nil
+}
+
+// duplicate looks up key. When it exists the row is counted as a duplicate
+// of section and its local ID returned.
+func (im *archiveImporter) duplicate(section, key, label string) (int64, bool) {
+	id, ok := im.existing[key]
+	if !ok {
+		return 0, false
+	}
+	im.report.Duplicates[section]++
+	if len(im.report.Details) < maxArchiveDetails {
+		im.report.Details = append(im.report.Details, fmt.Sprintf("%s: %s matches existing #%d", section, label, id))
+	}
+	return id, true
+}
+
+func (im *archiveImporter) created(section, key string, id int64) {
+	im.report.Created[section]++
+	im.existing[key] = id
+}
+
+// ref resolves an archive ID through one of the importer's ID maps.
+func ref(ids map[int64]int64, kind string, id int64) (int64, error) {
+	local, ok := ids[id]
+	if !ok {
+		return 0, &archiveError{fmt.Sprintf("unknown %s id %d", kind, id)}
+	}
+	return local, nil
+}
+
+func optionalRef(ids map[int64]int64, kind string, id *int64) (*int64, error) {
+	if id == nil {
+		return nil, nil
+	}
+	local, err := ref(ids, kind, *id)
+	if err != nil {
+		return nil, err
+	}
+	return &local, nil
+}
+
+// inSection prefixes an error with the archive position it came from.
+func inSection(section string, i int, err error) error {
+	var ae *archiveError
+	if errors.As(err, &ae) {
+		return &archiveError{fmt.Sprintf("%s[%d]: %s", section, i, ae.msg)}
+	}
+	if strings.Contains(err.Error(), "constraint failed") {
+		return &archiveError{fmt.Sprintf("%s[%d]: %v", section, i, err)}
+	}
+	return fmt.Errorf("%s[%d]: %w", section, i, err)
+}
+
+func (im *archiveImporter) run(ctx context.Context, a Archive) error {
+
+	for _, name := range a.Events {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		if _, err := im.q.UpsertEvent(ctx, strings.TrimSpace(name)); err != nil {
+			return err
+		}
+	}
+
+	for i, c := range a.Courses {
+		if strings.TrimSpace(c.Name) == "" || c.DistanceMeters <= 0 {
+			return inSection("courses", i, &archiveError{"name and a positive distanceMeters are required"})
+		}
+		key := courseKey(c.Name, c.DistanceMeters)
+		if id, ok := im.duplicate("courses", key, strconv.Quote(c.Name)); ok {
+			im.courses[c.ID] = id
+			continue
+		}
+		course, err := im.q.CreateCourse(ctx, db.CreateCourseParams{
+			Name:           strings.TrimSpace(c.Name),
+			Venue:          ptrToNullString(c.Venue),
+			DistanceMeters: c.DistanceMeters,
+			Surface:        ptrToNullString(c.Surface),
+			ElevationNotes: ptrToNullString(c.ElevationNotes),
+		})
+		if err != nil {
+			return inSection("courses", i, err)
+		}
+		im.courses[c.ID] = course.ID
+		im.created("courses", key, course.ID)
+	}
+
+	// A matched athlete keeps its own events, profile and status history.
+	for i, ath := range a.Athletes {
+		if strings.TrimSpace(ath.Name) == "" {
+			return inSection("athletes", i, &archiveError{"name is required"})
+		}
+		key := athleteKey(ath.Name, ath.GraduationYear)
+		if id, ok := im.duplicate("athletes", key, fmt.Sprintf("%q (class of %s)", ath.Name, optionalInt64String(ath.GraduationYear))); ok {
+			im.athletes[ath.ID] = id
+			continue
+		}
+		created, err := im.q.CreateAthlete(ctx, db.CreateAthleteParams{
+			Name:           strings.TrimSpace(ath.Name),
+			PersonalRecord: ptrToNullString(ath.PersonalRecord),
+			Gender:         ptrToNullString(ath.Gender),
+			GraduationYear: ptrToNullInt64(ath.GraduationYear),
+			JerseyNumber:   ptrToNullInt64(ath.JerseyNumber),
+		})
+		if err != nil {
+			return inSection("athletes", i, err)
+		}
+		im.athletes[ath.ID] = created.ID
+		im.created("athletes", key, created.ID)
+
+		if _, err := setAthleteEvents(ctx, im.q, created.ID, ath.Events); err != nil {
+			return inSection("athletes", i, err)
+		}
+		if ath.Profile != nil {
+			if _, err := im.q.UpsertAthleteProfile(ctx, db.UpsertAthleteProfileParams{
+				AthleteID:        created.ID,
+				Bio:              ptrToNullString(ath.Profile.Bio),
+				EmergencyContact: ptrToNullString(ath.Profile.EmergencyContact),
+				EmergencyPhone:   ptrToNullString(ath.Profile.EmergencyPhone),
+			}); err != nil {
+				return inSection("athletes", i, err)
+			}
+		}
+		for _, s := range ath.Statuses {
+			if _, err := im.q.CreateAthleteStatus(ctx, db.CreateAthleteStatusParams{
+				AthleteID: created.ID,
+				Status:    s.Status,
+				StartDate: s.StartDate,
+				EndDate:   ptrToNullString(s.EndDate),
+				Note:      ptrToNullString(s.Note),
+			}); err != nil {
+				return inSection("athletes", i, err)
+			}
+		}
+	}
+
+	for i, m := range a.Meets {
+		if strings.TrimSpace(m.Name) == "" {
+			return inSection("meets", i, &archiveError{"name is required"})
+		}
+		key := meetKey(m.Name, m.Date)
+		if id, ok := im.duplicate("meets", key, fmt.Sprintf("%q on %s", m.Name, stringOrEmpty(m.Date))); ok {
+			im.meets[m.ID] = id
+			continue
+		}
+		courseID, err := optionalRef(im.courses, "course", m.CourseID)
+		if err != nil {
+			return inSection("meets", i, err)
+		}
+		meet, err := im.q.CreateMeet(ctx, db.CreateMeetParams{
+			Name:         strings.TrimSpace(m.Name),
+			Date:         ptrToNullString(m.Date),
+			Location:     ptrToNullString(m.Location),
+			CourseID:     ptrToNullInt64(courseID),
+			StartTime:    ptrToNullString(m.StartTime),
+			BusDeparture: ptrToNullString(m.BusDeparture),
+			HostSchool:   ptrToNullString(m.HostSchool),
+		})
+		if err != nil {
+			return inSection("meets", i, err)
+		}
+		if m.Status != "" && m.Status != meetScheduled {
+			if _, err := im.q.UpdateMeetStatus(ctx, db.UpdateMeetStatusParams{Status: m.Status, ID: meet.ID}); err != nil {
+				return inSection("meets", i, err)
+			}
+		}
+		im.meets[m.ID] = meet.ID
+		im.created("meets", key, meet.ID)
+	}
+
+	for i, r := range a.Races {
+		meetID, err := ref(im.meets, "meet", r.MeetID)
+		if err != nil {
+			return inSection("races", i, err)
+		}
+		key := raceKey(meetID, r.Name)
+		if id, ok := im.duplicate("races", key, strconv.Quote(r.Name)); ok {
+			im.races[r.ID] = id
+			continue
+		}
+		race, err := im.q.CreateRace(ctx, db.CreateRaceParams{
+			MeetID:         meetID,
+			Name:           strings.TrimSpace(r.Name),
+			Gender:         ptrToNullString(r.Gender),
+			ScorerLimit:    r.ScorerLimit,
+			AlternateLimit: r.AlternateLimit,
+			EntryDeadline:  ptrToNullString(r.EntryDeadline),
+		})
+		if err != nil {
+			return inSection("races", i, err)
+		}
+		im.races[r.ID] = race.ID
+		im.created("races", key, race.ID)
+	}
+
+	for i, e := range a.Entries {
+		raceID, err := ref(im.races, "race", e.RaceID)
+		if err != nil {
+			return inSection("entries", i, err)
+		}
+		athleteID, err := ref(im.athletes, "athlete", e.AthleteID)
+		if err != nil {
+			return inSection("entries", i, err)
+		}
+		key := entryKey(raceID, athleteID)
+		if _, ok := im.duplicate("entries", key, fmt.Sprintf("athlete #%d in race #%d", athleteID, raceID)); ok {
+			continue
+		}
+		entry, err := im.q.CreateEntry(ctx, db.CreateEntryParams{
+			RaceID:    raceID,
+			AthleteID: athleteID,
+			Role:      e.Role,
+			Position:  e.Position,
+		})
+		if err != nil {
+			return inSection("entries", i, err)
+		}
+		im.created("entries", key, entry.ID)
+	}
+
+	for i, b := range a.Bibs {
+		meetID, err := ref(im.meets, "meet", b.MeetID)
+		if err != nil {
+			return inSection("bibs", i, err)
+		}
+		athleteID, err := ref(im.athletes, "athlete", b.AthleteID)
+		if err != nil {
+			return inSection("bibs", i, err)
+		}
+		byAthlete, byNumber := bibAthleteKey(meetID, athleteID), bibNumberKey(meetID, b.Bib)
+		if _, ok := im.duplicate("bibs", byAthlete, fmt.Sprintf("athlete #%d at meet #%d", athleteID, meetID)); ok {
+			continue
+		}
+		if _, ok := im.duplicate("bibs", byNumber, fmt.Sprintf("bib %d at meet #%d", b.Bib, meetID)); ok {
+			continue
+		}
+		bib, err := im.q.AssignBib(ctx, db.AssignBibParams{MeetID: meetID, AthleteID: athleteID, Bib: b.Bib})
+		if err != nil {
+			return inSection("bibs", i, err)
+		}
+		im.created("bibs", byAthlete, bib.ID)
+		im.existing[byNumber] = bib.ID
+	}
+
+	for i, r := range a.Results {
+		athleteID, err := optionalRef(im.athletes, "athlete", r.AthleteID)
+		if err != nil {
+			return inSection("results", i, err)
+		}
+		meetID, err := optionalRef(im.meets, "meet", r.MeetID)
+		if err != nil {
+			return inSection("results", i, err)
+		}
+		key := resultKey(athleteID, meetID, r.Time)
+		label := fmt.Sprintf("athlete #%s at meet #%s in %s", optionalInt64String(athleteID), optionalInt64String(meetID), stringOrEmpty(r.Time))
+		if id, ok := im.duplicate("results", key, label); ok {
+			im.results[r.ID] = id
+			continue
+		}
+		result, err := im.q.CreateResult(ctx, db.CreateResultParams{
+			AthleteID: ptrToNullInt64(athleteID),
+			MeetID:    ptrToNullInt64(meetID),
+			Time:      ptrToNullString(r.Time),
+			Place:     ptrToNullInt64(r.Place),
+		})
+		if err != nil {
+			return inSection("results", i, err)
+		}
+		im.results[r.ID] = result.ID
+		im.created("results", key, result.ID)
+
+		splits := append([]ArchiveSplit(nil), r.Splits...)
+		sort.Slice(splits, func(x, y int) bool { return splits[x].Index < splits[y].Index })
+		for _, s := range splits {
+			if _, err := im.q.CreateSplit(ctx, db.CreateSplitParams{
+				ResultID:       result.ID,
+				SplitIndex:     s.Index,
+				DistanceMeters: s.DistanceMeters,
+				Time:           s.Time,
+			}); err != nil {
+				return inSection("results", i, err)
+			}
+			im.report.Created["splits"]++
+		}
+	}
+
+	for i, b := range a.RecordBreaks {
+		key := recordBreakKey(b.Category, b.DistanceMeters, b.Gender, b.Grade, b.AthleteName, b.Time)
+		if _, ok := im.duplicate("recordBreaks", key, fmt.Sprintf("%s %s by %s", b.Category, b.Time, b.AthleteName)); ok {
+			continue
+		}
+		// A record break outlives the result and athlete it points at, so a
+		// missing reference is cleared rather than rejected.
+		resultID, _ := optionalRef(im.results, "result", b.ResultID)
+		athleteID, _ := optionalRef(im.athletes, "athlete", b.AthleteID)
+		brokenAt := b.BrokenAt
+		if brokenAt == "" {
+			brokenAt = time.Now().UTC().Format("2006-01-02 15:04:05")
+		}
+		created, err := im.q.ImportRecordBreak(ctx, db.ImportRecordBreakParams{
+			Category:       b.Category,
+			DistanceMeters: b.DistanceMeters,
+			Gender:         ptrToNullString(b.Gender),
+			Grade:          ptrToNullInt64(b.Grade),
+			ResultID:       ptrToNullInt64(resultID),
+			AthleteID:      ptrToNullInt64(athleteID),
+			AthleteName:    b.AthleteName,
+			Time:           b.Time,
+			PreviousHolder: ptrToNullString(b.PreviousHolder),
+			PreviousTime:   ptrToNullString(b.PreviousTime),
+			BrokenAt:       brokenAt,
+		})
+		if err != nil {
+			return inSection("recordBreaks", i, err)
+		}
+		im.created("recordBreaks", key, created.ID)
+	}
+
+	for i, m := range a.HistoricalMarks {
+		if strings.TrimSpace(m.AthleteName) == "" || m.DistanceMeters <= 0 || m.Time == "" {
+			return inSection("historicalMarks", i, &archiveError{"athleteName, a positive distanceMeters and time are required"})
+		}
+		key := historicalMarkKey(m.AthleteName, m.DistanceMeters, m.Time, m.Date)
+		if _, ok := im.duplicate("historicalMarks", key, fmt.Sprintf("%s by %s", m.Time, m.AthleteName)); ok {
+			continue
+		}
+		mark, err := im.q.CreateHistoricalMark(ctx, db.CreateHistoricalMarkParams{
+			AthleteName:    strings.TrimSpace(m.AthleteName),
+			Gender:         ptrToNullString(m.Gender),
+			Grade:          ptrToNullInt64(m.Grade),
+			DistanceMeters: m.DistanceMeters,
+			Time:           m.Time,
+			Date:           ptrToNullString(m.Date),
+			MeetName:       ptrToNullString(m.MeetName),
+			Notes:          ptrToNullString(m.Notes),
+		})
+		if err != nil {
+			return inSection("historicalMarks", i, err)
+		}
+		im.created("historicalMarks", key, mark.ID)
+	}
+
+	for i, p := range a.WorkoutPlans {
+		key := planKey(p.Date, p.Title)
+		planID, ok := im.duplicate("workoutPlans", key, fmt.Sprintf("%q on %s", p.Title, p.Date))
+		if !ok {
+			plan, err := im.q.CreateWorkoutPlan(ctx, db.CreateWorkoutPlanParams{
+				Date:            p.Date,
+				Type:            p.Type,
+				Title:           p.Title,
+				DistanceMiles:   ptrToNullFloat64(p.DistanceMiles),
+				DurationSeconds: ptrToNullInt64(p.DurationSeconds),
+				Description:     ptrToNullString(p.Description),
+			})
+			if err != nil {
+				return inSection("workoutPlans", i, err)
+			}
+			planID = plan.ID
+			im.created("workoutPlans", key, planID)
+		}
+		im.plans[p.ID] = planID
+
+		// Assignments are merged into a matched plan; adding one twice is a no-op.
+		for _, id := range p.AthleteIDs {
+			athleteID, err := ref(im.athletes, "athlete", id)
+			if err != nil {
+				return inSection("workoutPlans", i, err)
+			}
+			if err := im.q.AddWorkoutPlanAthlete(ctx, db.AddWorkoutPlanAthleteParams{PlanID: planID, AthleteID: athleteID}); err != nil {
+				return inSection("workoutPlans", i, err)
+			}
+		}
+	}
+
+	for i, w := range a.Workouts {
+		athleteID, err := ref(im.athletes, "athlete", w.AthleteID)
+		if err != nil {
+			return inSection("workouts", i, err)
+		}
+		planID, err := optionalRef(im.plans, "workout plan", w.PlanID)
+		if err != nil {
+			return inSection("workouts", i, err)
+		}
+		createdAt := w.CreatedAt
+		if createdAt == "" {
+			createdAt = time.Now().UTC().Format("2006-01-02 15:04:05")
+		}
+		key := workoutKey(athleteID, w.Date, w.Type, createdAt)
+		if _, ok := im.duplicate("workouts", key, fmt.Sprintf("%s run by athlete #%d on %s", w.Type, athleteID, w.Date)); ok {
+			continue
+		}
+		workout, err := im.q.ImportWorkout(ctx, db.ImportWorkoutParams{
+			AthleteID:       athleteID,
+			PlanID:          ptrToNullInt64(planID),
+			Date:            w.Date,
+			Type:            w.Type,
+			DistanceMiles:   ptrToNullFloat64(w.DistanceMiles),
+			DurationSeconds: ptrToNullInt64(w.DurationSeconds),
+			Rpe:             ptrToNullInt64(w.Rpe),
+			Notes:           ptrToNullString(w.Notes),
+			CreatedAt:       createdAt,
+		})
+		if err != nil {
+			return inSection("workouts", i, err)
+		}
+		im.created("workouts", key, workout.ID)
+	}
+
+	for i, s := range a.PracticeSessions {
+		meetID, err := optionalRef(im.meets, "meet", s.MeetID)
+		if err != nil {
+			return inSection("practiceSessions", i, err)
+		}
+		key := sessionKey(s.Date, s.Type, meetID)
+		if id, ok := im.duplicate("practiceSessions", key, fmt.Sprintf("%s on %s", s.Type, s.Date)); ok {
+			im.sessions[s.ID] = id
+			continue
+		}
+		session, err := im.q.CreatePracticeSession(ctx, db.CreatePracticeSessionParams{
+			Date:   s.Date,
+			Type:   s.Type,
+			MeetID: ptrToNullInt64(meetID),
+			Notes:  ptrToNullString(s.Notes),
+		})
+		if err != nil {
+			return inSection("practiceSessions", i, err)
+		}
+		im.sessions[s.ID] = session.ID
+		im.created("practiceSessions", key, session.ID)
+	}
+
+	for i, rec := range a.Attendance {
+		sessionID, err := ref(im.sessions, "practice session", rec.SessionID)
+		if err != nil {
+			return inSection("attendance", i, err)
+		}
+		athleteID, err := ref(im.athletes, "athlete", rec.AthleteID)
+		if err != nil {
+			return inSection("attendance", i, err)
+		}
+		key := attendanceKey(sessionID, athleteID)
+		if _, ok := im.duplicate("attendance", key, fmt.Sprintf("athlete #%d at session #%d", athleteID, sessionID)); ok {
+			continue
+		}
+		record, err := im.q.UpsertAttendance(ctx, db.UpsertAttendanceParams{
+			SessionID: sessionID,
+			AthleteID: athleteID,
+			Status:    rec.Status,
+			Note:      ptrToNullString(rec.Note),
+		})
+		if err != nil {
+			return inSection("attendance", i, err)
+		}
+		im.created("attendance", key, record.ID)
+	}
+
+	return nil
+}
+
+// --- Handlers ---
+
+// ExportArchive downloads the full data archive.
+func (s *Server) ExportArchive(c *gin.Context) {
+	ctx := c.Request.Context()
+	archive, err := s.buildArchive(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	name := "jones-county-xc-" + time.Now().Format("20060102") + ".json"
+	c.Header("Content-Disposition", `attachment; filename="`+name+`"`)
+	c.JSON(200, archive)
+}
+
+// ImportArchive loads an archive sent as the request body or as a "file"
+// upload. ?dryRun=true reports what would happen without saving, and
+// ?onDuplicate=fail rejects the archive if anything already exists.
+func (s *Server) ImportArchive(c *gin.Context) {
+	ctx := c.Request.Context()
+	dryRun := c.Query("dryRun") == "true"
+	onDuplicate := c.DefaultQuery("onDuplicate", "skip")
+	if onDuplicate != "skip" && onDuplicate != "fail" {
+		c.JSON(400, gin.H{"error": "onDuplicate must be skip or fail"})
+		return
+	}
+
+	var body io.Reader = c.Request.Body
+	if c.ContentType() == "multipart/form-data" {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		defer f.Close()
+		body = f
+	}
+
+	archive, err := decodeArchive(body)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := s.importArchive(ctx, archive, dryRun, onDuplicate == "fail")
+	if errors.Is(err, errArchiveDuplicates) {
+		c.JSON(409, gin.H{"error": err.Error(), "duplicates": report.Duplicates, "details": report.Details})
+		return
+	}
+	var ae *archiveError
+	if errors.As(err, &ae) {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	status := 201
+	if dryRun {
+		status = 200
+	}
+	c.JSON(status, report)
+}
+
+// --- CLI ---
+
+// runExportArchiveCommand implements `export archive [-o FILE]`.
+func runExportArchiveCommand(s *Server, args []string) error {
+	fs := flag.NewFlagSet("export archive", flag.ContinueOnError)
+	out := fs.String("o", "-", "output file (- for stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	archive, err := s.buildArchive(context.Background())
+	if err != nil {
+		return err
+	}
+	w, err := createOutput(*out)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(archive); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if *out != "-" {
+		fmt.Fprintf(os.Stderr, "Exported %d athletes, %d meets and %d results to %s\n",
+			len(archive.Athletes), len(archive.Meets), len(archive.Results), *out)
+	}
+	return nil
+}
+
+// runImportArchiveCommand implements `import archive [-dry-run]
+// [-on-duplicate skip|fail] FILE`.
+func runImportArchiveCommand(s *Server, args []string) error {
+	fs := flag.NewFlagSet("import archive", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "report what would be imported without saving")
+	onDuplicate := fs.String("on-duplicate", "skip", "skip rows that already exist, or fail the whole import")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: import archive [-dry-run] [-on-duplicate skip|fail] FILE")
+	}
+	if *onDuplicate != "skip" && *onDuplicate != "fail" {
+		return fmt.Errorf("-on-duplicate must be skip or fail")
+	}
+
+	in, err := openInput(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	archive, err := decodeArchive(in)
+	if err != nil {
+		return err
+	}
+
+	report, err := s.importArchive(context.Background(), archive, *dryRun, *onDuplicate == "fail")
+	for _, d := range report.Details {
+		fmt.Println("duplicate", d)
+	}
+	if err != nil {
+		return err
+	}
+
+	sections := make([]string, 0, len(report.Created)+len(report.Duplicates))
+	seen := map[string]bool{}
+	for _, m := range []map[string]int{report.Created, report.Duplicates} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				sections = append(sections, k)
+			}
+		}
+	}
+	sort.Strings(sections)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SECTION\tCREATED\tDUPLICATES")
+	for _, k := range sections {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", k, report.Created[k], report.Duplicates[k])
+	}
+	tw.Flush()
+	if *dryRun {
+		fmt.Println("Dry run: nothing was saved.")
+	}
+	return nil
+}
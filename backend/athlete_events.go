@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+)
+
+type EventResponse struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	AthleteCount int64  `json:"athleteCount"`
+}
+
+// GetEvents lists the event catalog with the number of athletes in each.
+func (s *Server) GetEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	events, err := s.store.GetAllEvents(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]EventResponse, len(events))
+	for i, e := range events {
+		response[i] = EventResponse{ID: e.ID, Name: e.Name, AthleteCount: e.AthleteCount}
+	}
+	c.JSON(200, response)
+}
+
+// athleteEventNames maps each athlete ID to its event names, sorted by name.
+func (s *Server) athleteEventNames(ctx context.Context) (map[int64][]string, error) {
+	rows, err := s.store.GetAllAthleteEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := map[int64][]string{}
+	for _, r := range rows {
+		events[r.AthleteID] = append(events[r.AthleteID], r.Name)
+	}
+	return events, nil
+}
+
+// setAthleteEvents replaces an athlete's events, adding names missing from
+// the catalog. Names are trimmed and matched case-insensitively, so "5k"
+// links to an existing "5K". It returns the stored names in catalog order.
+func setAthleteEvents(ctx context.Context, q db.Querier, athleteID int64, names []string) ([]string, error) {
+	if err := q.DeleteAthleteEvents(ctx, athleteID); err != nil {
+		return nil, err
+	}
+
+	stored := []string{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+
+		event, err := q.UpsertEvent(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := q.AddAthleteEvent(ctx, db.AddAthleteEventParams{
+			AthleteID: athleteID,
+			EventID:   event.ID,
+		}); err != nil {
+			return nil, err
+		}
+		stored = append(stored, event.Name)
+	}
+	sort.Slice(stored, func(i, j int) bool {
+		return strings.ToLower(stored[i]) < strings.ToLower(stored[j])
+	})
+	return stored, nil
+}
+
+// hasEvent reports whether names contains event, ignoring case like the
+// events table does.
+func hasEvent(names []string, event string) bool {
+	for _, name := range names {
+		if strings.EqualFold(name, event) {
+			return true
+		}
+	}
+	return false
+}
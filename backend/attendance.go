@@ -0,0 +1,618 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+)
+
+const (
+	attendancePresent = "present"
+	attendanceAbsent  = "absent"
+	attendanceExcused = "excused"
+	attendanceInjured = "injured"
+
+	sessionPractice = "practice"
+	sessionMeet     = "meet"
+)
+
+type SessionResponse struct {
+	ID     int64          `json:"id"`
+	Date   string         `json:"date"`
+	Type   string         `json:"type"`
+	MeetID *int64         `json:"meetId"`
+	Notes  *string        `json:"notes"`
+	Counts map[string]int `json:"counts"`
+}
+
+type AttendanceResponse struct {
+	ID          int64   `json:"id"`
+	SessionID   int64   `json:"sessionId"`
+	AthleteID   int64   `json:"athleteId"`
+	AthleteName string  `json:"athleteName"`
+	Status      string  `json:"status"`
+	Note        *string `json:"note"`
+}
+
+type SessionDetailResponse struct {
+	SessionResponse
+	Attendance []AttendanceResponse `json:"attendance"`
+}
+
+// AttendanceSummary counts one athlete's records over a season. Percentage
+// is present / (present + absent): excused and injured days do not count
+// against the athlete. It is null until there is a present or absent record.
+type AttendanceSummary struct {
+	AthleteID  int64    `json:"athleteId"`
+	Name       string   `json:"name"`
+	Season     string   `json:"season"`
+	Sessions   int      `json:"sessions"`
+	Recorded   int      `json:"recorded"`
+	Present    int      `json:"present"`
+	Absent     int      `json:"absent"`
+	Excused    int      `json:"excused"`
+	Injured    int      `json:"injured"`
+	Percentage *float64 `json:"percentage"`
+}
+
+type AthleteAttendanceResponse struct {
+	AttendanceSummary
+	Records []AthleteAttendanceRecord `json:"records"`
+}
+
+type AthleteAttendanceRecord struct {
+	SessionID int64   `json:"sessionId"`
+	Date      string  `json:"date"`
+	Type      string  `json:"type"`
+	Status    string  `json:"status"`
+	Note      *string `json:"note"`
+}
+
+type sessionInput struct {
+	Date   string  `json:"date"`
+	Type   string  `json:"type"`
+	MeetID *int64  `json:"meetId"`
+	Notes  *string `json:"notes"`
+}
+
+type attendanceInput struct {
+	Records []struct {
+		AthleteID int64   `json:"athleteId"`
+		Status    string  `json:"status"`
+		Note      *string `json:"note"`
+	} `json:"records"`
+}
+
+// --- Session handlers ---
+
+// GetSessions lists practice and meet sessions for a season (?season=YYYY,
+// default the current year), oldest first, with a count of each attendance
+// status. ?type narrows to practice or meet sessions.
+func (s *Server) GetSessions(c *gin.Context) {
+	ctx := c.Request.Context()
+	_, from, to, ok := seasonQuery(c)
+	if !ok {
+		return
+	}
+	kind := c.Query("type")
+	if kind != "" && kind != sessionPractice && kind != sessionMeet {
+		c.JSON(400, gin.H{"error": "type must be practice or meet"})
+		return
+	}
+
+	sessions, err := s.store.GetPracticeSessions(ctx, db.GetPracticeSessionsParams{
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	records, err := s.store.GetAttendanceBetween(ctx, db.GetAttendanceBetweenParams{
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	counts := map[int64]map[string]int{}
+	for _, r := range records {
+		if counts[r.SessionID] == nil {
+			counts[r.SessionID] = map[string]int{}
+		}
+		counts[r.SessionID][r.Status]++
+	}
+
+	response := []SessionResponse{}
+	for _, ps := range sessions {
+		if kind != "" && ps.Type != kind {
+			continue
+		}
+		response = append(response, sessionResponse(ps, counts[ps.ID]))
+	}
+	c.JSON(200, response)
+}
+
+// GetSession returns a session with every attendance record, by athlete name.
+func (s *Server) GetSession(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var sessionID int64
+	if _, err := fmt.Sscanf(id, "%d", &sessionID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid session ID"})
+		return
+	}
+
+	response, err := s.sessionDetail(ctx, sessionID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "session not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) CreateSession(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input sessionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg, err := input.validate(ctx, s.store); err != nil {
+		serverError(c, err)
+		return
+	} else if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	session, err := s.store.CreatePracticeSession(ctx, db.CreatePracticeSessionParams{
+		Date:   input.Date,
+		Type:   input.Type,
+		MeetID: ptrToNullInt64(input.MeetID),
+		Notes:  ptrToNullString(input.Notes),
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(201, sessionResponse(session, nil))
+}
+
+func (s *Server) UpdateSession(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var sessionID int64
+	if _, err := fmt.Sscanf(id, "%d", &sessionID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid session ID"})
+		return
+	}
+
+	var input sessionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg, err := input.validate(ctx, s.store); err != nil {
+		serverError(c, err)
+		return
+	} else if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	_, err := s.store.UpdatePracticeSession(ctx, db.UpdatePracticeSessionParams{
+		Date:   input.Date,
+		Type:   input.Type,
+		MeetID: ptrToNullInt64(input.MeetID),
+		Notes:  ptrToNullString(input.Notes),
+		ID:     sessionID,
+	})
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "session not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response, err := s.sessionDetail(ctx, sessionID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) DeleteSession(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var sessionID int64
+	if _, err := fmt.Sscanf(id, "%d", &sessionID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid session ID"})
+		return
+	}
+
+	n, err := s.store.DeletePracticeSession(ctx, sessionID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "session not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "session deleted"})
+}
+
+// --- Attendance handlers ---
+
+// SetAttendance records attendance for the listed athletes, replacing any
+// earlier record for the same athlete. Athletes not listed are unchanged.
+// The batch is written in one transaction.
+func (s *Server) SetAttendance(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var sessionID int64
+	if _, err := fmt.Sscanf(id, "%d", &sessionID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid session ID"})
+		return
+	}
+	if _, err := s.store.GetPracticeSessionByID(ctx, sessionID); err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "session not found"})
+		return
+	} else if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	var input attendanceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	seen := map[int64]bool{}
+	for _, r := range input.Records {
+		if !validAttendanceStatus(r.Status) {
+			c.JSON(400, gin.H{"error": "status must be one of present, absent, excused, injured"})
+			return
+		}
+		if seen[r.AthleteID] {
+			c.JSON(400, gin.H{"error": fmt.Sprintf("athlete %d is listed more than once", r.AthleteID)})
+			return
+		}
+		seen[r.AthleteID] = true
+	}
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	for _, r := range input.Records {
+		if _, err := tx.GetAthleteByID(ctx, r.AthleteID); err == sql.ErrNoRows {
+			c.JSON(400, gin.H{"error": fmt.Sprintf("athlete %d not found", r.AthleteID)})
+			return
+		} else if err != nil {
+			serverError(c, err)
+			return
+		}
+		if _, err := tx.UpsertAttendance(ctx, db.UpsertAttendanceParams{
+			SessionID: sessionID,
+			AthleteID: r.AthleteID,
+			Status:    r.Status,
+			Note:      ptrToNullString(r.Note),
+		}); err != nil {
+			serverError(c, err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response, err := s.sessionDetail(ctx, sessionID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) DeleteAttendance(c *gin.Context) {
+	ctx := c.Request.Context()
+	var sessionID, athleteID int64
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &sessionID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid session ID"})
+		return
+	}
+	if _, err := fmt.Sscanf(c.Param("athleteId"), "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+
+	n, err := s.store.DeleteAttendance(ctx, db.DeleteAttendanceParams{
+		SessionID: sessionID,
+		AthleteID: athleteID,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "attendance record not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "attendance deleted"})
+}
+
+// --- Summary handlers ---
+
+// GetAttendanceSummary reports every athlete with a record this season
+// (?season=YYYY), by name.
+func (s *Server) GetAttendanceSummary(c *gin.Context) {
+	ctx := c.Request.Context()
+	season, from, to, ok := seasonQuery(c)
+	if !ok {
+		return
+	}
+
+	summaries, _, err := s.attendanceSummaries(ctx, season, from, to)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	athletes, err := s.store.GetAllAthletes(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := []AttendanceSummary{}
+	for _, a := range athletes {
+		if sum, ok := summaries[a.ID]; ok {
+			sum.Name = a.Name
+			response = append(response, *sum)
+		}
+	}
+	sort.SliceStable(response, func(i, j int) bool {
+		return strings.ToLower(response[i].Name) < strings.ToLower(response[j].Name)
+	})
+	c.JSON(200, response)
+}
+
+// GetAthleteAttendance returns one athlete's season summary and records,
+// oldest first.
+func (s *Server) GetAthleteAttendance(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+	athlete, err := s.store.GetAthleteByID(ctx, athleteID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "athlete not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	season, from, to, ok := seasonQuery(c)
+	if !ok {
+		return
+	}
+
+	summaries, sessions, err := s.attendanceSummaries(ctx, season, from, to)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	summary, ok := summaries[athleteID]
+	if !ok {
+		summary = &AttendanceSummary{AthleteID: athleteID, Season: season, Sessions: sessions}
+	}
+	summary.Name = athlete.Name
+
+	records, err := s.store.GetAttendanceBetween(ctx, db.GetAttendanceBetweenParams{
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	response := AthleteAttendanceResponse{
+		AttendanceSummary: *summary,
+		Records:           []AthleteAttendanceRecord{},
+	}
+	for _, r := range records {
+		if r.AthleteID != athleteID {
+			continue
+		}
+		response.Records = append(response.Records, AthleteAttendanceRecord{
+			SessionID: r.SessionID,
+			Date:      r.Date,
+			Type:      r.Type,
+			Status:    r.Status,
+			Note:      nullStringToPtr(r.Note),
+		})
+	}
+	c.JSON(200, response)
+}
+
+// --- Helpers ---
+
+// seasonQuery reads ?season (a year, default the current one) and returns
+// it with the first and last dates of that year. On a bad value it writes
+// a 400 response and returns false.
+func seasonQuery(c *gin.Context) (string, string, string, bool) {
+	season := c.Query("season")
+	if season == "" {
+		season = strconv.Itoa(time.Now().In(meetLocation).Year())
+	}
+	year, err := strconv.Atoi(season)
+	if err != nil || year < 1900 || year > 9999 {
+		c.JSON(400, gin.H{"error": "season must be a year"})
+		return "", "", "", false
+	}
+	return season, season + "-01-01", season + "-12-31", true
+}
+
+// attendanceSummaries counts each athlete's records over a date range. It
+// also returns the number of sessions in the range.
+func (s *Server) attendanceSummaries(ctx context.Context, season, from, to string) (map[int64]*AttendanceSummary, int, error) {
+	sessions, err := s.store.GetPracticeSessions(ctx, db.GetPracticeSessionsParams{
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	records, err := s.store.GetAttendanceBetween(ctx, db.GetAttendanceBetweenParams{
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	summaries := map[int64]*AttendanceSummary{}
+	for _, r := range records {
+		sum, ok := summaries[r.AthleteID]
+		if !ok {
+			sum = &AttendanceSummary{AthleteID: r.AthleteID, Season: season, Sessions: len(sessions)}
+			summaries[r.AthleteID] = sum
+		}
+		sum.Recorded++
+		switch r.Status {
+		case attendancePresent:
+			sum.Present++
+		case attendanceAbsent:
+			sum.Absent++
+		case attendanceExcused:
+			sum.Excused++
+		case attendanceInjured:
+			sum.Injured++
+		}
+	}
+	for _, sum := range summaries {
+		if counted := sum.Present + sum.Absent; counted > 0 {
+			pct := math.Round(float64(sum.Present)/float64(counted)*1000) / 10
+			sum.Percentage = &pct
+		}
+	}
+	return summaries, len(sessions), nil
+}
+
+// validate checks a session, defaulting its type to practice and, for a
+// meet session, its date to the meet's. It returns an error message, or ""
+// if the input is valid; the error is only set when the meet couldn't be
+// loaded.
+func (in *sessionInput) validate(ctx context.Context, q db.Querier) (string, error) {
+	if in.Type == "" {
+		in.Type = sessionPractice
+	}
+	if in.Type != sessionPractice && in.Type != sessionMeet {
+		return "type must be practice or meet", nil
+	}
+	if in.MeetID != nil {
+		if in.Type != sessionMeet {
+			return "meetId is only allowed on meet sessions", nil
+		}
+		meet, err := q.GetMeetByID(ctx, *in.MeetID)
+		if err == sql.ErrNoRows {
+			return "meet not found", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if in.Date == "" && meet.Date.Valid {
+			in.Date = meet.Date.String
+		}
+	}
+	if in.Date == "" {
+		return "date is required", nil
+	}
+	date, err := normalizeDate(in.Date)
+	if err != nil {
+		return err.Error(), nil
+	}
+	in.Date = date
+	return "", nil
+}
+
+func validAttendanceStatus(s string) bool {
+	switch s {
+	case attendancePresent, attendanceAbsent, attendanceExcused, attendanceInjured:
+		return true
+	}
+	return false
+}
+
+func (s *Server) sessionDetail(ctx context.Context, sessionID int64) (SessionDetailResponse, error) {
+	session, err := s.store.GetPracticeSessionByID(ctx, sessionID)
+	if err != nil {
+		return SessionDetailResponse{}, err
+	}
+	records, err := s.store.GetAttendanceBySession(ctx, sessionID)
+	if err != nil {
+		return SessionDetailResponse{}, err
+	}
+
+	counts := map[string]int{}
+	response := SessionDetailResponse{Attendance: make([]AttendanceResponse, len(records))}
+	for i, r := range records {
+		counts[r.Status]++
+		response.Attendance[i] = AttendanceResponse{
+			ID:          r.ID,
+			SessionID:   r.SessionID,
+			AthleteID:   r.AthleteID,
+			AthleteName: r.AthleteName,
+			Status:      r.Status,
+			Note:        nullStringToPtr(r.Note),
+		}
+	}
+	response.SessionResponse = sessionResponse(session, counts)
+	return response, nil
+}
+
+func sessionResponse(s db.PracticeSession, counts map[string]int) SessionResponse {
+	full := map[string]int{
+		attendancePresent: 0,
+		attendanceAbsent:  0,
+		attendanceExcused: 0,
+		attendanceInjured: 0,
+	}
+	for status, n := range counts {
+		full[status] = n
+	}
+	return SessionResponse{
+		ID:     s.ID,
+		Date:   s.Date,
+		Type:   s.Type,
+		MeetID: nullInt64ToPtr(s.MeetID),
+		Notes:  nullStringToPtr(s.Notes),
+		Counts: full,
+	}
+}
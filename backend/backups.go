@@ -0,0 +1,465 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Backups are taken with VACUUM INTO, which writes a consistent, compacted
+// copy while the server keeps running. Each copy is integrity-checked
+// before it is kept and again before it is restored; listing only reads the
+// schema version. Only the newest BACKUP_KEEP are retained.
+
+const backupTimeLayout = "20060102-150405"
+
+// BackupResponse describes one backup file. Valid is false, with Error
+// set, when the file cannot be read as a database.
+type BackupResponse struct {
+	Name          string  `json:"name"`
+	SizeBytes     int64   `json:"sizeBytes"`
+	CreatedAt     string  `json:"createdAt"`
+	SchemaVersion int     `json:"schemaVersion"`
+	Valid         bool    `json:"valid"`
+	Error         *string `json:"error,omitempty"`
+}
+
+// backupConfig is read from the environment.
+type backupConfig struct {
+	Dir      string        // BACKUP_DIR, default "backups" next to the database
+	Interval time.Duration // BACKUP_INTERVAL, default 24h; 0 turns scheduling off
+	Keep     int           // BACKUP_KEEP, default 14
+}
+
+// backupMu keeps scheduled and manual backups from overlapping.
+var backupMu sync.Mutex
+
+func loadBackupConfig() (backupConfig, error) {
+	cfg := backupConfig{
+		Dir:      os.Getenv("BACKUP_DIR"),
+		Interval: 24 * time.Hour,
+		Keep:     14,
+	}
+	if cfg.Dir == "" {
+		cfg.Dir = filepath.Join(filepath.Dir(dbPath), "backups")
+	}
+	if v := os.Getenv("BACKUP_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return cfg, fmt.Errorf("invalid BACKUP_INTERVAL %q", v)
+		}
+		cfg.Interval = d
+	}
+	if v := os.Getenv("BACKUP_KEEP"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return cfg, fmt.Errorf("invalid BACKUP_KEEP %q", v)
+		}
+		cfg.Keep = n
+	}
+	return cfg, nil
+}
+
+// backupPrefix is the file name prefix for scheduled and on-demand backups,
+// e.g. "data-" for data.db. Rotation only deletes files with this prefix.
+func backupPrefix() string {
+	return strings.TrimSuffix(filepath.Base(dbPath), filepath.Ext(dbPath)) + "-"
+}
+
+// createBackup writes a new backup into cfg.Dir, checks it and prunes old
+// backups.
+func (s *Server) createBackup(ctx context.Context, cfg backupConfig) (BackupResponse, error) {
+	backupMu.Lock()
+	defer backupMu.Unlock()
+
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return BackupResponse{}, err
+	}
+	name := backupPrefix() + time.Now().UTC().Format(backupTimeLayout) + ".db"
+	path := filepath.Join(cfg.Dir, name)
+	if err := s.writeBackup(ctx, path); err != nil {
+		return BackupResponse{}, err
+	}
+	if err := pruneBackups(cfg); err != nil {
+		log.Printf("Pruning backups failed: %v", err)
+	}
+	return backupInfo(path)
+}
+
+// writeBackup copies the open database to path and verifies the copy. A
+// partial or corrupt file is removed.
+func (s *Server) writeBackup(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	tmp := path + ".partial"
+	os.Remove(tmp)
+	if err := s.store.Backup(ctx, tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if _, err := checkDatabaseFile(tmp); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("backup failed its integrity check: %v", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// checkDatabaseFile opens a database file read-only, runs SQLite's
+// integrity check and returns its schema version.
+func checkDatabaseFile(path string) (int, error) {
+	conn, err := openReadOnly(path)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	var result string
+	if err := conn.QueryRow("PRAGMA integrity_check").Scan(&result); err != nil {
+		return 0, err
+	}
+	if result != "ok" {
+		return 0, errors.New(result)
+	}
+	return schemaVersion(conn)
+}
+
+// fileSchemaVersion reads a database file's schema version without the
+// full integrity check, which reads every page.
+func fileSchemaVersion(path string) (int, error) {
+	conn, err := openReadOnly(path)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	return schemaVersion(conn)
+}
+
+func openReadOnly(path string) (*sql.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	return sql.Open("sqlite", "file:"+path+"?mode=ro")
+}
+
+// listBackups returns the backups in cfg.Dir, newest first.
+func listBackups(cfg backupConfig) ([]BackupResponse, error) {
+	names, err := backupNames(cfg)
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]BackupResponse, 0, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		b, err := backupInfo(filepath.Join(cfg.Dir, names[i]))
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, b)
+	}
+	return backups, nil
+}
+
+// backupNames lists backup file names oldest first. The timestamp in the
+// name sorts chronologically.
+func backupNames(cfg backupConfig) ([]string, error) {
+	entries, err := os.ReadDir(cfg.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), backupPrefix()) && strings.HasSuffix(e.Name(), ".db") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func pruneBackups(cfg backupConfig) error {
+	names, err := backupNames(cfg)
+	if err != nil {
+		return err
+	}
+	for len(names) > cfg.Keep {
+		if err := os.Remove(filepath.Join(cfg.Dir, names[0])); err != nil {
+			return err
+		}
+		log.Printf("Removed old backup %s", names[0])
+		names = names[1:]
+	}
+	return nil
+}
+
+// backupInfo describes a backup file. A file that is not a readable
+// database is reported as invalid rather than failing the listing.
+func backupInfo(path string) (BackupResponse, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return BackupResponse{}, err
+	}
+	b := BackupResponse{
+		Name:      filepath.Base(path),
+		SizeBytes: info.Size(),
+		CreatedAt: info.ModTime().UTC().Format(time.RFC3339),
+		Valid:     true,
+	}
+	if b.SchemaVersion, err = fileSchemaVersion(path); err != nil {
+		msg := err.Error()
+		b.Valid, b.Error = false, &msg
+	}
+	return b, nil
+}
+
+// startBackupScheduler takes a backup every cfg.Interval. The first one is
+// taken right away if the newest backup is already older than that.
+func (s *Server) startBackupScheduler(cfg backupConfig) {
+	if cfg.Interval == 0 {
+		log.Println("BACKUP_INTERVAL is 0; scheduled backups are off")
+		return
+	}
+	log.Printf("Backing up to %s every %s, keeping %d", cfg.Dir, cfg.Interval, cfg.Keep)
+
+	wait := time.Duration(0)
+	if names, err := backupNames(cfg); err == nil && len(names) > 0 {
+		if info, err := os.Stat(filepath.Join(cfg.Dir, names[len(names)-1])); err == nil {
+			wait = cfg.Interval - time.Since(info.ModTime())
+		}
+	}
+	go func() {
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+		for {
+			b, err := s.createBackup(context.Background(), cfg)
+			if err != nil {
+				log.Printf("Scheduled backup failed: %v", err)
+			} else {
+				log.Printf("Backup %s written (%d bytes)", b.Name, b.SizeBytes)
+			}
+			time.Sleep(cfg.Interval)
+		}
+	}()
+}
+
+// --- Admin handlers ---
+
+func (s *Server) GetBackups(c *gin.Context) {
+	cfg, err := loadBackupConfig()
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	backups, err := listBackups(cfg)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, backups)
+}
+
+// CreateBackup takes a backup now, outside the schedule.
+func (s *Server) CreateBackup(c *gin.Context) {
+	cfg, err := loadBackupConfig()
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	backup, err := s.createBackup(c.Request.Context(), cfg)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(201, backup)
+}
+
+// --- CLI ---
+
+// runBackupCommand implements `backup [-o FILE]` and `backup list`. Without
+// -o the backup goes to the backup directory and old ones are pruned.
+func runBackupCommand(s *Server, args []string) error {
+	cfg, err := loadBackupConfig()
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 && args[0] == "list" {
+		backups, err := listBackups(cfg)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tSIZE\tCREATED\tSCHEMA")
+		for _, b := range backups {
+			schema := strconv.Itoa(b.SchemaVersion)
+			if !b.Valid {
+				schema = "invalid: " + *b.Error
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", b.Name, b.SizeBytes, b.CreatedAt, schema)
+		}
+		return tw.Flush()
+	}
+
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	out := fs.String("o", "", "write a one-off backup to this file instead of the backup directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *out != "" {
+		backupMu.Lock()
+		defer backupMu.Unlock()
+		if err := s.writeBackup(context.Background(), *out); err != nil {
+			return err
+		}
+		info, err := os.Stat(*out)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Backed up %s to %s (%d bytes)\n", dbPath, *out, info.Size())
+		return nil
+	}
+
+	b, err := s.createBackup(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Backed up %s to %s (%d bytes, schema version %d)\n", dbPath, filepath.Join(cfg.Dir, b.Name), b.SizeBytes, b.SchemaVersion)
+	return nil
+}
+
+// runRestoreCommand replaces the database with a backup. The server must be
+// stopped first. The backup must pass an integrity check and must not have
+// a newer schema than this binary knows; older schemas are migrated on the
+// next start. The current database is saved alongside the backups first.
+func runRestoreCommand(_ *Server, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: restore FILE")
+	}
+	src := fs.Arg(0)
+
+	version, err := checkDatabaseFile(src)
+	if err != nil {
+		return fmt.Errorf("%s is not a usable backup: %v", src, err)
+	}
+	latest, err := latestSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		return fmt.Errorf("%s has no schema; it is not a backup of this app", src)
+	}
+	if version > latest {
+		return fmt.Errorf("%s has schema version %d but this binary only knows up to %d; restore it with a newer build", src, version, latest)
+	}
+	// SQLite removes these when the last connection closes, so they mean
+	// the server is still running (or crashed and needs a clean restart).
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if _, err := os.Stat(dbPath + suffix); err == nil {
+			return fmt.Errorf("%s%s exists; stop the server before restoring", dbPath, suffix)
+		}
+	}
+
+	cfg, err := loadBackupConfig()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dbPath); err == nil {
+		saved, err := saveBeforeRestore(cfg)
+		if err != nil {
+			return fmt.Errorf("saving the current database: %v", err)
+		}
+		fmt.Printf("Saved the current database to %s\n", saved)
+	}
+
+	// Copy next to the target and rename, so the swap itself is atomic.
+	tmp := dbPath + ".restore"
+	if err := copyFile(src, tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	os.Remove(dbPath + "-wal")
+	os.Remove(dbPath + "-shm")
+	if err := os.Rename(tmp, dbPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored %s from %s (schema version %d)\n", dbPath, src, version)
+	if version < latest {
+		fmt.Printf("Migrations %d-%d will be applied when the server starts.\n", version+1, latest)
+	}
+	return nil
+}
+
+// saveBeforeRestore copies the current database into the backup directory
+// under a name that rotation leaves alone. The database being replaced is
+// often damaged, so when VACUUM INTO cannot read it the file is copied
+// byte for byte instead.
+func saveBeforeRestore(cfg backupConfig) (string, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(cfg.Dir, "pre-restore-"+time.Now().UTC().Format(backupTimeLayout)+".db")
+	err := vacuumInto(dbPath, path)
+	if err == nil {
+		return path, nil
+	}
+	os.Remove(path)
+	fmt.Printf("Could not copy the current database with VACUUM INTO (%v); copying the file as is\n", err)
+	if err := copyFile(dbPath, path); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+func vacuumInto(src, dst string) error {
+	conn, err := openDatabase(src)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = conn.Exec("VACUUM INTO ?", dst)
+	return err
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,522 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"jones-county-xc/backend/db"
+)
+
+// The backend binary is also the admin tool. With no command it serves the
+// API; the other commands work on the database directly through the Server's
+// store and are meant for ops tasks on the server, for example:
+//
+//	./server -db /var/www/jones-county-xc/backend/data.db user list
+
+// dbPath is set by the global -db flag.
+var dbPath string
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(s *Server, args []string) error
+	ownsDB  bool // the command opens the database itself and gets a nil Server
+}
+
+var commands = []command{
+	{name: "serve", usage: "serve [-addr :8080]", run: runServeCommand, summary: "run the HTTP API (the default)"},
+	{name: "migrate", usage: "migrate [-status]", run: runMigrateCommand, ownsDB: true, summary: "apply pending schema migrations"},
+	{name: "user", usage: "user add|reset-password|list [flags]", run: runUserCommand, summary: "manage athlete and parent accounts"},
+	{name: "import", usage: "import results -meet ID FILE | archive FILE", run: runImportCommand, summary: "load results CSV or a data archive (- reads stdin)"},
+	{name: "export", usage: "export results -meet ID | roster | archive [-o FILE]", run: runExportCommand, summary: "write results or the roster as CSV, or a data archive"},
+	{name: "backup", usage: "backup [-o FILE] | list", run: runBackupCommand, summary: "back up the database while it is in use"},
+	{name: "restore", usage: "restore FILE", run: runRestoreCommand, ownsDB: true, summary: "replace the database with a backup (server stopped)"},
+	{name: "seed", usage: "seed [-seed N] [-athletes N] [-today DATE] [-force]", run: runSeedCommand, summary: "fill an empty database with a generated season"},
+	{name: "rollover", usage: "rollover [-year N] [-apply]", run: runRolloverCommand, summary: "close a school year"},
+}
+
+func printUsage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, "Usage: %s [-db FILE] [command] [flags]\n\nCommands:\n", os.Args[0])
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", c.usage, c.summary)
+	}
+	tw.Flush()
+	fmt.Fprintln(w, "\nGlobal flags:")
+	flag.PrintDefaults()
+	fmt.Fprintf(w, "\nRun '%s COMMAND -h' for a command's flags.\n", os.Args[0])
+}
+
+// runCommand dispatches to a command. The database is opened and migrated
+// first unless the command manages it itself.
+func runCommand(args []string) error {
+	if len(args) == 0 {
+		args = []string{"serve"}
+	}
+	name, args := args[0], args[1:]
+	if name == "help" {
+		printUsage()
+		return nil
+	}
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		var s *Server
+		if !c.ownsDB {
+			store, err := openStore(dbPath)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			s = NewServer(store)
+		}
+		return c.run(s, args)
+	}
+	printUsage()
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func runServeCommand(s *Server, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return serve(s, *addr)
+}
+
+// runMigrateCommand applies pending migrations, or with -status only lists
+// them. Every other command also migrates on start, so this is mainly for
+// checking a database before deploying.
+func runMigrateCommand(_ *Server, args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	status := fs.Bool("status", false, "show the schema version and pending migrations without applying them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	conn, err := openDatabase(dbPath)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	current, err := schemaVersion(conn)
+	if err != nil {
+		return err
+	}
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+	var pending []migration
+	for _, m := range migrations {
+		if m.Version > current {
+			pending = append(pending, m)
+		}
+	}
+
+	if *status {
+		fmt.Printf("%s is at schema version %d\n", dbPath, current)
+		for _, m := range pending {
+			fmt.Printf("  pending: %s\n", strings.TrimPrefix(m.Name, "migrations/"))
+		}
+		if len(pending) == 0 {
+			fmt.Println("Up to date.")
+		}
+		return nil
+	}
+
+	if err := runMigrations(conn); err != nil {
+		return err
+	}
+	latest, err := schemaVersion(conn)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Applied %d migration(s); %s is at schema version %d\n", len(pending), dbPath, latest)
+	return nil
+}
+
+// --- user ---
+
+func runUserCommand(s *Server, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: user add|reset-password|list [flags]")
+	}
+	switch args[0] {
+	case "add":
+		return runUserAdd(s, args[1:])
+	case "reset-password":
+		return runUserResetPassword(s, args[1:])
+	case "list":
+		return runUserList(s)
+	}
+	return fmt.Errorf("unknown user command %q; use add, reset-password or list", args[0])
+}
+
+// runUserAdd creates an account without an invite, for example when a
+// parent cannot get the invite email.
+func runUserAdd(s *Server, args []string) error {
+	fs := flag.NewFlagSet("user add", flag.ContinueOnError)
+	username := fs.String("username", "", "login name (required)")
+	role := fs.String("role", roleAthlete, "athlete or parent")
+	athletes := fs.String("athlete", "", "comma-separated athlete IDs to link (required)")
+	password := fs.String("password", "", "password (read from stdin when omitted)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if !usernamePattern.MatchString(*username) || strings.EqualFold(*username, adminUsername) {
+		return fmt.Errorf("-username must be 3-64 letters, digits or . _ @ + -")
+	}
+	if *role != roleAthlete && *role != roleParent {
+		return fmt.Errorf("-role must be athlete or parent")
+	}
+	athleteIDs, err := parseIDList(*athletes)
+	if err != nil || len(athleteIDs) == 0 {
+		return fmt.Errorf("-athlete must list at least one athlete ID")
+	}
+	hash, err := readPasswordHash(*password)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.store.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	account, err := tx.CreateAccount(context.Background(), db.CreateAccountParams{
+		Username:     *username,
+		PasswordHash: hash,
+		Role:         *role,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			return fmt.Errorf("username %q is already taken", *username)
+		}
+		return err
+	}
+	for _, id := range athleteIDs {
+		if _, err := tx.GetAthleteByID(context.Background(), id); err == sql.ErrNoRows {
+			return fmt.Errorf("athlete %d not found", id)
+		} else if err != nil {
+			return err
+		}
+		if err := tx.AddAccountAthlete(context.Background(), db.AddAccountAthleteParams{
+			AccountID: account.ID,
+			AthleteID: id,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s account %s (id %d) linked to athlete(s) %s\n", account.Role, account.Username, account.ID, *athletes)
+	return nil
+}
+
+// runUserResetPassword sets a new password. Tokens issued with the old
+// password stop working.
+func runUserResetPassword(s *Server, args []string) error {
+	fs := flag.NewFlagSet("user reset-password", flag.ContinueOnError)
+	username := fs.String("username", "", "login name (required)")
+	password := fs.String("password", "", "new password (read from stdin when omitted)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	account, err := s.store.GetAccountByUsername(context.Background(), *username)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("no account named %q", *username)
+	}
+	if err != nil {
+		return err
+	}
+	hash, err := readPasswordHash(*password)
+	if err != nil {
+		return err
+	}
+	if err := s.store.UpdateAccountPassword(context.Background(), db.UpdateAccountPasswordParams{
+		PasswordHash: hash,
+		ID:           account.ID,
+	}); err != nil {
+		return err
+	}
+	fmt.Printf("Password for %s updated; existing sign-ins have been signed out\n", account.Username)
+	return nil
+}
+
+func runUserList(s *Server) error {
+	accounts, err := s.store.GetAllAccounts(context.Background())
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tUSERNAME\tROLE\tATHLETES\tCREATED")
+	for _, a := range accounts {
+		ids, err := s.store.GetAccountAthleteIDs(context.Background(), a.ID)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", a.ID, a.Username, a.Role, joinIDs(ids), a.CreatedAt)
+	}
+	return tw.Flush()
+}
+
+// readPasswordHash validates a password, reading it from the first line of
+// stdin when none was given, and returns its bcrypt hash.
+func readPasswordHash(password string) (string, error) {
+	if password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// --- import / export ---
+
+func runImportCommand(s *Server, args []string) error {
+	if len(args) > 0 && args[0] == "archive" {
+		return runImportArchiveCommand(s, args[1:])
+	}
+	if len(args) == 0 || args[0] != "results" {
+		return fmt.Errorf("usage: import results -meet ID FILE | archive FILE")
+	}
+	fs := flag.NewFlagSet("import results", flag.ContinueOnError)
+	meetID := fs.Int64("meet", 0, "meet to import into (required; must be in progress)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: import results -meet ID FILE")
+	}
+	if code, msg, err := s.checkMeetInProgress(context.Background(), *meetID); err != nil {
+		return err
+	} else if code != 0 {
+		return fmt.Errorf("%s", msg)
+	}
+
+	in, err := openInput(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	rows, err := parseResultsCSV(in)
+	if err != nil {
+		return err
+	}
+	if msg, err := s.checkImportAthletes(context.Background(), rows); err != nil {
+		return err
+	} else if msg != "" {
+		return fmt.Errorf("%s", msg)
+	}
+	posted, splitCount, err := s.importResults(context.Background(), *meetID, rows)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d result(s) and %d split(s) into meet %d\n", len(posted.Results), splitCount, *meetID)
+	for _, b := range posted.NewRecords {
+		fmt.Printf("New %s record: %s %s\n", b.Category, b.AthleteName, b.Time)
+	}
+	return nil
+}
+
+func runExportCommand(s *Server, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: export results -meet ID | roster | archive [-o FILE]")
+	}
+	what := args[0]
+	if what == "archive" {
+		return runExportArchiveCommand(s, args[1:])
+	}
+	fs := flag.NewFlagSet("export "+what, flag.ContinueOnError)
+	out := fs.String("o", "-", "output file (- for stdout)")
+	meetID := fs.Int64("meet", 0, "meet to export (results only)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	var records [][]string
+	var err error
+	switch what {
+	case "results":
+		records, err = resultRecords(s, *meetID)
+	case "roster":
+		records, err = rosterRecords(s)
+	default:
+		return fmt.Errorf("unknown export %q; use results, roster or archive", what)
+	}
+	if err != nil {
+		return err
+	}
+
+	w, err := createOutput(*out)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	cw.WriteAll(records)
+	if err := cw.Error(); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// resultRecords writes a meet's results in the import format, so the file
+// can be edited and loaded again with `import results`.
+func resultRecords(s *Server, meetID int64) ([][]string, error) {
+	if _, err := s.store.GetMeetByID(context.Background(), meetID); err == sql.ErrNoRows {
+		return nil, fmt.Errorf("meet %d not found", meetID)
+	} else if err != nil {
+		return nil, err
+	}
+	results, err := s.store.GetResultsByMeet(context.Background(), sql.NullInt64{Int64: meetID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	splits := make(map[int64]map[float64]string, len(results))
+	seen := map[float64]bool{}
+	var distances []float64
+	for _, r := range results {
+		rs, err := s.store.GetSplitsByResult(context.Background(), r.ID)
+		if err != nil {
+			return nil, err
+		}
+		splits[r.ID] = map[float64]string{}
+		for _, sp := range rs {
+			if !seen[sp.DistanceMeters] {
+				seen[sp.DistanceMeters] = true
+				distances = append(distances, sp.DistanceMeters)
+			}
+			splits[r.ID][sp.DistanceMeters] = sp.Time
+		}
+	}
+	sort.Float64s(distances)
+
+	header := []string{"athleteId", "athlete", "place", "time"}
+	for _, d := range distances {
+		header = append(header, "split_"+strconv.FormatFloat(d, 'f', -1, 64))
+	}
+	records := [][]string{header}
+	for _, r := range results {
+		record := []string{
+			optionalInt64String(nullInt64ToPtr(r.AthleteID)),
+			r.AthleteName,
+			optionalInt64String(nullInt64ToPtr(r.Place)),
+			r.Time.String,
+		}
+		for _, d := range distances {
+			record = append(record, splits[r.ID][d])
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func rosterRecords(s *Server) ([][]string, error) {
+	athletes, err := s.store.GetAllAthletes(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := s.athleteCurrentStatuses(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	records := [][]string{{"id", "name", "gender", "graduationYear", "grade", "jerseyNumber", "status"}}
+	for _, a := range athletes {
+		status := statuses[a.ID]
+		if status == "" {
+			status = statusActive
+		}
+		records = append(records, []string{
+			strconv.FormatInt(a.ID, 10),
+			a.Name,
+			a.Gender.String,
+			optionalInt64String(nullInt64ToPtr(a.GraduationYear)),
+			optionalInt64String(nullInt64ToPtr(gradeOn(a.GraduationYear, now))),
+			optionalInt64String(nullInt64ToPtr(a.JerseyNumber)),
+			status,
+		})
+	}
+	return records, nil
+}
+
+// --- Helpers ---
+
+func parseIDList(s string) ([]int64, error) {
+	var ids []int64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func joinIDs(ids []int64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+func createOutput(path string) (io.WriteCloser, error) {
+	if path == "-" || path == "" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	return os.Create(path)
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+	"jones-county-xc/backend/pace"
+)
+
+const maxCompareAthletes = 10
+
+type CompareAthlete struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type CompareFinisher struct {
+	AthleteID   int64    `json:"athleteId"`
+	AthleteName string   `json:"athleteName"`
+	Order       int      `json:"order"`
+	Place       *int64   `json:"place"`
+	Time        *string  `json:"time"`
+	GapSeconds  *float64 `json:"gapSeconds"`
+	Gap         *string  `json:"gap"`
+}
+
+// CompareMeet is one race at a meet. RaceID and Race are set when the
+// finishers were entered in the race; otherwise they are grouped by Gender.
+type CompareMeet struct {
+	MeetID    int64             `json:"meetId"`
+	MeetName  string            `json:"meetName"`
+	MeetDate  *string           `json:"meetDate"`
+	RaceID    *int64            `json:"raceId"`
+	Race      *string           `json:"race"`
+	Gender    *string           `json:"gender"`
+	Finishers []CompareFinisher `json:"finishers"`
+}
+
+type HeadToHead struct {
+	AthleteID  int64 `json:"athleteId"`
+	OpponentID int64 `json:"opponentId"`
+	Wins       int   `json:"wins"`
+	Losses     int   `json:"losses"`
+	Ties       int   `json:"ties"`
+}
+
+type CompareResponse struct {
+	Athletes   []CompareAthlete `json:"athletes"`
+	Meets      []CompareMeet    `json:"meets"`
+	HeadToHead []HeadToHead     `json:"headToHead"`
+}
+
+// compareRace identifies the race a result was run in: the entered race when
+// there is one, otherwise the athlete's gender at that meet.
+type compareRace struct {
+	meetID int64
+	raceID int64
+	gender string
+}
+
+// compareResult is one athlete's result at a shared meet.
+type compareResult struct {
+	athlete db.Athlete
+	row     db.GetResultsByAthleteRow
+	seconds float64
+	timed   bool
+}
+
+// GetCompare compares two or more athletes (?athletes=1,2,3) across the
+// races where at least two of them ran. Athletes entered in the same race
+// at a meet share it; athletes without an entry are grouped by gender, and
+// those with no gender are left out. Each race lists the compared athletes
+// in finishing order with gaps behind the first of them, and headToHead has
+// every pairing's record over their shared races.
+func (s *Server) GetCompare(c *gin.Context) {
+	ctx := c.Request.Context()
+	var ids []int64
+	seen := map[int64]bool{}
+	for _, part := range strings.Split(c.Query("athletes"), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			c.JSON(400, gin.H{"error": "invalid athlete ID"})
+			return
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 2 || len(ids) > maxCompareAthletes {
+		c.JSON(400, gin.H{"error": "athletes must list between 2 and 10 athlete IDs"})
+		return
+	}
+
+	response := CompareResponse{
+		Athletes:   make([]CompareAthlete, len(ids)),
+		Meets:      []CompareMeet{},
+		HeadToHead: []HeadToHead{},
+	}
+	entered, races, err := s.compareEntries(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	byRace := map[compareRace][]compareResult{}
+	for i, id := range ids {
+		athlete, err := s.store.GetAthleteByID(ctx, id)
+		if err == sql.ErrNoRows {
+			c.JSON(404, gin.H{"error": "athlete not found"})
+			return
+		}
+		if err != nil {
+			serverError(c, err)
+			return
+		}
+		response.Athletes[i] = CompareAthlete{ID: athlete.ID, Name: athlete.Name}
+
+		results, err := s.store.GetResultsByAthlete(ctx, sql.NullInt64{Int64: id, Valid: true})
+		if err != nil {
+			serverError(c, err)
+			return
+		}
+		counted := map[int64]bool{}
+		for _, r := range results {
+			if !r.MeetID.Valid || counted[r.MeetID.Int64] {
+				continue
+			}
+			counted[r.MeetID.Int64] = true
+			key := compareRace{meetID: r.MeetID.Int64, raceID: entered[[2]int64{r.MeetID.Int64, id}]}
+			if key.raceID == 0 {
+				if !athlete.Gender.Valid || athlete.Gender.String == "" {
+					continue
+				}
+				key.gender = athlete.Gender.String
+			}
+			cr := compareResult{athlete: athlete, row: r}
+			if r.Time.Valid {
+				if seconds, err := pace.Parse(r.Time.String); err == nil {
+					cr.seconds, cr.timed = seconds, true
+				}
+			}
+			byRace[key] = append(byRace[key], cr)
+		}
+	}
+
+	// Pairings follow the order the athletes were requested in.
+	for i := 0; i < len(ids); i++ {
+		for j := i + 1; j < len(ids); j++ {
+			response.HeadToHead = append(response.HeadToHead, HeadToHead{AthleteID: ids[i], OpponentID: ids[j]})
+		}
+	}
+	records := map[[2]int64]*HeadToHead{}
+	for i := range response.HeadToHead {
+		h := &response.HeadToHead[i]
+		records[[2]int64{h.AthleteID, h.OpponentID}] = h
+	}
+
+	for key, finishers := range byRace {
+		if len(finishers) < 2 {
+			continue
+		}
+		sort.SliceStable(finishers, func(i, j int) bool {
+			return finishedAhead(finishers[i], finishers[j]) < 0
+		})
+
+		first := finishers[0].row
+		meet := CompareMeet{
+			MeetID:    key.meetID,
+			MeetName:  first.MeetName,
+			MeetDate:  nullStringToPtr(first.MeetDate),
+			Finishers: make([]CompareFinisher, len(finishers)),
+		}
+		if key.raceID != 0 {
+			raceID, name := key.raceID, races[key.raceID]
+			meet.RaceID, meet.Race = &raceID, &name
+		} else {
+			gender := key.gender
+			meet.Gender = &gender
+		}
+		for i, f := range finishers {
+			cf := CompareFinisher{
+				AthleteID:   f.athlete.ID,
+				AthleteName: f.athlete.Name,
+				Order:       i + 1,
+				Place:       nullInt64ToPtr(f.row.Place),
+				Time:        nullStringToPtr(f.row.Time),
+			}
+			if f.timed && finishers[0].timed {
+				gap := roundTenths(f.seconds - finishers[0].seconds)
+				gapText := "+" + pace.Format(gap)
+				cf.GapSeconds, cf.Gap = &gap, &gapText
+			}
+			meet.Finishers[i] = cf
+		}
+		response.Meets = append(response.Meets, meet)
+
+		for i := range finishers {
+			for j := range finishers {
+				h, ok := records[[2]int64{finishers[i].athlete.ID, finishers[j].athlete.ID}]
+				if !ok {
+					continue
+				}
+				switch cmp := finishedAhead(finishers[i], finishers[j]); {
+				case cmp < 0:
+					h.Wins++
+				case cmp > 0:
+					h.Losses++
+				default:
+					h.Ties++
+				}
+			}
+		}
+	}
+
+	// Oldest meet first; undated meets sort ahead, matching ORDER BY m.date.
+	sort.Slice(response.Meets, func(i, j int) bool {
+		a, b := response.Meets[i], response.Meets[j]
+		var dateA, dateB string
+		if a.MeetDate != nil {
+			dateA = *a.MeetDate
+		}
+		if b.MeetDate != nil {
+			dateB = *b.MeetDate
+		}
+		if dateA != dateB {
+			return dateA < dateB
+		}
+		if a.MeetID != b.MeetID {
+			return a.MeetID < b.MeetID
+		}
+		return raceOrder(a) < raceOrder(b)
+	})
+	c.JSON(200, response)
+}
+
+// compareEntries maps (meet ID, athlete ID) to the race the athlete was
+// entered in, and race IDs to names.
+func (s *Server) compareEntries(ctx context.Context) (map[[2]int64]int64, map[int64]string, error) {
+	races, err := s.store.GetAllRaces(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := s.store.GetAllEntries(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	meetOf := make(map[int64]int64, len(races))
+	names := make(map[int64]string, len(races))
+	for _, r := range races {
+		meetOf[r.ID] = r.MeetID
+		names[r.ID] = r.Name
+	}
+	entered := make(map[[2]int64]int64, len(entries))
+	for _, e := range entries {
+		entered[[2]int64{meetOf[e.RaceID], e.AthleteID}] = e.RaceID
+	}
+	return entered, names, nil
+}
+
+// raceOrder sorts a meet's entered races by ID, then the gender groups.
+func raceOrder(m CompareMeet) string {
+	if m.RaceID != nil {
+		return fmt.Sprintf("0%020d", *m.RaceID)
+	}
+	return "1" + *m.Gender
+}
+
+// finishedAhead orders two results from the same meet: by place when both
+// have one (results entered without a place store 0), otherwise by time.
+// Negative means a finished ahead of b.
+func finishedAhead(a, b compareResult) int {
+	placed := a.row.Place.Int64 > 0 && b.row.Place.Int64 > 0
+	if placed && a.row.Place.Int64 != b.row.Place.Int64 {
+		if a.row.Place.Int64 < b.row.Place.Int64 {
+			return -1
+		}
+		return 1
+	}
+	if a.timed && b.timed && a.seconds != b.seconds {
+		if a.seconds < b.seconds {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+	"jones-county-xc/backend/pace"
+)
+
+type CourseResponse struct {
+	ID             int64   `json:"id"`
+	Name           string  `json:"name"`
+	Venue          *string `json:"venue"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	Surface        *string `json:"surface"`
+	ElevationNotes *string `json:"elevationNotes"`
+}
+
+type CourseDetailResponse struct {
+	CourseResponse
+	Record *CourseMarkResponse `json:"record"`
+}
+
+type CourseMarkResponse struct {
+	Rank        int     `json:"rank"`
+	ResultID    int64   `json:"resultId"`
+	AthleteID   int64   `json:"athleteId"`
+	AthleteName string  `json:"athleteName"`
+	MeetID      int64   `json:"meetId"`
+	MeetName    string  `json:"meetName"`
+	MeetDate    *string `json:"meetDate"`
+	Time        string  `json:"time"`
+}
+
+type courseInput struct {
+	Name           string  `json:"name"`
+	Venue          *string `json:"venue"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	Surface        *string `json:"surface"`
+	ElevationNotes *string `json:"elevationNotes"`
+}
+
+// validate returns an error message, or "" when the input is valid.
+func (in *courseInput) validate() string {
+	if in.Name == "" {
+		return "name is required"
+	}
+	if in.DistanceMeters <= 0 {
+		return "distanceMeters must be positive"
+	}
+	return ""
+}
+
+// --- Read handlers ---
+
+func (s *Server) GetCourses(c *gin.Context) {
+	ctx := c.Request.Context()
+	courses, err := s.store.GetAllCourses(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]CourseResponse, len(courses))
+	for i, course := range courses {
+		response[i] = courseResponse(course)
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) GetCourseByID(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var courseID int64
+	if _, err := fmt.Sscanf(id, "%d", &courseID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid course ID"})
+		return
+	}
+
+	course, err := s.store.GetCourseByID(ctx, courseID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "course not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	bests, err := s.courseBests(ctx, courseID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := CourseDetailResponse{CourseResponse: courseResponse(course)}
+	if len(bests) > 0 {
+		response.Record = &bests[0]
+	}
+	c.JSON(200, response)
+}
+
+// GetCourseBests returns each athlete's best mark on the course, fastest
+// first. The first entry is the course record.
+func (s *Server) GetCourseBests(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var courseID int64
+	if _, err := fmt.Sscanf(id, "%d", &courseID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid course ID"})
+		return
+	}
+
+	if _, err := s.store.GetCourseByID(ctx, courseID); err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "course not found"})
+		return
+	} else if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	bests, err := s.courseBests(ctx, courseID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, bests)
+}
+
+// --- Write handlers ---
+
+func (s *Server) CreateCourse(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input courseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := input.validate(); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	course, err := s.store.CreateCourse(ctx, db.CreateCourseParams{
+		Name:           input.Name,
+		Venue:          ptrToNullString(input.Venue),
+		DistanceMeters: input.DistanceMeters,
+		Surface:        ptrToNullString(input.Surface),
+		ElevationNotes: ptrToNullString(input.ElevationNotes),
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(201, courseResponse(course))
+}
+
+func (s *Server) UpdateCourse(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var courseID int64
+	if _, err := fmt.Sscanf(id, "%d", &courseID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid course ID"})
+		return
+	}
+
+	var input courseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := input.validate(); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	course, err := s.store.UpdateCourse(ctx, db.UpdateCourseParams{
+		ID:             courseID,
+		Name:           input.Name,
+		Venue:          ptrToNullString(input.Venue),
+		DistanceMeters: input.DistanceMeters,
+		Surface:        ptrToNullString(input.Surface),
+		ElevationNotes: ptrToNullString(input.ElevationNotes),
+	})
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "course not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, courseResponse(course))
+}
+
+func (s *Server) DeleteCourse(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var courseID int64
+	if _, err := fmt.Sscanf(id, "%d", &courseID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid course ID"})
+		return
+	}
+
+	n, err := s.store.DeleteCourse(ctx, courseID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "course not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "course deleted"})
+}
+
+// --- Helpers ---
+
+func courseResponse(course db.Course) CourseResponse {
+	return CourseResponse{
+		ID:             course.ID,
+		Name:           course.Name,
+		Venue:          nullStringToPtr(course.Venue),
+		DistanceMeters: course.DistanceMeters,
+		Surface:        nullStringToPtr(course.Surface),
+		ElevationNotes: nullStringToPtr(course.ElevationNotes),
+	}
+}
+
+// courseBests keeps each athlete's fastest result on the course and ranks
+// them. Times are compared as parsed seconds because the stored text doesn't
+// sort correctly ("9:59" > "10:01"). Tied times share a rank.
+func (s *Server) courseBests(ctx context.Context, courseID int64) ([]CourseMarkResponse, error) {
+	results, err := s.store.GetResultsByCourse(ctx, sql.NullInt64{Int64: courseID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []markCandidate
+	for i, r := range results {
+		if !r.AthleteID.Valid || !r.Time.Valid {
+			continue
+		}
+		seconds, err := pace.Parse(r.Time.String)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, markCandidate{
+			AthleteID: r.AthleteID.Int64,
+			Name:      r.AthleteName,
+			Seconds:   seconds,
+			Row:       i,
+		})
+	}
+
+	marks := bestPerAthlete(candidates)
+	ranks := competitionRanks(marks)
+	response := make([]CourseMarkResponse, len(marks))
+	for i, m := range marks {
+		r := results[m.Row]
+		response[i] = CourseMarkResponse{
+			Rank:        ranks[i],
+			ResultID:    r.ID,
+			AthleteID:   r.AthleteID.Int64,
+			AthleteName: r.AthleteName,
+			MeetID:      r.MeetID.Int64,
+			MeetName:    r.MeetName,
+			MeetDate:    nullStringToPtr(r.MeetDate),
+			Time:        r.Time.String,
+		}
+	}
+	return response, nil
+}
@@ -8,19 +8,176 @@ import (
 	"database/sql"
 )
 
+type Account struct {
+	ID           int64
+	Username     string
+	PasswordHash string
+	Role         string
+	CreatedAt    string
+}
+
+type AccountAthlete struct {
+	AccountID int64
+	AthleteID int64
+}
+
 type Athlete struct {
 	ID             int64
 	Name           string
-	Grade          sql.NullInt64
 	PersonalRecord sql.NullString
-	Events         sql.NullString
+	Gender         sql.NullString
+	GraduationYear sql.NullInt64
+	JerseyNumber   sql.NullInt64
+}
+
+type AthleteEvent struct {
+	AthleteID int64
+	EventID   int64
+}
+
+type AthleteProfile struct {
+	AthleteID        int64
+	Bio              sql.NullString
+	EmergencyContact sql.NullString
+	EmergencyPhone   sql.NullString
+}
+
+type AthleteStatus struct {
+	ID        int64
+	AthleteID int64
+	Status    string
+	StartDate string
+	EndDate   sql.NullString
+	Note      sql.NullString
+}
+
+type Attendance struct {
+	ID        int64
+	SessionID int64
+	AthleteID int64
+	Status    string
+	Note      sql.NullString
+}
+
+type Bib struct {
+	ID        int64
+	MeetID    int64
+	AthleteID int64
+	Bib       int64
+}
+
+type Course struct {
+	ID             int64
+	Name           string
+	Venue          sql.NullString
+	DistanceMeters float64
+	Surface        sql.NullString
+	ElevationNotes sql.NullString
+}
+
+type EmailOutbox struct {
+	ID            int64
+	ToEmail       string
+	Subject       string
+	Body          string
+	Event         string
+	Status        string
+	Attempts      int64
+	NextAttemptAt string
+	LastError     sql.NullString
+	CreatedAt     string
+	SentAt        sql.NullString
+}
+
+type EmailSubscription struct {
+	ID               int64
+	Email            string
+	AccountID        sql.NullInt64
+	MeetChanges      int64
+	Results          string
+	UnsubscribeToken string
+	CreatedAt        string
+}
+
+type Entry struct {
+	ID        int64
+	RaceID    int64
+	AthleteID int64
+	Role      string
+	Position  int64
+}
+
+type Event struct {
+	ID   int64
+	Name string
+}
+
+type HistoricalMark struct {
+	ID             int64
+	AthleteName    string
+	Gender         sql.NullString
+	Grade          sql.NullInt64
+	DistanceMeters float64
+	Time           string
+	Date           sql.NullString
+	MeetName       sql.NullString
+	Notes          sql.NullString
+}
+
+type Invite struct {
+	ID        int64
+	Code      string
+	AthleteID int64
+	Role      string
+	CreatedAt string
+	ExpiresAt string
+	UsedAt    sql.NullString
+	AccountID sql.NullInt64
 }
 
 type Meet struct {
-	ID       int64
-	Name     string
-	Date     sql.NullString
-	Location sql.NullString
+	ID           int64
+	Name         string
+	Date         sql.NullString
+	Location     sql.NullString
+	CourseID     sql.NullInt64
+	Status       string
+	StartTime    sql.NullString
+	BusDeparture sql.NullString
+	HostSchool   sql.NullString
+}
+
+type PracticeSession struct {
+	ID     int64
+	Date   string
+	Type   string
+	MeetID sql.NullInt64
+	Notes  sql.NullString
+}
+
+type Race struct {
+	ID             int64
+	MeetID         int64
+	Name           string
+	Gender         sql.NullString
+	ScorerLimit    int64
+	AlternateLimit int64
+	EntryDeadline  sql.NullString
+}
+
+type RecordBreak struct {
+	ID             int64
+	Category       string
+	DistanceMeters float64
+	Gender         sql.NullString
+	Grade          sql.NullInt64
+	ResultID       sql.NullInt64
+	AthleteID      sql.NullInt64
+	AthleteName    string
+	Time           string
+	PreviousHolder sql.NullString
+	PreviousTime   sql.NullString
+	BrokenAt       string
 }
 
 type Result struct {
@@ -30,3 +187,66 @@ type Result struct {
 	Time      sql.NullString
 	Place     sql.NullInt64
 }
+
+type Split struct {
+	ID             int64
+	ResultID       int64
+	SplitIndex     int64
+	DistanceMeters float64
+	Time           string
+}
+
+type Webhook struct {
+	ID        int64
+	Url       string
+	Secret    string
+	Active    int64
+	CreatedAt string
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int64
+	Event          string
+	Payload        string
+	Status         string
+	Attempts       int64
+	NextAttemptAt  string
+	ResponseStatus sql.NullInt64
+	LastError      sql.NullString
+	CreatedAt      string
+	DeliveredAt    sql.NullString
+}
+
+type WebhookEvent struct {
+	WebhookID int64
+	Event     string
+}
+
+type Workout struct {
+	ID              int64
+	AthleteID       int64
+	PlanID          sql.NullInt64
+	Date            string
+	Type            string
+	DistanceMiles   sql.NullFloat64
+	DurationSeconds sql.NullInt64
+	Rpe             sql.NullInt64
+	Notes           sql.NullString
+	CreatedAt       string
+}
+
+type WorkoutPlan struct {
+	ID              int64
+	Date            string
+	Type            string
+	Title           string
+	DistanceMiles   sql.NullFloat64
+	DurationSeconds sql.NullInt64
+	Description     sql.NullString
+}
+
+type WorkoutPlanAthlete struct {
+	PlanID    int64
+	AthleteID int64
+}
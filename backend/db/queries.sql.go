@@ -11,56 +11,76 @@ import (
 )
 
 const createAthlete = `-- name: CreateAthlete :one
-INSERT INTO athletes (name, grade, personal_record, events)
-VALUES (?, ?, ?, ?)
-RETURNING id, name, grade, personal_record, events
+INSERT INTO athletes (name, personal_record, gender, graduation_year, jersey_number)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id, name, personal_record, gender, graduation_year, jersey_number
 `
 
 type CreateAthleteParams struct {
 	Name           string
-	Grade          sql.NullInt64
 	PersonalRecord sql.NullString
-	Events         sql.NullString
+	Gender         sql.NullString
+	GraduationYear sql.NullInt64
+	JerseyNumber   sql.NullInt64
 }
 
 func (q *Queries) CreateAthlete(ctx context.Context, arg CreateAthleteParams) (Athlete, error) {
 	row := q.db.QueryRowContext(ctx, createAthlete,
 		arg.Name,
-		arg.Grade,
 		arg.PersonalRecord,
-		arg.Events,
+		arg.Gender,
+		arg.GraduationYear,
+		arg.JerseyNumber,
 	)
 	var i Athlete
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.Grade,
 		&i.PersonalRecord,
-		&i.Events,
+		&i.Gender,
+		&i.GraduationYear,
+		&i.JerseyNumber,
 	)
 	return i, err
 }
 
 const createMeet = `-- name: CreateMeet :one
-INSERT INTO meets (name, date, location)
-VALUES (?, ?, ?)
-RETURNING id, name, date, location
+INSERT INTO meets (name, date, location, course_id, start_time, bus_departure, host_school)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, name, date, location, course_id, status, start_time, bus_departure, host_school
 `
 
 type CreateMeetParams struct {
-	Name     string
-	Date     sql.NullString
-	Location sql.NullString
+	Name         string
+	Date         sql.NullString
+	Location     sql.NullString
+	CourseID     sql.NullInt64
+	StartTime    sql.NullString
+	BusDeparture sql.NullString
+	HostSchool   sql.NullString
 }
 
 func (q *Queries) CreateMeet(ctx context.Context, arg CreateMeetParams) (Meet, error) {
-	row := q.db.QueryRowContext(ctx, createMeet, arg.Name, arg.Date, arg.Location)
+	row := q.db.QueryRowContext(ctx, createMeet,
+		arg.Name,
+		arg.Date,
+		arg.Location,
+		arg.CourseID,
+		arg.StartTime,
+		arg.BusDeparture,
+		arg.HostSchool,
+	)
 	var i Meet
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.Date,
 		&i.Location,
+		&i.CourseID,
+		&i.Status,
+		&i.StartTime,
+		&i.BusDeparture,
+		&i.HostSchool,
 	)
 	return i, err
 }
@@ -96,22 +116,28 @@ func (q *Queries) CreateResult(ctx context.Context, arg CreateResultParams) (Res
 	return i, err
 }
 
-const deleteAthlete = `-- name: DeleteAthlete :exec
+const deleteAthlete = `-- name: DeleteAthlete :execrows
 DELETE FROM athletes WHERE id = ?
 `
 
-func (q *Queries) DeleteAthlete(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteAthlete, id)
-	return err
+func (q *Queries) DeleteAthlete(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAthlete, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const deleteMeet = `-- name: DeleteMeet :exec
+const deleteMeet = `-- name: DeleteMeet :execrows
 DELETE FROM meets WHERE id = ?
 `
 
-func (q *Queries) DeleteMeet(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteMeet, id)
-	return err
+func (q *Queries) DeleteMeet(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteMeet, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const deleteResult = `-- name: DeleteResult :exec
@@ -124,7 +150,7 @@ func (q *Queries) DeleteResult(ctx context.Context, id int64) error {
 }
 
 const getAllAthletes = `-- name: GetAllAthletes :many
-SELECT id, name, grade, personal_record, events FROM athletes ORDER BY name
+SELECT id, name, personal_record, gender, graduation_year, jersey_number FROM athletes ORDER BY name
 `
 
 func (q *Queries) GetAllAthletes(ctx context.Context) ([]Athlete, error) {
@@ -139,9 +165,10 @@ func (q *Queries) GetAllAthletes(ctx context.Context) ([]Athlete, error) {
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
-			&i.Grade,
 			&i.PersonalRecord,
-			&i.Events,
+			&i.Gender,
+			&i.GraduationYear,
+			&i.JerseyNumber,
 		); err != nil {
 			return nil, err
 		}
@@ -157,7 +184,7 @@ func (q *Queries) GetAllAthletes(ctx context.Context) ([]Athlete, error) {
 }
 
 const getAllMeets = `-- name: GetAllMeets :many
-SELECT id, name, date, location FROM meets ORDER BY date
+SELECT id, name, date, location, course_id, status, start_time, bus_departure, host_school FROM meets ORDER BY date
 `
 
 func (q *Queries) GetAllMeets(ctx context.Context) ([]Meet, error) {
@@ -174,6 +201,11 @@ func (q *Queries) GetAllMeets(ctx context.Context) ([]Meet, error) {
 			&i.Name,
 			&i.Date,
 			&i.Location,
+			&i.CourseID,
+			&i.Status,
+			&i.StartTime,
+			&i.BusDeparture,
+			&i.HostSchool,
 		); err != nil {
 			return nil, err
 		}
@@ -189,24 +221,38 @@ func (q *Queries) GetAllMeets(ctx context.Context) ([]Meet, error) {
 }
 
 const getAllResults = `-- name: GetAllResults :many
-SELECT id, athlete_id, meet_id, time, place FROM results ORDER BY id
+SELECT r.id, r.athlete_id, r.meet_id, r.time, r.place, c.distance_meters
+FROM results r
+LEFT JOIN meets m ON r.meet_id = m.id
+LEFT JOIN courses c ON m.course_id = c.id
+ORDER BY r.id
 `
 
-func (q *Queries) GetAllResults(ctx context.Context) ([]Result, error) {
+type GetAllResultsRow struct {
+	ID             int64
+	AthleteID      sql.NullInt64
+	MeetID         sql.NullInt64
+	Time           sql.NullString
+	Place          sql.NullInt64
+	DistanceMeters sql.NullFloat64
+}
+
+func (q *Queries) GetAllResults(ctx context.Context) ([]GetAllResultsRow, error) {
 	rows, err := q.db.QueryContext(ctx, getAllResults)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Result
+	var items []GetAllResultsRow
 	for rows.Next() {
-		var i Result
+		var i GetAllResultsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.AthleteID,
 			&i.MeetID,
 			&i.Time,
 			&i.Place,
+			&i.DistanceMeters,
 		); err != nil {
 			return nil, err
 		}
@@ -222,7 +268,7 @@ func (q *Queries) GetAllResults(ctx context.Context) ([]Result, error) {
 }
 
 const getAthleteByID = `-- name: GetAthleteByID :one
-SELECT id, name, grade, personal_record, events FROM athletes WHERE id = ? LIMIT 1
+SELECT id, name, personal_record, gender, graduation_year, jersey_number FROM athletes WHERE id = ? LIMIT 1
 `
 
 func (q *Queries) GetAthleteByID(ctx context.Context, id int64) (Athlete, error) {
@@ -231,15 +277,16 @@ func (q *Queries) GetAthleteByID(ctx context.Context, id int64) (Athlete, error)
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.Grade,
 		&i.PersonalRecord,
-		&i.Events,
+		&i.Gender,
+		&i.GraduationYear,
+		&i.JerseyNumber,
 	)
 	return i, err
 }
 
 const getMeetByID = `-- name: GetMeetByID :one
-SELECT id, name, date, location FROM meets WHERE id = ? LIMIT 1
+SELECT id, name, date, location, course_id, status, start_time, bus_departure, host_school FROM meets WHERE id = ? LIMIT 1
 `
 
 func (q *Queries) GetMeetByID(ctx context.Context, id int64) (Meet, error) {
@@ -250,6 +297,11 @@ func (q *Queries) GetMeetByID(ctx context.Context, id int64) (Meet, error) {
 		&i.Name,
 		&i.Date,
 		&i.Location,
+		&i.CourseID,
+		&i.Status,
+		&i.StartTime,
+		&i.BusDeparture,
+		&i.HostSchool,
 	)
 	return i, err
 }
@@ -272,21 +324,23 @@ func (q *Queries) GetResultByID(ctx context.Context, id int64) (Result, error) {
 }
 
 const getResultsByAthlete = `-- name: GetResultsByAthlete :many
-SELECT r.id, r.athlete_id, r.meet_id, r.time, r.place, m.name as meet_name, m.date as meet_date
+SELECT r.id, r.athlete_id, r.meet_id, r.time, r.place, m.name as meet_name, m.date as meet_date, c.distance_meters
 FROM results r
 JOIN meets m ON r.meet_id = m.id
+LEFT JOIN courses c ON m.course_id = c.id
 WHERE r.athlete_id = ?
 ORDER BY m.date
 `
 
 type GetResultsByAthleteRow struct {
-	ID        int64
-	AthleteID sql.NullInt64
-	MeetID    sql.NullInt64
-	Time      sql.NullString
-	Place     sql.NullInt64
-	MeetName  string
-	MeetDate  sql.NullString
+	ID             int64
+	AthleteID      sql.NullInt64
+	MeetID         sql.NullInt64
+	Time           sql.NullString
+	Place          sql.NullInt64
+	MeetName       string
+	MeetDate       sql.NullString
+	DistanceMeters sql.NullFloat64
 }
 
 func (q *Queries) GetResultsByAthlete(ctx context.Context, athleteID sql.NullInt64) ([]GetResultsByAthleteRow, error) {
@@ -306,6 +360,7 @@ func (q *Queries) GetResultsByAthlete(ctx context.Context, athleteID sql.NullInt
 			&i.Place,
 			&i.MeetName,
 			&i.MeetDate,
+			&i.DistanceMeters,
 		); err != nil {
 			return nil, err
 		}
@@ -321,20 +376,23 @@ func (q *Queries) GetResultsByAthlete(ctx context.Context, athleteID sql.NullInt
 }
 
 const getResultsByMeet = `-- name: GetResultsByMeet :many
-SELECT r.id, r.athlete_id, r.meet_id, r.time, r.place, a.name as athlete_name
+SELECT r.id, r.athlete_id, r.meet_id, r.time, r.place, a.name as athlete_name, c.distance_meters
 FROM results r
 JOIN athletes a ON r.athlete_id = a.id
+LEFT JOIN meets m ON r.meet_id = m.id
+LEFT JOIN courses c ON m.course_id = c.id
 WHERE r.meet_id = ?
 ORDER BY r.place
 `
 
 type GetResultsByMeetRow struct {
-	ID          int64
-	AthleteID   sql.NullInt64
-	MeetID      sql.NullInt64
-	Time        sql.NullString
-	Place       sql.NullInt64
-	AthleteName string
+	ID             int64
+	AthleteID      sql.NullInt64
+	MeetID         sql.NullInt64
+	Time           sql.NullString
+	Place          sql.NullInt64
+	AthleteName    string
+	DistanceMeters sql.NullFloat64
 }
 
 func (q *Queries) GetResultsByMeet(ctx context.Context, meetID sql.NullInt64) ([]GetResultsByMeetRow, error) {
@@ -353,6 +411,7 @@ func (q *Queries) GetResultsByMeet(ctx context.Context, meetID sql.NullInt64) ([
 			&i.Time,
 			&i.Place,
 			&i.AthleteName,
+			&i.DistanceMeters,
 		); err != nil {
 			return nil, err
 		}
@@ -369,50 +428,57 @@ func (q *Queries) GetResultsByMeet(ctx context.Context, meetID sql.NullInt64) ([
 
 const updateAthlete = `-- name: UpdateAthlete :one
 UPDATE athletes
-SET name = ?, grade = ?, personal_record = ?, events = ?
+SET name = ?, personal_record = ?, gender = ?, graduation_year = ?, jersey_number = ?
 WHERE id = ?
-RETURNING id, name, grade, personal_record, events
+RETURNING id, name, personal_record, gender, graduation_year, jersey_number
 `
 
 type UpdateAthleteParams struct {
 	Name           string
-	Grade          sql.NullInt64
 	PersonalRecord sql.NullString
-	Events         sql.NullString
+	Gender         sql.NullString
+	GraduationYear sql.NullInt64
+	JerseyNumber   sql.NullInt64
 	ID             int64
 }
 
 func (q *Queries) UpdateAthlete(ctx context.Context, arg UpdateAthleteParams) (Athlete, error) {
 	row := q.db.QueryRowContext(ctx, updateAthlete,
 		arg.Name,
-		arg.Grade,
 		arg.PersonalRecord,
-		arg.Events,
+		arg.Gender,
+		arg.GraduationYear,
+		arg.JerseyNumber,
 		arg.ID,
 	)
 	var i Athlete
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.Grade,
 		&i.PersonalRecord,
-		&i.Events,
+		&i.Gender,
+		&i.GraduationYear,
+		&i.JerseyNumber,
 	)
 	return i, err
 }
 
 const updateMeet = `-- name: UpdateMeet :one
 UPDATE meets
-SET name = ?, date = ?, location = ?
+SET name = ?, date = ?, location = ?, course_id = ?, start_time = ?, bus_departure = ?, host_school = ?
 WHERE id = ?
-RETURNING id, name, date, location
+RETURNING id, name, date, location, course_id, status, start_time, bus_departure, host_school
 `
 
 type UpdateMeetParams struct {
-	Name     string
-	Date     sql.NullString
-	Location sql.NullString
-	ID       int64
+	Name         string
+	Date         sql.NullString
+	Location     sql.NullString
+	CourseID     sql.NullInt64
+	StartTime    sql.NullString
+	BusDeparture sql.NullString
+	HostSchool   sql.NullString
+	ID           int64
 }
 
 func (q *Queries) UpdateMeet(ctx context.Context, arg UpdateMeetParams) (Meet, error) {
@@ -420,6 +486,10 @@ func (q *Queries) UpdateMeet(ctx context.Context, arg UpdateMeetParams) (Meet, e
 		arg.Name,
 		arg.Date,
 		arg.Location,
+		arg.CourseID,
+		arg.StartTime,
+		arg.BusDeparture,
+		arg.HostSchool,
 		arg.ID,
 	)
 	var i Meet
@@ -428,6 +498,38 @@ func (q *Queries) UpdateMeet(ctx context.Context, arg UpdateMeetParams) (Meet, e
 		&i.Name,
 		&i.Date,
 		&i.Location,
+		&i.CourseID,
+		&i.Status,
+		&i.StartTime,
+		&i.BusDeparture,
+		&i.HostSchool,
+	)
+	return i, err
+}
+
+const updateMeetStatus = `-- name: UpdateMeetStatus :one
+UPDATE meets SET status = ? WHERE id = ?
+RETURNING id, name, date, location, course_id, status, start_time, bus_departure, host_school
+`
+
+type UpdateMeetStatusParams struct {
+	Status string
+	ID     int64
+}
+
+func (q *Queries) UpdateMeetStatus(ctx context.Context, arg UpdateMeetStatusParams) (Meet, error) {
+	row := q.db.QueryRowContext(ctx, updateMeetStatus, arg.Status, arg.ID)
+	var i Meet
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Date,
+		&i.Location,
+		&i.CourseID,
+		&i.Status,
+		&i.StartTime,
+		&i.BusDeparture,
+		&i.HostSchool,
 	)
 	return i, err
 }
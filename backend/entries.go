@@ -0,0 +1,581 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+)
+
+const (
+	roleScorer    = "scorer"
+	roleAlternate = "alternate"
+
+	defaultScorerLimit    = 7
+	defaultAlternateLimit = 2
+)
+
+type EntryResponse struct {
+	ID           int64   `json:"id"`
+	AthleteID    int64   `json:"athleteId"`
+	AthleteName  string  `json:"athleteName"`
+	Role         string  `json:"role"`
+	Position     int64   `json:"position"`
+	Grade        *int64  `json:"grade"`
+	JerseyNumber *int64  `json:"jerseyNumber"`
+	Bib          *int64  `json:"bib"`
+	Gender       *string `json:"gender"`
+}
+
+type RaceResponse struct {
+	ID             int64           `json:"id"`
+	MeetID         int64           `json:"meetId"`
+	Name           string          `json:"name"`
+	Gender         *string         `json:"gender"`
+	ScorerLimit    int64           `json:"scorerLimit"`
+	AlternateLimit int64           `json:"alternateLimit"`
+	EntryDeadline  *string         `json:"entryDeadline"`
+	Locked         bool            `json:"locked"`
+	Scorers        []EntryResponse `json:"scorers"`
+	Alternates     []EntryResponse `json:"alternates"`
+}
+
+// ResultSheetRow is one entered athlete on a meet's result-entry sheet,
+// with their result filled in if one has been recorded.
+type ResultSheetRow struct {
+	RaceID      int64   `json:"raceId"`
+	RaceName    string  `json:"raceName"`
+	Role        string  `json:"role"`
+	AthleteID   int64   `json:"athleteId"`
+	AthleteName string  `json:"athleteName"`
+	Bib         *int64  `json:"bib"`
+	ResultID    *int64  `json:"resultId"`
+	Place       *int64  `json:"place"`
+	Time        *string `json:"time"`
+}
+
+type raceInput struct {
+	Name           string  `json:"name"`
+	Gender         *string `json:"gender"`
+	ScorerLimit    *int64  `json:"scorerLimit"`
+	AlternateLimit *int64  `json:"alternateLimit"`
+	EntryDeadline  *string `json:"entryDeadline"`
+}
+
+type lineupInput struct {
+	Scorers    []int64 `json:"scorers"`
+	Alternates []int64 `json:"alternates"`
+}
+
+// --- Read handlers ---
+
+// GetMeetRaces lists a meet's races with their declared lineups.
+func (s *Server) GetMeetRaces(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var meetID int64
+	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid meet ID"})
+		return
+	}
+
+	races, err := s.meetRaces(ctx, meetID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, races)
+}
+
+// ExportMeetEntries returns the meet's entry sheet as CSV, one row per
+// entered athlete in lineup order.
+func (s *Server) ExportMeetEntries(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var meetID int64
+	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid meet ID"})
+		return
+	}
+
+	meet, err := s.store.GetMeetByID(ctx, meetID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "meet not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	races, err := s.meetRaces(ctx, meetID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write([]string{"race", "role", "position", "bib", "athleteId", "athlete", "grade", "jersey"})
+	for _, race := range races {
+		for _, e := range append(race.Scorers, race.Alternates...) {
+			w.Write([]string{
+				race.Name,
+				e.Role,
+				strconv.FormatInt(e.Position, 10),
+				optionalInt64String(e.Bib),
+				strconv.FormatInt(e.AthleteID, 10),
+				e.AthleteName,
+				optionalInt64String(e.Grade),
+				optionalInt64String(e.JerseyNumber),
+			})
+		}
+	}
+	w.Flush()
+
+	filename := fmt.Sprintf("entries-meet-%d.csv", meet.ID)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(200, "text/csv; charset=utf-8", []byte(b.String()))
+}
+
+// GetMeetResultSheet pre-populates result entry with the meet's entered
+// athletes. With ?format=csv it returns a sheet in the results import
+// format: fill in place and time, drop non-starters and post it to
+// /meets/:id/results/import.
+func (s *Server) GetMeetResultSheet(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var meetID int64
+	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid meet ID"})
+		return
+	}
+
+	races, err := s.meetRaces(ctx, meetID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	results, err := s.store.GetResultsByMeet(ctx, sql.NullInt64{Int64: meetID, Valid: true})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	byAthlete := map[int64]db.GetResultsByMeetRow{}
+	for _, r := range results {
+		if r.AthleteID.Valid {
+			byAthlete[r.AthleteID.Int64] = r
+		}
+	}
+
+	rows := []ResultSheetRow{}
+	for _, race := range races {
+		for _, e := range append(race.Scorers, race.Alternates...) {
+			row := ResultSheetRow{
+				RaceID:      race.ID,
+				RaceName:    race.Name,
+				Role:        e.Role,
+				AthleteID:   e.AthleteID,
+				AthleteName: e.AthleteName,
+				Bib:         e.Bib,
+			}
+			if r, ok := byAthlete[e.AthleteID]; ok {
+				resultID := r.ID
+				row.ResultID = &resultID
+				row.Place = nullInt64ToPtr(r.Place)
+				row.Time = nullStringToPtr(r.Time)
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	if c.Query("format") != "csv" {
+		c.JSON(200, rows)
+		return
+	}
+
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write([]string{"athleteId", "place", "time", "athlete", "race", "bib"})
+	for _, row := range rows {
+		var t string
+		if row.Time != nil {
+			t = *row.Time
+		}
+		w.Write([]string{
+			strconv.FormatInt(row.AthleteID, 10),
+			optionalInt64String(row.Place),
+			t,
+			row.AthleteName,
+			row.RaceName,
+			optionalInt64String(row.Bib),
+		})
+	}
+	w.Flush()
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"results-meet-%d.csv\"", meetID))
+	c.Data(200, "text/csv; charset=utf-8", []byte(b.String()))
+}
+
+// --- Write handlers ---
+
+func (s *Server) CreateRace(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var meetID int64
+	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid meet ID"})
+		return
+	}
+	if _, err := s.store.GetMeetByID(ctx, meetID); err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "meet not found"})
+		return
+	} else if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	var input raceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	params, msg := input.params()
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	race, err := s.store.CreateRace(ctx, db.CreateRaceParams{
+		MeetID:         meetID,
+		Name:           params.Name,
+		Gender:         params.Gender,
+		ScorerLimit:    params.ScorerLimit,
+		AlternateLimit: params.AlternateLimit,
+		EntryDeadline:  params.EntryDeadline,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			c.JSON(409, gin.H{"error": "meet already has a race with that name"})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+	c.JSON(201, raceResponse(race, nil))
+}
+
+// UpdateRace changes a race's settings. It is allowed after the deadline
+// so a coach can extend it; the lineup itself stays locked until then.
+func (s *Server) UpdateRace(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var raceID int64
+	if _, err := fmt.Sscanf(id, "%d", &raceID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid race ID"})
+		return
+	}
+
+	var input raceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	params, msg := input.params()
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+	params.ID = raceID
+
+	race, err := s.store.UpdateRace(ctx, params)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "race not found"})
+		return
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			c.JSON(409, gin.H{"error": "meet already has a race with that name"})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+
+	response, err := s.raceWithEntries(ctx, race)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) DeleteRace(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var raceID int64
+	if _, err := fmt.Sscanf(id, "%d", &raceID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid race ID"})
+		return
+	}
+
+	n, err := s.store.DeleteRace(ctx, raceID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "race not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "race deleted"})
+}
+
+// SetRaceEntries declares a race's lineup, replacing any earlier one.
+// Scorers and alternates are listed in lineup order. It is rejected once
+// the entry deadline has passed, when a list is over its limit, or when an
+// athlete is already entered in another race at the same meet.
+func (s *Server) SetRaceEntries(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var raceID int64
+	if _, err := fmt.Sscanf(id, "%d", &raceID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid race ID"})
+		return
+	}
+
+	race, err := s.store.GetRaceByID(ctx, raceID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "race not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if raceLocked(race, time.Now()) {
+		c.JSON(409, gin.H{"error": "entries are locked; the deadline has passed"})
+		return
+	}
+
+	var input lineupInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if int64(len(input.Scorers)) > race.ScorerLimit {
+		c.JSON(400, gin.H{"error": fmt.Sprintf("at most %d scorers may be entered", race.ScorerLimit)})
+		return
+	}
+	if int64(len(input.Alternates)) > race.AlternateLimit {
+		c.JSON(400, gin.H{"error": fmt.Sprintf("at most %d alternates may be entered", race.AlternateLimit)})
+		return
+	}
+
+	// Athletes already entered in the meet's other races.
+	entries, err := s.store.GetEntriesByMeet(ctx, race.MeetID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	elsewhere := map[int64]bool{}
+	for _, e := range entries {
+		if e.RaceID != raceID {
+			elsewhere[e.AthleteID] = true
+		}
+	}
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err := tx.DeleteEntriesByRace(ctx, raceID); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	seen := map[int64]bool{}
+	for _, list := range []struct {
+		role     string
+		athletes []int64
+	}{{roleScorer, input.Scorers}, {roleAlternate, input.Alternates}} {
+		for i, athleteID := range list.athletes {
+			if seen[athleteID] {
+				c.JSON(400, gin.H{"error": fmt.Sprintf("athlete %d is listed more than once", athleteID)})
+				return
+			}
+			seen[athleteID] = true
+			if elsewhere[athleteID] {
+				c.JSON(409, gin.H{"error": fmt.Sprintf("athlete %d is already entered in another race at this meet", athleteID)})
+				return
+			}
+
+			athlete, err := tx.GetAthleteByID(ctx, athleteID)
+			if err == sql.ErrNoRows {
+				c.JSON(400, gin.H{"error": fmt.Sprintf("athlete %d not found", athleteID)})
+				return
+			}
+			if err != nil {
+				serverError(c, err)
+				return
+			}
+			if race.Gender.Valid && athlete.Gender.Valid && race.Gender.String != athlete.Gender.String {
+				c.JSON(400, gin.H{"error": fmt.Sprintf("%s does not match the race's gender", athlete.Name)})
+				return
+			}
+
+			if _, err := tx.CreateEntry(ctx, db.CreateEntryParams{
+				RaceID:    raceID,
+				AthleteID: athleteID,
+				Role:      list.role,
+				Position:  int64(i + 1),
+			}); err != nil {
+				serverError(c, err)
+				return
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response, err := s.raceWithEntries(ctx, race)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, response)
+}
+
+// --- Helpers ---
+
+// params validates the input and fills in default limits.
+func (input raceInput) params() (db.UpdateRaceParams, string) {
+	params := db.UpdateRaceParams{
+		Name:           strings.TrimSpace(input.Name),
+		Gender:         ptrToNullString(input.Gender),
+		ScorerLimit:    defaultScorerLimit,
+		AlternateLimit: defaultAlternateLimit,
+	}
+	if params.Name == "" {
+		return params, "name is required"
+	}
+	if !validGender(input.Gender) {
+		return params, "gender must be \"M\" or \"F\""
+	}
+	if input.ScorerLimit != nil {
+		params.ScorerLimit = *input.ScorerLimit
+	}
+	if input.AlternateLimit != nil {
+		params.AlternateLimit = *input.AlternateLimit
+	}
+	if params.ScorerLimit < 1 || params.AlternateLimit < 0 {
+		return params, "scorerLimit must be positive and alternateLimit not negative"
+	}
+	if input.EntryDeadline != nil {
+		deadline, err := time.Parse(time.RFC3339, *input.EntryDeadline)
+		if err != nil {
+			return params, "entryDeadline must be an RFC 3339 timestamp, e.g. 2026-10-01T18:00:00-04:00"
+		}
+		params.EntryDeadline = sql.NullString{String: deadline.Format(time.RFC3339), Valid: true}
+	}
+	return params, ""
+}
+
+// raceLocked reports whether the race's entry deadline has passed.
+func raceLocked(race db.Race, now time.Time) bool {
+	if !race.EntryDeadline.Valid {
+		return false
+	}
+	deadline, err := time.Parse(time.RFC3339, race.EntryDeadline.String)
+	return err == nil && !now.Before(deadline)
+}
+
+// meetRaces loads a meet's races with their lineups.
+func (s *Server) meetRaces(ctx context.Context, meetID int64) ([]RaceResponse, error) {
+	races, err := s.store.GetRacesByMeet(ctx, meetID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.store.GetEntriesByMeet(ctx, meetID)
+	if err != nil {
+		return nil, err
+	}
+
+	byRace := map[int64][]db.GetEntriesByMeetRow{}
+	for _, e := range entries {
+		byRace[e.RaceID] = append(byRace[e.RaceID], e)
+	}
+	response := make([]RaceResponse, len(races))
+	for i, race := range races {
+		response[i] = raceResponse(race, byRace[race.ID])
+	}
+	return response, nil
+}
+
+// raceWithEntries loads a single race's lineup.
+func (s *Server) raceWithEntries(ctx context.Context, race db.Race) (RaceResponse, error) {
+	entries, err := s.store.GetEntriesByMeet(ctx, race.MeetID)
+	if err != nil {
+		return RaceResponse{}, err
+	}
+
+	var own []db.GetEntriesByMeetRow
+	for _, e := range entries {
+		if e.RaceID == race.ID {
+			own = append(own, e)
+		}
+	}
+	return raceResponse(race, own), nil
+}
+
+func raceResponse(race db.Race, entries []db.GetEntriesByMeetRow) RaceResponse {
+	now := time.Now()
+	response := RaceResponse{
+		ID:             race.ID,
+		MeetID:         race.MeetID,
+		Name:           race.Name,
+		Gender:         nullStringToPtr(race.Gender),
+		ScorerLimit:    race.ScorerLimit,
+		AlternateLimit: race.AlternateLimit,
+		EntryDeadline:  nullStringToPtr(race.EntryDeadline),
+		Locked:         raceLocked(race, now),
+		Scorers:        []EntryResponse{},
+		Alternates:     []EntryResponse{},
+	}
+	for _, e := range entries {
+		entry := EntryResponse{
+			ID:           e.ID,
+			AthleteID:    e.AthleteID,
+			AthleteName:  e.AthleteName,
+			Role:         e.Role,
+			Position:     e.Position,
+			Grade:        nullInt64ToPtr(gradeOn(e.GraduationYear, now)),
+			JerseyNumber: nullInt64ToPtr(e.JerseyNumber),
+			Bib:          nullInt64ToPtr(e.Bib),
+			Gender:       nullStringToPtr(e.Gender),
+		}
+		if e.Role == roleScorer {
+			response.Scorers = append(response.Scorers, entry)
+		} else {
+			response.Alternates = append(response.Alternates, entry)
+		}
+	}
+	return response
+}
+
+func optionalInt64String(n *int64) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatInt(*n, 10)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"log"
+	"time"
+)
+
+// Event names passed to emitEvent.
+const (
+	EventAthleteCreated = "athlete.created"
+	EventAthleteUpdated = "athlete.updated"
+	EventAthleteDeleted = "athlete.deleted"
+	EventMeetCreated    = "meet.created"
+	EventMeetUpdated    = "meet.updated"
+	EventMeetDeleted    = "meet.deleted"
+	EventResultCreated  = "result.created"
+	EventResultUpdated  = "result.updated"
+	EventResultDeleted  = "result.deleted"
+	EventResultsPosted  = "results.posted"
+	EventRecordBroken   = "record.broken"
+)
+
+// eventNames lists every event, in the order shown to webhook subscribers.
+var eventNames = []string{
+	EventAthleteCreated, EventAthleteUpdated, EventAthleteDeleted,
+	EventMeetCreated, EventMeetUpdated, EventMeetDeleted,
+	EventResultCreated, EventResultUpdated, EventResultDeleted,
+	EventResultsPosted, EventRecordBroken,
+}
+
+// MeetUpdatedEvent is the payload for EventMeetUpdated. PreviousDate is the
+// meet's date before the update.
+type MeetUpdatedEvent struct {
+	Meet         MeetResponse `json:"meet"`
+	PreviousDate *string      `json:"previousDate"`
+}
+
+// DeletedEvent is the payload for the *.deleted events.
+type DeletedEvent struct {
+	ID int64 `json:"id"`
+}
+
+// ResultsPostedEvent is the payload for EventResultsPosted, emitted once
+// when a meet's results are committed from timing or imported in bulk.
+// NewRecords lists the school records the results broke.
+type ResultsPostedEvent struct {
+	MeetID     int64                 `json:"meetId"`
+	Results    []ResultResponse      `json:"results"`
+	NewRecords []RecordBreakResponse `json:"newRecords,omitempty"`
+}
+
+// eventListener is called synchronously for every emitted event. Events are
+// emitted after the write that caused them has been committed.
+type eventListener func(name string, payload any)
+
+// onEvent registers a listener. NewServer registers the email and webhook
+// queues; anything else must be added before serving.
+func (s *Server) onEvent(l eventListener) {
+	s.listeners = append(s.listeners, l)
+}
+
+func (s *Server) emitEvent(name string, payload any) {
+	log.Printf("Event %s", name)
+	for _, l := range s.listeners {
+		l(name, payload)
+	}
+}
+
+// runWorker calls drain every interval, or sooner when woken, forever.
+// The email and webhook workers run on it.
+func runWorker(interval time.Duration, wakeCh <-chan struct{}, drain func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		drain()
+		select {
+		case <-ticker.C:
+		case <-wakeCh:
+		}
+	}
+}
+
+// wake nudges a worker so newly queued work does not wait for the next
+// poll. It never blocks.
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// retryBackoff is the wait after the given number of failed attempts:
+// 1m, 2m, 4m, ... capped at an hour.
+func retryBackoff(attempts int64) time.Duration {
+	d := time.Minute << (attempts - 1)
+	if attempts > 7 || d > time.Hour {
+		return time.Hour
+	}
+	return d
+}
+
+// outboxTime formats the retry timestamps kept by the workers. They are
+// always UTC so that string comparison in SQL orders them correctly.
+func outboxTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"database/sql"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/pace"
+)
+
+const (
+	defaultLeaderboardSize = 10
+	maxLeaderboardSize     = 100
+)
+
+type LeaderboardEntry struct {
+	Rank        int     `json:"rank"`
+	AthleteID   int64   `json:"athleteId"`
+	AthleteName string  `json:"athleteName"`
+	Grade       *int64  `json:"grade"`
+	Gender      *string `json:"gender"`
+	Time        string  `json:"time"`
+	ResultID    int64   `json:"resultId"`
+	MeetID      int64   `json:"meetId"`
+	MeetName    string  `json:"meetName"`
+	MeetDate    *string `json:"meetDate"`
+	CourseID    int64   `json:"courseId"`
+	PaceResponse
+}
+
+type LeaderboardResponse struct {
+	DistanceMeters float64            `json:"distanceMeters"`
+	Entries        []LeaderboardEntry `json:"entries"`
+}
+
+// GetLeaderboards returns one board per race distance with each athlete's
+// best mark, fastest first. Optional filters: gender, grade (the grade the
+// athlete was in at the meet), season (the meet year), courseId and
+// distance (meters). limit sets the number of
+// places per board; athletes tied on the last place are all included.
+func (s *Server) GetLeaderboards(c *gin.Context) {
+	ctx := c.Request.Context()
+	limit := defaultLeaderboardSize
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxLeaderboardSize {
+			c.JSON(400, gin.H{"error": "limit must be between 1 and 100"})
+			return
+		}
+		limit = n
+	}
+
+	grade, ok := optionalInt64Query(c, "grade")
+	if !ok {
+		c.JSON(400, gin.H{"error": "invalid grade"})
+		return
+	}
+	courseID, ok := optionalInt64Query(c, "courseId")
+	if !ok {
+		c.JSON(400, gin.H{"error": "invalid courseId"})
+		return
+	}
+	var distance sql.NullFloat64
+	if v := c.Query("distance"); v != "" {
+		meters, err := strconv.ParseFloat(v, 64)
+		if err != nil || meters <= 0 {
+			c.JSON(400, gin.H{"error": "invalid distance"})
+			return
+		}
+		distance = sql.NullFloat64{Float64: meters, Valid: true}
+	}
+	gender := c.Query("gender")
+	season := c.Query("season")
+
+	results, err := s.store.GetLeaderboardResults(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	// Rows arrive ordered by distance, so boards come out shortest first.
+	var distances []float64
+	byDistance := map[float64][]markCandidate{}
+	for i, r := range results {
+		if !r.AthleteID.Valid || !r.Time.Valid {
+			continue
+		}
+		if gender != "" && (!r.Gender.Valid || r.Gender.String != gender) {
+			continue
+		}
+		if g := gradeAtMeet(r.GraduationYear, r.MeetDate); grade.Valid && (!g.Valid || g.Int64 != grade.Int64) {
+			continue
+		}
+		if season != "" && meetSeason(r.MeetDate) != season {
+			continue
+		}
+		if courseID.Valid && r.CourseID.Int64 != courseID.Int64 {
+			continue
+		}
+		if distance.Valid && r.DistanceMeters != distance.Float64 {
+			continue
+		}
+		seconds, err := pace.Parse(r.Time.String)
+		if err != nil {
+			continue
+		}
+
+		if _, seen := byDistance[r.DistanceMeters]; !seen {
+			distances = append(distances, r.DistanceMeters)
+		}
+		byDistance[r.DistanceMeters] = append(byDistance[r.DistanceMeters], markCandidate{
+			AthleteID: r.AthleteID.Int64,
+			Name:      r.AthleteName,
+			Seconds:   seconds,
+			Row:       i,
+		})
+	}
+
+	response := make([]LeaderboardResponse, 0, len(distances))
+	for _, meters := range distances {
+		marks := bestPerAthlete(byDistance[meters])
+		ranks := competitionRanks(marks)
+
+		board := LeaderboardResponse{DistanceMeters: meters, Entries: []LeaderboardEntry{}}
+		for i, m := range marks {
+			if ranks[i] > limit {
+				break
+			}
+			r := results[m.Row]
+			board.Entries = append(board.Entries, LeaderboardEntry{
+				Rank:        ranks[i],
+				AthleteID:   r.AthleteID.Int64,
+				AthleteName: r.AthleteName,
+				Grade:       nullInt64ToPtr(gradeAtMeet(r.GraduationYear, r.MeetDate)),
+				Gender:      nullStringToPtr(r.Gender),
+				Time:        r.Time.String,
+				ResultID:    r.ID,
+				MeetID:      r.MeetID.Int64,
+				MeetName:    r.MeetName,
+				MeetDate:    nullStringToPtr(r.MeetDate),
+				CourseID:    r.CourseID.Int64,
+
+				PaceResponse: paceResponse(r.Time, sql.NullFloat64{Float64: r.DistanceMeters, Valid: true}),
+			})
+		}
+		response = append(response, board)
+	}
+	c.JSON(200, response)
+}
+
+// meetSeason returns the year a meet was run in, which is the cross country
+// season it belongs to.
+func meetSeason(date sql.NullString) string {
+	if !date.Valid || len(date.String) < 4 {
+		return ""
+	}
+	return date.String[:4]
+}
+
+func optionalInt64Query(c *gin.Context, key string) (sql.NullInt64, bool) {
+	v := c.Query(key)
+	if v == "" {
+		return sql.NullInt64{}, true
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return sql.NullInt64{}, false
+	}
+	return sql.NullInt64{Int64: n, Valid: true}, true
+}
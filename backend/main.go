@@ -6,8 +6,13 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"os"
 	"strings"
 	"time"
 
@@ -17,22 +22,45 @@ import (
 	_ "modernc.org/sqlite"
 
 	"jones-county-xc/backend/db"
+	"jones-county-xc/backend/pace"
 )
 
 // JSON-friendly response types
 type AthleteResponse struct {
-	ID             int64   `json:"id"`
-	Name           string  `json:"name"`
-	Grade          *int64  `json:"grade"`
-	PersonalRecord *string `json:"personal_record"`
-	Events         *string `json:"events"`
+	ID             int64    `json:"id"`
+	Name           string   `json:"name"`
+	Grade          *int64   `json:"grade"`
+	GraduationYear *int64   `json:"graduationYear"`
+	JerseyNumber   *int64   `json:"jerseyNumber"`
+	PersonalRecord *string  `json:"personal_record"`
+	Events         []string `json:"events"`
+	Gender         *string  `json:"gender"`
+	Status         string   `json:"status"`
 }
 
+// MeetResponse.Locked is true once the meet is final; its results cannot be
+// changed until it is reopened.
 type MeetResponse struct {
-	ID       int64   `json:"id"`
-	Name     string  `json:"name"`
-	Date     *string `json:"date"`
-	Location *string `json:"location"`
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	Date         *string `json:"date"`
+	Location     *string `json:"location"`
+	CourseID     *int64  `json:"courseId"`
+	Status       string  `json:"status"`
+	StartTime    *string `json:"startTime"`
+	StartsAt     *string `json:"startsAt"`
+	BusDeparture *string `json:"busDeparture"`
+	HostSchool   *string `json:"hostSchool"`
+	Locked       bool    `json:"locked"`
+}
+
+// PaceResponse is embedded in every result response. The fields are null
+// when the meet has no course, so the distance is unknown.
+type PaceResponse struct {
+	DistanceMeters *float64 `json:"distanceMeters"`
+	PacePerMile    *string  `json:"pacePerMile"`
+	PacePerKm      *string  `json:"pacePerKm"`
+	Equivalent5K   *string  `json:"equivalent5k"`
 }
 
 type ResultResponse struct {
@@ -41,6 +69,14 @@ type ResultResponse struct {
 	MeetID    *int64  `json:"meetId"`
 	Time      *string `json:"time"`
 	Place     *int64  `json:"place"`
+	PaceResponse
+}
+
+// CreatedResultResponse is returned by CreateResult and UpdateResult and
+// lists any school records the saved result broke.
+type CreatedResultResponse struct {
+	ResultResponse
+	NewRecords []RecordBreakResponse `json:"newRecords,omitempty"`
 }
 
 type MeetResultResponse struct {
@@ -50,10 +86,38 @@ type MeetResultResponse struct {
 	Time        *string `json:"time"`
 	Place       *int64  `json:"place"`
 	AthleteName string  `json:"athleteName"`
+	PaceResponse
+}
+
+type AthleteResultResponse struct {
+	ID        int64   `json:"id"`
+	AthleteID *int64  `json:"athleteId"`
+	MeetID    *int64  `json:"meetId"`
+	Time      *string `json:"time"`
+	Place     *int64  `json:"place"`
+	MeetName  string  `json:"meetName"`
+	MeetDate  *string `json:"meetDate"`
+	PaceResponse
 }
 
-var queries *db.Queries
-var database *sql.DB
+// Server holds what the handlers share. Handlers are methods on it and reach
+// the database only through store.
+type Server struct {
+	store Store
+	// requestTimeout bounds each API request; zero means no limit.
+	requestTimeout time.Duration
+	mail           mailConfig
+	listeners      []eventListener
+}
+
+// NewServer returns a server with the email and webhook queues listening
+// for events. Their workers are only started by serve.
+func NewServer(store Store) *Server {
+	s := &Server{store: store, mail: defaultMailConfig()}
+	s.onEvent(s.queueEmails)
+	s.onEvent(s.queueWebhooks)
+	return s
+}
 
 // --- Auth ---
 
@@ -85,7 +149,8 @@ func validateToken(token string) bool {
 	return hmac.Equal([]byte(token), []byte(expected))
 }
 
-func Login(c *gin.Context) {
+func (s *Server) Login(c *gin.Context) {
+	ctx := c.Request.Context()
 	var input struct {
 		Username string `json:"username"`
 		Password string `json:"password"`
@@ -96,7 +161,12 @@ func Login(c *gin.Context) {
 	}
 
 	if input.Username != adminUsername {
-		c.JSON(401, gin.H{"message": "Invalid username or password"})
+		account, ok := s.loginAccount(ctx, input.Username, input.Password)
+		if !ok {
+			c.JSON(401, gin.H{"message": "Invalid username or password"})
+			return
+		}
+		c.JSON(200, gin.H{"token": accountToken(account), "role": account.Role})
 		return
 	}
 	if err := bcrypt.CompareHashAndPassword(adminHash, []byte(input.Password)); err != nil {
@@ -104,7 +174,7 @@ func Login(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"token": generateToken(adminUsername)})
+	c.JSON(200, gin.H{"token": generateToken(adminUsername), "role": roleAdmin})
 }
 
 func AuthMiddleware() gin.HandlerFunc {
@@ -123,29 +193,131 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// defaultRequestTimeout applies when REQUEST_TIMEOUT is unset.
+const defaultRequestTimeout = 30 * time.Second
+
+// loadRequestTimeout reads REQUEST_TIMEOUT, a Go duration. "0" turns the
+// timeout off.
+func loadRequestTimeout() (time.Duration, error) {
+	v := os.Getenv("REQUEST_TIMEOUT")
+	if v == "" {
+		return defaultRequestTimeout, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid REQUEST_TIMEOUT %q", v)
+	}
+	return d, nil
+}
+
+// TimeoutMiddleware gives each request a deadline. Handlers pass the request
+// context to the store, so a query still running at the deadline is
+// interrupted and the handler answers through serverError.
+func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// statusClientClosedRequest is nginx's status for a request the client gave
+// up on. Nobody reads the response; it keeps these out of the 500s in logs.
+const statusClientClosedRequest = 499
+
+// serverError answers a request that failed for a reason other than bad
+// input. Failures caused by the request's context ending are reported as
+// such: 503 when it ran past the timeout, 499 when the client went away.
+func serverError(c *gin.Context, err error) {
+	ctxErr := c.Request.Context().Err()
+	if ctxErr == nil && (errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)) {
+		ctxErr = err
+	}
+	switch {
+	case errors.Is(ctxErr, context.DeadlineExceeded):
+		c.JSON(503, gin.H{"error": "request timed out"})
+	case errors.Is(ctxErr, context.Canceled):
+		c.JSON(statusClientClosedRequest, gin.H{"error": "request cancelled"})
+	default:
+		c.JSON(500, gin.H{"error": err.Error()})
+	}
+}
+
 // --- Read handlers ---
 
-func GetAthletes(c *gin.Context) {
-	athletes, err := queries.GetAllAthletes(context.Background())
+// GetAthletes lists the roster sorted by name. Alumni are left out unless
+// ?status=alumni or ?includeAlumni=true. Optional filters: q (name search),
+// event, gender, grade, graduationYear and status.
+func (s *Server) GetAthletes(c *gin.Context) {
+	ctx := c.Request.Context()
+	grade, ok := optionalInt64Query(c, "grade")
+	if !ok {
+		c.JSON(400, gin.H{"error": "invalid grade"})
+		return
+	}
+	graduationYear, ok := optionalInt64Query(c, "graduationYear")
+	if !ok {
+		c.JSON(400, gin.H{"error": "invalid graduationYear"})
+		return
+	}
+	status := c.Query("status")
+	if status != "" && !validStatus(status) {
+		c.JSON(400, gin.H{"error": "status must be one of active, injured, inactive, alumni"})
+		return
+	}
+	event := c.Query("event")
+	gender := c.Query("gender")
+	q := strings.ToLower(strings.TrimSpace(c.Query("q")))
+	includeAlumni := status == statusAlumni || c.Query("includeAlumni") == "true"
+
+	athletes, err := s.store.GetAllAthletes(ctx)
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		serverError(c, err)
+		return
+	}
+	events, err := s.athleteEventNames(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	statuses, err := s.athleteCurrentStatuses(ctx)
+	if err != nil {
+		serverError(c, err)
 		return
 	}
 
-	response := make([]AthleteResponse, len(athletes))
-	for i, a := range athletes {
-		response[i] = AthleteResponse{
-			ID:             a.ID,
-			Name:           a.Name,
-			Grade:          nullInt64ToPtr(a.Grade),
-			PersonalRecord: nullStringToPtr(a.PersonalRecord),
-			Events:         nullStringToPtr(a.Events),
+	response := make([]AthleteResponse, 0, len(athletes))
+	for _, a := range athletes {
+		r := athleteResponse(a, events[a.ID], statuses[a.ID])
+		if r.Status == statusAlumni && !includeAlumni {
+			continue
+		}
+		if q != "" && !strings.Contains(strings.ToLower(r.Name), q) {
+			continue
+		}
+		if event != "" && !hasEvent(r.Events, event) {
+			continue
+		}
+		if gender != "" && (r.Gender == nil || *r.Gender != gender) {
+			continue
+		}
+		if grade.Valid && (r.Grade == nil || *r.Grade != grade.Int64) {
+			continue
 		}
+		if graduationYear.Valid && a.GraduationYear != graduationYear {
+			continue
+		}
+		if status != "" && r.Status != status {
+			continue
+		}
+		response = append(response, r)
 	}
 	c.JSON(200, response)
 }
 
-func GetAthleteByID(c *gin.Context) {
+func (s *Server) GetAthleteByID(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
 	var athleteID int64
 	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
@@ -153,42 +325,65 @@ func GetAthleteByID(c *gin.Context) {
 		return
 	}
 
-	athlete, err := queries.GetAthleteByID(context.Background(), athleteID)
-	if err != nil {
+	athlete, err := s.store.GetAthleteByID(ctx, athleteID)
+	if err == sql.ErrNoRows {
 		c.JSON(404, gin.H{"error": "athlete not found"})
 		return
 	}
-
-	response := AthleteResponse{
-		ID:             athlete.ID,
-		Name:           athlete.Name,
-		Grade:          nullInt64ToPtr(athlete.Grade),
-		PersonalRecord: nullStringToPtr(athlete.PersonalRecord),
-		Events:         nullStringToPtr(athlete.Events),
+	if err != nil {
+		serverError(c, err)
+		return
 	}
-	c.JSON(200, response)
+	events, err := s.store.GetAthleteEventNames(ctx, athleteID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	statuses, err := s.store.GetAthleteStatuses(ctx, athleteID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	today := time.Now().Format("2006-01-02")
+	c.JSON(200, athleteResponse(athlete, events, currentStatus(statuses, today)))
 }
 
-func GetMeets(c *gin.Context) {
-	meets, err := queries.GetAllMeets(context.Background())
+// GetMeets lists meets by date. ?upcoming=true keeps meets from today on,
+// soonest first; ?past=true keeps earlier meets, most recent first. Meets
+// without a date are only in the unfiltered list.
+func (s *Server) GetMeets(c *gin.Context) {
+	ctx := c.Request.Context()
+	upcoming, past, msg := meetListFilter(c)
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	var meets []db.Meet
+	var err error
+	switch {
+	case upcoming:
+		meets, err = s.store.GetUpcomingMeets(ctx, meetToday())
+	case past:
+		meets, err = s.store.GetPastMeets(ctx, meetToday())
+	default:
+		meets, err = s.store.GetAllMeets(ctx)
+	}
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		serverError(c, err)
 		return
 	}
 
 	response := make([]MeetResponse, len(meets))
 	for i, m := range meets {
-		response[i] = MeetResponse{
-			ID:       m.ID,
-			Name:     m.Name,
-			Date:     nullStringToPtr(m.Date),
-			Location: nullStringToPtr(m.Location),
-		}
+		response[i] = meetResponse(m)
 	}
 	c.JSON(200, response)
 }
 
-func GetMeetByID(c *gin.Context) {
+func (s *Server) GetMeetByID(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
 	var meetID int64
 	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
@@ -196,25 +391,24 @@ func GetMeetByID(c *gin.Context) {
 		return
 	}
 
-	meet, err := queries.GetMeetByID(context.Background(), meetID)
-	if err != nil {
+	meet, err := s.store.GetMeetByID(ctx, meetID)
+	if err == sql.ErrNoRows {
 		c.JSON(404, gin.H{"error": "meet not found"})
 		return
 	}
-
-	response := MeetResponse{
-		ID:       meet.ID,
-		Name:     meet.Name,
-		Date:     nullStringToPtr(meet.Date),
-		Location: nullStringToPtr(meet.Location),
+	if err != nil {
+		serverError(c, err)
+		return
 	}
-	c.JSON(200, response)
+
+	c.JSON(200, meetResponse(meet))
 }
 
-func GetResults(c *gin.Context) {
-	results, err := queries.GetAllResults(context.Background())
+func (s *Server) GetResults(c *gin.Context) {
+	ctx := c.Request.Context()
+	results, err := s.store.GetAllResults(ctx)
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		serverError(c, err)
 		return
 	}
 
@@ -226,12 +420,45 @@ func GetResults(c *gin.Context) {
 			MeetID:    nullInt64ToPtr(r.MeetID),
 			Time:      nullStringToPtr(r.Time),
 			Place:     nullInt64ToPtr(r.Place),
+
+			PaceResponse: paceResponse(r.Time, r.DistanceMeters),
 		}
 	}
 	c.JSON(200, response)
 }
 
-func GetMeetResults(c *gin.Context) {
+func (s *Server) GetResultByID(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var resultID int64
+	if _, err := fmt.Sscanf(id, "%d", &resultID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid result ID"})
+		return
+	}
+
+	result, err := s.store.GetResultByID(ctx, resultID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "result not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	c.JSON(200, ResultResponse{
+		ID:        result.ID,
+		AthleteID: nullInt64ToPtr(result.AthleteID),
+		MeetID:    nullInt64ToPtr(result.MeetID),
+		Time:      nullStringToPtr(result.Time),
+		Place:     nullInt64ToPtr(result.Place),
+
+		PaceResponse: paceResponse(result.Time, s.meetDistance(ctx, result.MeetID.Int64)),
+	})
+}
+
+func (s *Server) GetMeetResults(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
 	var meetID int64
 	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
@@ -239,9 +466,9 @@ func GetMeetResults(c *gin.Context) {
 		return
 	}
 
-	results, err := queries.GetResultsByMeet(context.Background(), sql.NullInt64{Int64: meetID, Valid: true})
+	results, err := s.store.GetResultsByMeet(ctx, sql.NullInt64{Int64: meetID, Valid: true})
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		serverError(c, err)
 		return
 	}
 
@@ -254,6 +481,40 @@ func GetMeetResults(c *gin.Context) {
 			Time:        nullStringToPtr(r.Time),
 			Place:       nullInt64ToPtr(r.Place),
 			AthleteName: r.AthleteName,
+
+			PaceResponse: paceResponse(r.Time, r.DistanceMeters),
+		}
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) GetAthleteResults(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+
+	results, err := s.store.GetResultsByAthlete(ctx, sql.NullInt64{Int64: athleteID, Valid: true})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]AthleteResultResponse, len(results))
+	for i, r := range results {
+		response[i] = AthleteResultResponse{
+			ID:        r.ID,
+			AthleteID: nullInt64ToPtr(r.AthleteID),
+			MeetID:    nullInt64ToPtr(r.MeetID),
+			Time:      nullStringToPtr(r.Time),
+			Place:     nullInt64ToPtr(r.Place),
+			MeetName:  r.MeetName,
+			MeetDate:  nullStringToPtr(r.MeetDate),
+
+			PaceResponse: paceResponse(r.Time, r.DistanceMeters),
 		}
 	}
 	c.JSON(200, response)
@@ -261,13 +522,61 @@ func GetMeetResults(c *gin.Context) {
 
 // --- Athlete write handlers ---
 
-func CreateAthlete(c *gin.Context) {
-	var input struct {
-		Name           string  `json:"name"`
-		Grade          *int64  `json:"grade"`
-		PersonalRecord *string `json:"personal_record"`
-		Events         *string `json:"events"`
+// athleteInput takes either graduationYear or, for convenience, the
+// athlete's grade this school year, which is stored as a graduation year.
+type athleteInput struct {
+	Name           string   `json:"name"`
+	Grade          *int64   `json:"grade"`
+	GraduationYear *int64   `json:"graduationYear"`
+	JerseyNumber   *int64   `json:"jerseyNumber"`
+	PersonalRecord *string  `json:"personal_record"`
+	Events         []string `json:"events"`
+	Gender         *string  `json:"gender"`
+}
+
+// validate checks the profile fields and resolves grade into
+// GraduationYear. It returns an error message, or "" if the input is valid.
+func (input *athleteInput) validate() string {
+	if !validGender(input.Gender) {
+		return "gender must be \"M\" or \"F\""
 	}
+	if input.Grade != nil && (*input.Grade < 1 || *input.Grade > 12) {
+		return "grade must be between 1 and 12"
+	}
+	if input.GraduationYear == nil && input.Grade != nil {
+		year := graduationYearFor(*input.Grade)
+		input.GraduationYear = &year
+	}
+	if input.JerseyNumber != nil && *input.JerseyNumber < 0 {
+		return "jerseyNumber must not be negative"
+	}
+	return ""
+}
+
+// apply returns a with the validated input's fields written over it. Only
+// the JSON fields named in set are written; grade sets the graduation year.
+func (input *athleteInput) apply(a db.Athlete, set map[string]bool) db.Athlete {
+	if set["name"] {
+		a.Name = input.Name
+	}
+	if set["personal_record"] {
+		a.PersonalRecord = ptrToNullString(input.PersonalRecord)
+	}
+	if set["gender"] {
+		a.Gender = ptrToNullString(input.Gender)
+	}
+	if set["graduationYear"] || set["grade"] {
+		a.GraduationYear = ptrToNullInt64(input.GraduationYear)
+	}
+	if set["jerseyNumber"] {
+		a.JerseyNumber = ptrToNullInt64(input.JerseyNumber)
+	}
+	return a
+}
+
+func (s *Server) CreateAthlete(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input athleteInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
@@ -276,28 +585,48 @@ func CreateAthlete(c *gin.Context) {
 		c.JSON(400, gin.H{"error": "name is required"})
 		return
 	}
+	if msg := input.validate(); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
 
-	athlete, err := queries.CreateAthlete(context.Background(), db.CreateAthleteParams{
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	athlete, err := tx.CreateAthlete(ctx, db.CreateAthleteParams{
 		Name:           input.Name,
-		Grade:          ptrToNullInt64(input.Grade),
 		PersonalRecord: ptrToNullString(input.PersonalRecord),
-		Events:         ptrToNullString(input.Events),
+		Gender:         ptrToNullString(input.Gender),
+		GraduationYear: ptrToNullInt64(input.GraduationYear),
+		JerseyNumber:   ptrToNullInt64(input.JerseyNumber),
 	})
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		serverError(c, err)
+		return
+	}
+	events, err := setAthleteEvents(ctx, tx, athlete.ID, input.Events)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
 		return
 	}
 
-	c.JSON(201, AthleteResponse{
-		ID:             athlete.ID,
-		Name:           athlete.Name,
-		Grade:          nullInt64ToPtr(athlete.Grade),
-		PersonalRecord: nullStringToPtr(athlete.PersonalRecord),
-		Events:         nullStringToPtr(athlete.Events),
-	})
+	response := athleteResponse(athlete, events, statusActive)
+	s.emitEvent(EventAthleteCreated, response)
+	c.JSON(201, response)
 }
 
-func UpdateAthlete(c *gin.Context) {
+// UpdateAthlete changes the fields present in the body, including the
+// event list. Fields left out keep their values; null clears one.
+func (s *Server) UpdateAthlete(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
 	var athleteID int64
 	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
@@ -305,39 +634,79 @@ func UpdateAthlete(c *gin.Context) {
 		return
 	}
 
-	var input struct {
-		Name           string  `json:"name"`
-		Grade          *int64  `json:"grade"`
-		PersonalRecord *string `json:"personal_record"`
-		Events         *string `json:"events"`
-	}
-	if err := c.ShouldBindJSON(&input); err != nil {
+	var input athleteInput
+	set, err := bindFields(c, &input)
+	if err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
+	if msg := input.validate(); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	existing, err := tx.GetAthleteByID(ctx, athleteID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "athlete not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	a := input.apply(existing, set)
+	if a.Name == "" {
+		c.JSON(400, gin.H{"error": "name is required"})
+		return
+	}
 
-	athlete, err := queries.UpdateAthlete(context.Background(), db.UpdateAthleteParams{
+	athlete, err := tx.UpdateAthlete(ctx, db.UpdateAthleteParams{
 		ID:             athleteID,
-		Name:           input.Name,
-		Grade:          ptrToNullInt64(input.Grade),
-		PersonalRecord: ptrToNullString(input.PersonalRecord),
-		Events:         ptrToNullString(input.Events),
+		Name:           a.Name,
+		PersonalRecord: a.PersonalRecord,
+		Gender:         a.Gender,
+		GraduationYear: a.GraduationYear,
+		JerseyNumber:   a.JerseyNumber,
 	})
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		serverError(c, err)
+		return
+	}
+	var events []string
+	if set["events"] {
+		events, err = setAthleteEvents(ctx, tx, athleteID, input.Events)
+	} else {
+		events, err = tx.GetAthleteEventNames(ctx, athleteID)
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	statuses, err := tx.GetAthleteStatuses(ctx, athleteID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
 		return
 	}
 
-	c.JSON(200, AthleteResponse{
-		ID:             athlete.ID,
-		Name:           athlete.Name,
-		Grade:          nullInt64ToPtr(athlete.Grade),
-		PersonalRecord: nullStringToPtr(athlete.PersonalRecord),
-		Events:         nullStringToPtr(athlete.Events),
-	})
+	today := time.Now().Format("2006-01-02")
+	response := athleteResponse(athlete, events, currentStatus(statuses, today))
+	s.emitEvent(EventAthleteUpdated, response)
+	c.JSON(200, response)
 }
 
-func DeleteAthlete(c *gin.Context) {
+func (s *Server) DeleteAthlete(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
 	var athleteID int64
 	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
@@ -345,49 +714,126 @@ func DeleteAthlete(c *gin.Context) {
 		return
 	}
 
-	if err := queries.DeleteAthlete(context.Background(), athleteID); err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+	n, err := s.store.DeleteAthlete(ctx, athleteID)
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY") {
+			c.JSON(409, gin.H{"error": "athlete has results; delete them first"})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "athlete not found"})
 		return
 	}
+	s.emitEvent(EventAthleteDeleted, DeletedEvent{ID: athleteID})
 	c.JSON(200, gin.H{"message": "athlete deleted"})
 }
 
 // --- Meet write handlers ---
 
-func CreateMeet(c *gin.Context) {
-	var input struct {
-		Name     string  `json:"name"`
-		Date     *string `json:"date"`
-		Location *string `json:"location"`
+// meetInput holds the editable meet fields. Status is changed separately
+// through UpdateMeetStatus.
+type meetInput struct {
+	Name         string  `json:"name"`
+	Date         *string `json:"date"`
+	Location     *string `json:"location"`
+	CourseID     *int64  `json:"courseId"`
+	StartTime    *string `json:"startTime"`
+	BusDeparture *string `json:"busDeparture"`
+	HostSchool   *string `json:"hostSchool"`
+}
+
+// apply returns m with the input's fields written over it. Only the JSON
+// fields named in set are written; a nil set writes them all.
+func (in meetInput) apply(m db.Meet, set map[string]bool) db.Meet {
+	has := func(field string) bool { return set == nil || set[field] }
+	if has("name") {
+		m.Name = in.Name
+	}
+	if has("date") {
+		m.Date = ptrToNullString(in.Date)
+	}
+	if has("location") {
+		m.Location = ptrToNullString(in.Location)
+	}
+	if has("courseId") {
+		m.CourseID = ptrToNullInt64(in.CourseID)
+	}
+	if has("startTime") {
+		m.StartTime = ptrToNullString(in.StartTime)
+	}
+	if has("busDeparture") {
+		m.BusDeparture = ptrToNullString(in.BusDeparture)
+	}
+	if has("hostSchool") {
+		m.HostSchool = ptrToNullString(in.HostSchool)
+	}
+	return m
+}
+
+// bindFields binds a JSON object body into v and returns the names of the
+// fields it contained, so an update can keep the fields left out.
+func bindFields(c *gin.Context, v any) (map[string]bool, error) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(fields))
+	for name := range fields {
+		set[name] = true
 	}
+	return set, nil
+}
+
+func (s *Server) CreateMeet(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input meetInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
-	if input.Name == "" {
-		c.JSON(400, gin.H{"error": "name is required"})
+	if msg := input.normalize(); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+	m := input.apply(db.Meet{}, nil)
+	if msg := checkMeet(m); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
 		return
 	}
 
-	meet, err := queries.CreateMeet(context.Background(), db.CreateMeetParams{
-		Name:     input.Name,
-		Date:     ptrToNullString(input.Date),
-		Location: ptrToNullString(input.Location),
+	meet, err := s.store.CreateMeet(ctx, db.CreateMeetParams{
+		Name:         m.Name,
+		Date:         m.Date,
+		Location:     m.Location,
+		CourseID:     m.CourseID,
+		StartTime:    m.StartTime,
+		BusDeparture: m.BusDeparture,
+		HostSchool:   m.HostSchool,
 	})
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		serverError(c, err)
 		return
 	}
 
-	c.JSON(201, MeetResponse{
-		ID:       meet.ID,
-		Name:     meet.Name,
-		Date:     nullStringToPtr(meet.Date),
-		Location: nullStringToPtr(meet.Location),
-	})
+	response := meetResponse(meet)
+	s.emitEvent(EventMeetCreated, response)
+	c.JSON(201, response)
 }
 
-func UpdateMeet(c *gin.Context) {
+// UpdateMeet changes the fields present in the body. Fields left out keep
+// their values; null or a blank string clears one.
+func (s *Server) UpdateMeet(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
 	var meetID int64
 	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
@@ -395,36 +841,65 @@ func UpdateMeet(c *gin.Context) {
 		return
 	}
 
-	var input struct {
-		Name     string  `json:"name"`
-		Date     *string `json:"date"`
-		Location *string `json:"location"`
-	}
-	if err := c.ShouldBindJSON(&input); err != nil {
+	var input meetInput
+	set, err := bindFields(c, &input)
+	if err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
+	if msg := input.normalize(); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
 
-	meet, err := queries.UpdateMeet(context.Background(), db.UpdateMeetParams{
-		ID:       meetID,
-		Name:     input.Name,
-		Date:     ptrToNullString(input.Date),
-		Location: ptrToNullString(input.Location),
-	})
+	tx, err := s.store.Begin(ctx)
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		serverError(c, err)
 		return
 	}
+	defer tx.Rollback()
 
-	c.JSON(200, MeetResponse{
-		ID:       meet.ID,
-		Name:     meet.Name,
-		Date:     nullStringToPtr(meet.Date),
-		Location: nullStringToPtr(meet.Location),
+	previous, err := tx.GetMeetByID(ctx, meetID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "meet not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	m := input.apply(previous, set)
+	if msg := checkMeet(m); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	meet, err := tx.UpdateMeet(ctx, db.UpdateMeetParams{
+		ID:           meetID,
+		Name:         m.Name,
+		Date:         m.Date,
+		Location:     m.Location,
+		CourseID:     m.CourseID,
+		StartTime:    m.StartTime,
+		BusDeparture: m.BusDeparture,
+		HostSchool:   m.HostSchool,
 	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := meetResponse(meet)
+	s.emitEvent(EventMeetUpdated, MeetUpdatedEvent{Meet: response, PreviousDate: nullStringToPtr(previous.Date)})
+	c.JSON(200, response)
 }
 
-func DeleteMeet(c *gin.Context) {
+func (s *Server) DeleteMeet(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
 	var meetID int64
 	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
@@ -432,16 +907,27 @@ func DeleteMeet(c *gin.Context) {
 		return
 	}
 
-	if err := queries.DeleteMeet(context.Background(), meetID); err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+	n, err := s.store.DeleteMeet(ctx, meetID)
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY") {
+			c.JSON(409, gin.H{"error": "meet has results; delete them first"})
+			return
+		}
+		serverError(c, err)
 		return
 	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "meet not found"})
+		return
+	}
+	s.emitEvent(EventMeetDeleted, DeletedEvent{ID: meetID})
 	c.JSON(200, gin.H{"message": "meet deleted"})
 }
 
 // --- Result write handlers ---
 
-func CreateResult(c *gin.Context) {
+func (s *Server) CreateResult(c *gin.Context) {
+	ctx := c.Request.Context()
 	var input struct {
 		AthleteID int64  `json:"athleteId"`
 		MeetID    int64  `json:"meetId"`
@@ -453,28 +939,46 @@ func CreateResult(c *gin.Context) {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
+	if _, err := pace.Parse(input.Time); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if !s.requireMeetInProgress(c, input.MeetID) {
+		return
+	}
 
-	result, err := queries.CreateResult(context.Background(), db.CreateResultParams{
+	result, err := s.store.CreateResult(ctx, db.CreateResultParams{
 		AthleteID: sql.NullInt64{Int64: input.AthleteID, Valid: true},
 		MeetID:    sql.NullInt64{Int64: input.MeetID, Valid: true},
 		Time:      sql.NullString{String: input.Time, Valid: true},
 		Place:     sql.NullInt64{Int64: input.Place, Valid: true},
 	})
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		serverError(c, err)
 		return
 	}
 
-	c.JSON(201, ResultResponse{
-		ID:        result.ID,
-		AthleteID: nullInt64ToPtr(result.AthleteID),
-		MeetID:    nullInt64ToPtr(result.MeetID),
-		Time:      nullStringToPtr(result.Time),
-		Place:     nullInt64ToPtr(result.Place),
-	})
+	// The result is saved; record the breaks it caused even if the client
+	// has gone away.
+	recordCtx := context.WithoutCancel(ctx)
+	response := CreatedResultResponse{
+		ResultResponse: ResultResponse{
+			ID:        result.ID,
+			AthleteID: nullInt64ToPtr(result.AthleteID),
+			MeetID:    nullInt64ToPtr(result.MeetID),
+			Time:      nullStringToPtr(result.Time),
+			Place:     nullInt64ToPtr(result.Place),
+
+			PaceResponse: paceResponse(result.Time, s.meetDistance(ctx, input.MeetID)),
+		},
+		NewRecords: s.checkRecords(recordCtx, result.ID),
+	}
+	s.emitEvent(EventResultCreated, response)
+	c.JSON(201, response)
 }
 
-func UpdateResult(c *gin.Context) {
+func (s *Server) UpdateResult(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
 	var resultID int64
 	if _, err := fmt.Sscanf(id, "%d", &resultID); err != nil {
@@ -492,8 +996,28 @@ func UpdateResult(c *gin.Context) {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
+	if _, err := pace.Parse(input.Time); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	existing, err := s.store.GetResultByID(ctx, resultID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "result not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if !s.requireMeetInProgress(c, existing.MeetID.Int64) {
+		return
+	}
+	if input.MeetID != existing.MeetID.Int64 && !s.requireMeetInProgress(c, input.MeetID) {
+		return
+	}
 
-	result, err := queries.UpdateResult(context.Background(), db.UpdateResultParams{
+	result, err := s.store.UpdateResult(ctx, db.UpdateResultParams{
 		ID:        resultID,
 		AthleteID: sql.NullInt64{Int64: input.AthleteID, Valid: true},
 		MeetID:    sql.NullInt64{Int64: input.MeetID, Valid: true},
@@ -501,20 +1025,31 @@ func UpdateResult(c *gin.Context) {
 		Place:     sql.NullInt64{Int64: input.Place, Valid: true},
 	})
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		serverError(c, err)
 		return
 	}
 
-	c.JSON(200, ResultResponse{
-		ID:        result.ID,
-		AthleteID: nullInt64ToPtr(result.AthleteID),
-		MeetID:    nullInt64ToPtr(result.MeetID),
-		Time:      nullStringToPtr(result.Time),
-		Place:     nullInt64ToPtr(result.Place),
-	})
+	response := CreatedResultResponse{
+		ResultResponse: ResultResponse{
+			ID:        result.ID,
+			AthleteID: nullInt64ToPtr(result.AthleteID),
+			MeetID:    nullInt64ToPtr(result.MeetID),
+			Time:      nullStringToPtr(result.Time),
+			Place:     nullInt64ToPtr(result.Place),
+
+			PaceResponse: paceResponse(result.Time, s.meetDistance(ctx, input.MeetID)),
+		},
+	}
+	// A corrected time or a move to another meet can set a new record.
+	if result.Time != existing.Time || result.MeetID != existing.MeetID || result.AthleteID != existing.AthleteID {
+		response.NewRecords = s.checkRecords(context.WithoutCancel(ctx), result.ID)
+	}
+	s.emitEvent(EventResultUpdated, response)
+	c.JSON(200, response)
 }
 
-func DeleteResult(c *gin.Context) {
+func (s *Server) DeleteResult(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
 	var resultID int64
 	if _, err := fmt.Sscanf(id, "%d", &resultID); err != nil {
@@ -522,16 +1057,30 @@ func DeleteResult(c *gin.Context) {
 		return
 	}
 
-	if err := queries.DeleteResult(context.Background(), resultID); err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+	existing, err := s.store.GetResultByID(ctx, resultID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "result not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
 		return
 	}
+	if !s.requireMeetInProgress(c, existing.MeetID.Int64) {
+		return
+	}
+
+	if err := s.store.DeleteResult(ctx, resultID); err != nil {
+		serverError(c, err)
+		return
+	}
+	s.emitEvent(EventResultDeleted, DeletedEvent{ID: resultID})
 	c.JSON(200, gin.H{"message": "result deleted"})
 }
 
 // --- Misc ---
 
-func HealthCheck(c *gin.Context) {
+func (s *Server) HealthCheck(c *gin.Context) {
 	c.JSON(200, gin.H{
 		"status":    "healthy",
 		"message":   "Backend server is running!",
@@ -555,6 +1104,13 @@ func nullInt64ToPtr(ni sql.NullInt64) *int64 {
 	return nil
 }
 
+func nullFloat64ToPtr(nf sql.NullFloat64) *float64 {
+	if nf.Valid {
+		return &nf.Float64
+	}
+	return nil
+}
+
 func ptrToNullString(s *string) sql.NullString {
 	if s != nil {
 		return sql.NullString{String: *s, Valid: true}
@@ -569,68 +1125,306 @@ func ptrToNullInt64(i *int64) sql.NullInt64 {
 	return sql.NullInt64{}
 }
 
-func initDB() {
-	var err error
-	database, err = sql.Open("sqlite", "data.db")
+func ptrToNullFloat64(f *float64) sql.NullFloat64 {
+	if f != nil {
+		return sql.NullFloat64{Float64: *f, Valid: true}
+	}
+	return sql.NullFloat64{}
+}
+
+func meetResponse(m db.Meet) MeetResponse {
+	return MeetResponse{
+		ID:           m.ID,
+		Name:         m.Name,
+		Date:         nullStringToPtr(m.Date),
+		Location:     nullStringToPtr(m.Location),
+		CourseID:     nullInt64ToPtr(m.CourseID),
+		Status:       m.Status,
+		StartTime:    nullStringToPtr(m.StartTime),
+		StartsAt:     meetStartsAt(m),
+		BusDeparture: nullStringToPtr(m.BusDeparture),
+		HostSchool:   nullStringToPtr(m.HostSchool),
+		Locked:       m.Status == meetFinal,
+	}
+}
+
+func athleteResponse(a db.Athlete, events []string, status string) AthleteResponse {
+	if events == nil {
+		events = []string{}
+	}
+	if status == "" {
+		status = statusActive
+	}
+	return AthleteResponse{
+		ID:             a.ID,
+		Name:           a.Name,
+		Grade:          nullInt64ToPtr(gradeOn(a.GraduationYear, time.Now())),
+		GraduationYear: nullInt64ToPtr(a.GraduationYear),
+		JerseyNumber:   nullInt64ToPtr(a.JerseyNumber),
+		PersonalRecord: nullStringToPtr(a.PersonalRecord),
+		Events:         events,
+		Gender:         nullStringToPtr(a.Gender),
+		Status:         status,
+	}
+}
+
+func validGender(g *string) bool {
+	return g == nil || *g == "M" || *g == "F"
+}
+
+// paceResponse derives paces and a Riegel 5K equivalent for a result. It
+// returns empty fields when the distance is unknown or the time won't parse.
+func paceResponse(t sql.NullString, meters sql.NullFloat64) PaceResponse {
+	if !t.Valid || !meters.Valid {
+		return PaceResponse{}
+	}
+	conv, err := pace.Convert(t.String, meters.Float64)
 	if err != nil {
-		log.Fatalf("Failed to open database: %v", err)
+		return PaceResponse{DistanceMeters: &meters.Float64}
 	}
+	perMile := pace.Format(conv.PerMile)
+	perKm := pace.Format(conv.PerKilometer)
+	equivalent := pace.Format(conv.Equivalent5K)
+	return PaceResponse{
+		DistanceMeters: &meters.Float64,
+		PacePerMile:    &perMile,
+		PacePerKm:      &perKm,
+		Equivalent5K:   &equivalent,
+	}
+}
 
-	if _, err := database.Exec("PRAGMA journal_mode=WAL"); err != nil {
-		log.Fatalf("Failed to set journal mode: %v", err)
+// meetDistance looks up the course distance for a meet, if it has one.
+func (s *Server) meetDistance(ctx context.Context, meetID int64) sql.NullFloat64 {
+	meters, err := s.store.GetMeetDistance(ctx, meetID)
+	if err != nil {
+		return sql.NullFloat64{}
 	}
+	return sql.NullFloat64{Float64: meters, Valid: true}
+}
+
+// busyTimeout is how long a connection waits on another connection's write
+// lock before a query fails with SQLITE_BUSY.
+const busyTimeout = 5 * time.Second
 
-	if _, err := database.Exec("PRAGMA foreign_keys=ON"); err != nil {
-		log.Fatalf("Failed to enable foreign keys: %v", err)
+// openDatabase opens the SQLite file at path with the settings the server
+// relies on. It does not run migrations. The pragmas go in the DSN so the
+// driver applies them to every connection in the pool, not just the first.
+func openDatabase(path string) (*sql.DB, error) {
+	conn, err := sql.Open("sqlite", databaseDSN(path))
+	if err != nil {
+		return nil, err
 	}
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
 
-	queries = db.New(database)
+// databaseDSN is the file: URI for path with foreign keys, the busy timeout
+// and WAL turned on.
+func databaseDSN(path string) string {
+	path = strings.NewReplacer("%", "%25", "?", "%3f", "#", "%23").Replace(path)
+	return fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(%d)&_pragma=journal_mode(WAL)",
+		path, busyTimeout.Milliseconds())
+}
+
+// openStore opens the database at path and brings it up to date.
+func openStore(path string) (*sqlStore, error) {
+	conn, err := openDatabase(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %v", err)
+	}
+	if err := runMigrations(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("running migrations: %v", err)
+	}
+
+	store := newSQLStore(conn)
 	log.Println("Database initialized successfully")
+	return store, nil
 }
 
 func main() {
-	initDB()
-	defer database.Close()
+	flag.StringVar(&dbPath, "db", "data.db", "path to the SQLite database")
+	flag.Usage = printUsage
+	flag.Parse()
 
+	if err := runCommand(flag.Args()); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+// serve runs the HTTP server until it fails.
+func serve(s *Server, addr string) error {
 	initAuth()
+	var err error
+	if s.mail, err = loadMailConfig(); err != nil {
+		return err
+	}
+	s.startEmailWorker()
+	s.startWebhookWorker()
+	backups, err := loadBackupConfig()
+	if err != nil {
+		return err
+	}
+	if s.requestTimeout, err = loadRequestTimeout(); err != nil {
+		return err
+	}
+	s.startBackupScheduler(backups)
+
+	log.Printf("Server starting on %s", addr)
+	return NewRouter(s).Run(addr)
+}
 
+// NewRouter builds the API routes. It starts nothing in the background, so
+// tests can drive it directly with httptest.
+func NewRouter(s *Server) *gin.Engine {
 	r := gin.Default()
 	r.Use(cors.Default())
+	if s.requestTimeout > 0 {
+		r.Use(TimeoutMiddleware(s.requestTimeout))
+	}
 
-	r.GET("/health", HealthCheck)
+	r.GET("/health", s.HealthCheck)
 
 	api := r.Group("/api")
 	{
-		api.GET("/health", HealthCheck)
+		api.GET("/health", s.HealthCheck)
 
 		// Auth
-		api.POST("/auth/login", Login)
+		api.POST("/auth/login", s.Login)
+		api.POST("/accounts", s.Register)
+		api.GET("/unsubscribe", s.ConfirmUnsubscribe)
+		api.POST("/unsubscribe", s.Unsubscribe)
 
 		// Public read endpoints
-		api.GET("/athletes", GetAthletes)
-		api.GET("/athletes/:id", GetAthleteByID)
-		api.GET("/meets", GetMeets)
-		api.GET("/meets/:id", GetMeetByID)
-		api.GET("/meets/:id/results", GetMeetResults)
-		api.GET("/results", GetResults)
+		api.GET("/athletes", s.GetAthletes)
+		api.GET("/athletes/:id", s.GetAthleteByID)
+		api.GET("/athletes/:id/results", s.GetAthleteResults)
+		api.GET("/athletes/:id/analytics", s.GetAthleteAnalytics)
+		api.GET("/athletes/:id/statuses", s.GetAthleteStatuses)
+		api.GET("/alumni", s.GetAlumni)
+		api.GET("/compare", s.GetCompare)
+		api.GET("/meets", s.GetMeets)
+		api.GET("/meets/next", s.GetNextMeet)
+		api.GET("/meets/:id", s.GetMeetByID)
+		api.GET("/meets/:id/results", s.GetMeetResults)
+		api.GET("/meets/:id/bibs", s.GetMeetBibs)
+		api.GET("/meets/:id/races", s.GetMeetRaces)
+		api.GET("/meets/:id/entries/export", s.ExportMeetEntries)
+		api.GET("/results", s.GetResults)
+		api.GET("/results/:id", s.GetResultByID)
+		api.GET("/results/:id/splits", s.GetResultSplits)
+		api.GET("/leaderboards", s.GetLeaderboards)
+		api.GET("/records", s.GetRecords)
+		api.GET("/records/breaks", s.GetRecordBreaks)
+		api.GET("/records/historical", s.GetHistoricalMarks)
+		api.GET("/courses", s.GetCourses)
+		api.GET("/courses/:id", s.GetCourseByID)
+		api.GET("/courses/:id/bests", s.GetCourseBests)
+		api.GET("/events", s.GetEvents)
+
+		// Athlete and parent accounts (or the admin). Routes about one
+		// athlete check that the account is linked to that athlete.
+		self := api.Group("/", s.AccountMiddleware())
+		{
+			self.GET("/me", s.GetMe)
+			self.POST("/me/athletes", s.LinkAthlete)
+			self.GET("/me/notifications", s.GetMyNotifications)
+			self.PUT("/me/notifications", s.UpdateMyNotifications)
+			self.DELETE("/me/notifications", s.DeleteMyNotifications)
+
+			owned := self.Group("/athletes/:id", RequireAthleteOwner(athleteParamOwner))
+			owned.GET("/workouts", s.GetAthleteWorkouts)
+			owned.POST("/workouts", s.CreateWorkout)
+			owned.GET("/mileage", s.GetAthleteMileage)
+			owned.GET("/plans", s.GetAthletePlans)
+			owned.GET("/attendance", s.GetAthleteAttendance)
+			owned.GET("/profile", s.GetAthleteProfile)
+			owned.PUT("/profile", s.UpdateAthleteProfile)
+
+			self.PUT("/workouts/:id", RequireAthleteOwner(s.workoutOwner), s.UpdateWorkout)
+			self.DELETE("/workouts/:id", RequireAthleteOwner(s.workoutOwner), s.DeleteWorkout)
+		}
 
 		// Protected write endpoints
 		admin := api.Group("/", AuthMiddleware())
 		{
-			admin.POST("/athletes", CreateAthlete)
-			admin.PUT("/athletes/:id", UpdateAthlete)
-			admin.DELETE("/athletes/:id", DeleteAthlete)
-
-			admin.POST("/meets", CreateMeet)
-			admin.PUT("/meets/:id", UpdateMeet)
-			admin.DELETE("/meets/:id", DeleteMeet)
-
-			admin.POST("/results", CreateResult)
-			admin.PUT("/results/:id", UpdateResult)
-			admin.DELETE("/results/:id", DeleteResult)
+			admin.POST("/athletes", s.CreateAthlete)
+			admin.PUT("/athletes/:id", s.UpdateAthlete)
+			admin.DELETE("/athletes/:id", s.DeleteAthlete)
+			admin.POST("/athletes/:id/statuses", s.CreateAthleteStatus)
+			admin.PUT("/athletes/:id/statuses/:statusId", s.UpdateAthleteStatus)
+			admin.DELETE("/athletes/:id/statuses/:statusId", s.DeleteAthleteStatus)
+			admin.GET("/mileage", s.GetTeamMileage)
+			admin.GET("/plans", s.GetWorkoutPlans)
+			admin.POST("/plans", s.CreateWorkoutPlan)
+			admin.PUT("/plans/:id", s.UpdateWorkoutPlan)
+			admin.DELETE("/plans/:id", s.DeleteWorkoutPlan)
+			admin.GET("/sessions", s.GetSessions)
+			admin.GET("/sessions/:id", s.GetSession)
+			admin.POST("/sessions", s.CreateSession)
+			admin.PUT("/sessions/:id", s.UpdateSession)
+			admin.DELETE("/sessions/:id", s.DeleteSession)
+			admin.PUT("/sessions/:id/attendance", s.SetAttendance)
+			admin.DELETE("/sessions/:id/attendance/:athleteId", s.DeleteAttendance)
+			admin.GET("/attendance", s.GetAttendanceSummary)
+			admin.POST("/athletes/:id/invites", s.CreateInvite)
+			admin.GET("/invites", s.GetInvites)
+			admin.DELETE("/invites/:id", s.DeleteInvite)
+			admin.GET("/accounts", s.GetAccounts)
+			admin.DELETE("/accounts/:id", s.DeleteAccount)
+			admin.GET("/subscriptions", s.GetSubscriptions)
+			admin.POST("/subscriptions", s.CreateSubscription)
+			admin.DELETE("/subscriptions/:id", s.DeleteSubscription)
+			admin.GET("/outbox", s.GetOutbox)
+			admin.POST("/outbox/:id/retry", s.RetryEmail)
+			admin.GET("/webhooks", s.GetWebhooks)
+			admin.POST("/webhooks", s.CreateWebhook)
+			admin.PUT("/webhooks/:id", s.UpdateWebhook)
+			admin.DELETE("/webhooks/:id", s.DeleteWebhook)
+			admin.POST("/webhooks/:id/ping", s.PingWebhook)
+			admin.GET("/webhooks/:id/deliveries", s.GetWebhookDeliveries)
+			admin.POST("/webhooks/:id/deliveries/:deliveryId/retry", s.RetryWebhookDelivery)
+			admin.GET("/admin/export", s.ExportArchive)
+			admin.POST("/admin/import", s.ImportArchive)
+			admin.GET("/backups", s.GetBackups)
+			admin.POST("/backups", s.CreateBackup)
+			admin.GET("/rollover/preview", s.PreviewRollover)
+			admin.POST("/rollover", s.ApplyRollover)
+
+			admin.POST("/meets", s.CreateMeet)
+			admin.PUT("/meets/:id", s.UpdateMeet)
+			admin.DELETE("/meets/:id", s.DeleteMeet)
+			admin.POST("/meets/:id/status", s.UpdateMeetStatus)
+
+			admin.POST("/meets/:id/bibs", s.AssignBib)
+			admin.DELETE("/meets/:id/bibs/:athleteId", s.DeleteBib)
+			admin.POST("/meets/:id/timing/preview", s.PreviewTiming)
+			admin.POST("/meets/:id/timing/commit", s.CommitTiming)
+
+			admin.POST("/meets/:id/races", s.CreateRace)
+			admin.PUT("/races/:id", s.UpdateRace)
+			admin.DELETE("/races/:id", s.DeleteRace)
+			admin.PUT("/races/:id/entries", s.SetRaceEntries)
+			admin.GET("/meets/:id/results/sheet", s.GetMeetResultSheet)
+
+			admin.POST("/results", s.CreateResult)
+			admin.PUT("/results/:id", s.UpdateResult)
+			admin.DELETE("/results/:id", s.DeleteResult)
+			admin.PUT("/results/:id/splits", s.SetResultSplits)
+			admin.POST("/meets/:id/results/import", s.ImportMeetResults)
+
+			admin.POST("/courses", s.CreateCourse)
+			admin.PUT("/courses/:id", s.UpdateCourse)
+			admin.DELETE("/courses/:id", s.DeleteCourse)
+
+			admin.POST("/records/historical", s.CreateHistoricalMark)
+			admin.PUT("/records/historical/:id", s.UpdateHistoricalMark)
+			admin.DELETE("/records/historical/:id", s.DeleteHistoricalMark)
 		}
 	}
 
-	log.Println("Server starting on port :8080")
-	r.Run(":8080")
+	return r
 }
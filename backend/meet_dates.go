@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // the container image has no zoneinfo
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+)
+
+// Meets are stored as a local calendar date ("2006-01-02") plus an optional
+// 24-hour start time ("15:04"), both in the team's time zone. Storing dates
+// in ISO form keeps ORDER BY date chronological.
+const (
+	meetDateLayout = "2006-01-02"
+	meetTimeLayout = "15:04"
+)
+
+var meetLocation = mustLoadLocation("America/New_York")
+
+// meetDateLayouts are the date formats accepted on write. Anything else is
+// rejected rather than stored as free text.
+var meetDateLayouts = []string{
+	"2006-01-02",
+	"2006-1-2",
+	"01/02/2006",
+	"1/2/2006",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"Jan 2 2006",
+	"January 2 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+}
+
+var meetTimeLayouts = []string{
+	"15:04",
+	"15:04:05",
+	"3:04 PM",
+	"3:04PM",
+	"3 PM",
+	"3PM",
+}
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Fatalf("Failed to load time zone %s: %v", name, err)
+	}
+	return loc
+}
+
+// normalizeDate parses any accepted date format and returns it as
+// YYYY-MM-DD. RFC 3339 timestamps are converted to the local date.
+func normalizeDate(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(meetLocation).Format(meetDateLayout), nil
+	}
+	for _, layout := range meetDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format(meetDateLayout), nil
+		}
+	}
+	return "", fmt.Errorf("invalid date %q; use YYYY-MM-DD", s)
+}
+
+// normalizeClockTime parses a 24-hour or AM/PM time and returns it as HH:MM.
+func normalizeClockTime(s string) (string, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	for _, layout := range meetTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format(meetTimeLayout), nil
+		}
+	}
+	return "", fmt.Errorf("invalid time %q; use HH:MM", s)
+}
+
+// normalize rewrites the date and times into their stored forms and
+// returns an error message, or "" when the input is valid. Blank values
+// clear the field.
+func (in *meetInput) normalize() string {
+	if in.Date != nil {
+		if strings.TrimSpace(*in.Date) == "" {
+			in.Date = nil
+		} else {
+			date, err := normalizeDate(*in.Date)
+			if err != nil {
+				return err.Error()
+			}
+			in.Date = &date
+		}
+	}
+	for _, field := range []struct {
+		name  string
+		value **string
+	}{{"startTime", &in.StartTime}, {"busDeparture", &in.BusDeparture}} {
+		if *field.value == nil {
+			continue
+		}
+		if strings.TrimSpace(**field.value) == "" {
+			*field.value = nil
+			continue
+		}
+		t, err := normalizeClockTime(**field.value)
+		if err != nil {
+			return field.name + ": " + err.Error()
+		}
+		*field.value = &t
+	}
+	return ""
+}
+
+// checkMeet validates a meet once the input has been applied to it.
+func checkMeet(m db.Meet) string {
+	if m.Name == "" {
+		return "name is required"
+	}
+	if m.StartTime.Valid && !m.Date.Valid {
+		return "startTime requires a date"
+	}
+	return ""
+}
+
+// meetStartsAt combines a meet's date and start time into an RFC 3339
+// timestamp with the America/New_York offset in effect on that day.
+func meetStartsAt(m db.Meet) *string {
+	if !m.Date.Valid || !m.StartTime.Valid {
+		return nil
+	}
+	t, err := time.ParseInLocation(meetDateLayout+" "+meetTimeLayout, m.Date.String+" "+m.StartTime.String, meetLocation)
+	if err != nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
+
+// meetToday is the current date in the team's time zone; meets on this
+// date count as upcoming.
+func meetToday() sql.NullString {
+	return sql.NullString{String: time.Now().In(meetLocation).Format(meetDateLayout), Valid: true}
+}
+
+// meetListFilter reads ?upcoming and ?past. At most one may be true.
+func meetListFilter(c *gin.Context) (upcoming, past bool, errMsg string) {
+	for _, f := range []struct {
+		name  string
+		value *bool
+	}{{"upcoming", &upcoming}, {"past", &past}} {
+		raw := c.Query(f.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return false, false, f.name + " must be true or false"
+		}
+		*f.value = v
+	}
+	if upcoming && past {
+		return false, false, "upcoming and past cannot both be true"
+	}
+	return upcoming, past, ""
+}
+
+// GetNextMeet returns the first meet on or after today that has not been
+// cancelled or finished.
+func (s *Server) GetNextMeet(c *gin.Context) {
+	ctx := c.Request.Context()
+	meet, err := s.store.GetNextMeet(ctx, meetToday())
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "no upcoming meets"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, meetResponse(meet))
+}
+
+// normalizeStoredMeetDates rewrites dates saved before validation existed
+// into ISO form. Dates that cannot be parsed are logged and left alone. It
+// runs once, as part of migration 016.
+func normalizeStoredMeetDates(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, "SELECT id, date FROM meets WHERE date IS NOT NULL")
+	if err != nil {
+		return err
+	}
+	type storedDate struct {
+		id   int64
+		date string
+	}
+	var dates []storedDate
+	for rows.Next() {
+		var d storedDate
+		if err := rows.Scan(&d.id, &d.date); err != nil {
+			rows.Close()
+			return err
+		}
+		dates = append(dates, d)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, d := range dates {
+		date, err := normalizeDate(d.date)
+		if err != nil {
+			log.Printf("Meet %d has an unrecognized date %q; leaving it unchanged", d.id, d.date)
+			continue
+		}
+		if date == d.date {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE meets SET date = ? WHERE id = ?", date, d.id); err != nil {
+			return err
+		}
+		log.Printf("Normalized meet %d date %q to %s", d.id, d.date, date)
+	}
+	return nil
+}
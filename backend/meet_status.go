@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+)
+
+const (
+	meetScheduled  = "scheduled"
+	meetPostponed  = "postponed"
+	meetCancelled  = "cancelled"
+	meetInProgress = "in-progress"
+	meetFinal      = "final"
+)
+
+// meetTransitions lists the statuses a meet may move to from each status.
+// Results of a final meet are locked until an admin reopens it by moving it
+// back to in-progress, e.g. to correct a time.
+var meetTransitions = map[string][]string{
+	meetScheduled:  {meetPostponed, meetCancelled, meetInProgress},
+	meetPostponed:  {meetScheduled, meetCancelled, meetInProgress},
+	meetCancelled:  {meetScheduled},
+	meetInProgress: {meetFinal, meetPostponed},
+	meetFinal:      {meetInProgress},
+}
+
+// UpdateMeetStatus moves a meet through its lifecycle. Transitions not in
+// meetTransitions are rejected with 409 and the allowed ones listed.
+func (s *Server) UpdateMeetStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var meetID int64
+	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid meet ID"})
+		return
+	}
+
+	var input struct {
+		Status string `json:"status"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if _, ok := meetTransitions[input.Status]; !ok {
+		c.JSON(400, gin.H{"error": "status must be one of scheduled, postponed, cancelled, in-progress, final"})
+		return
+	}
+
+	meet, err := s.store.GetMeetByID(ctx, meetID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "meet not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if !canTransition(meet.Status, input.Status) {
+		c.JSON(409, gin.H{
+			"error":   fmt.Sprintf("cannot change a %s meet to %s", meet.Status, input.Status),
+			"allowed": meetTransitions[meet.Status],
+		})
+		return
+	}
+
+	updated, err := s.store.UpdateMeetStatus(ctx, db.UpdateMeetStatusParams{
+		Status: input.Status,
+		ID:     meetID,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := meetResponse(updated)
+	s.emitEvent(EventMeetUpdated, MeetUpdatedEvent{Meet: response, PreviousDate: nullStringToPtr(meet.Date)})
+	c.JSON(200, response)
+}
+
+func canTransition(from, to string) bool {
+	for _, s := range meetTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// requireMeetInProgress writes an error response and returns false unless
+// the meet exists and is in progress. Results may only be entered, changed
+// or removed while a meet is being run.
+func (s *Server) requireMeetInProgress(c *gin.Context, meetID int64) bool {
+	ctx := c.Request.Context()
+	code, msg, err := s.checkMeetInProgress(ctx, meetID)
+	if err != nil {
+		serverError(c, err)
+		return false
+	}
+	if code != 0 {
+		c.JSON(code, gin.H{"error": msg})
+		return false
+	}
+	return true
+}
+
+// checkMeetInProgress returns the status code and message to reject a
+// result change with, or 0 when the meet is in progress. The error is only
+// set when the meet couldn't be loaded.
+func (s *Server) checkMeetInProgress(ctx context.Context, meetID int64) (int, string, error) {
+	meet, err := s.store.GetMeetByID(ctx, meetID)
+	if err == sql.ErrNoRows {
+		return 404, "meet not found", nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	if meet.Status == meetFinal {
+		return 409, "meet is final; reopen it (set it in-progress) to change its results", nil
+	}
+	if meet.Status != meetInProgress {
+		return 409, fmt.Sprintf("results can only be entered while the meet is in progress (it is %s)", meet.Status), nil
+	}
+	return 0, "", nil
+}
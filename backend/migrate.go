@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationSteps are data fixes that need Go rather than SQL. Each runs
+// after the SQL of the migration with the same version, in its transaction.
+// Steps query the tables directly: the sqlc queries follow the latest
+// schema, not the one the step runs against.
+var migrationSteps = map[int]func(ctx context.Context, tx *sql.Tx) error{
+	16: normalizeStoredMeetDates,
+}
+
+type migration struct {
+	Version int
+	Name    string
+}
+
+// loadMigrations returns the embedded migrations sorted by version. Files are
+// named NNN_description.sql and the numeric prefix is the schema version.
+func loadMigrations() ([]migration, error) {
+	names, err := fs.Glob(migrationFiles, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]migration, 0, len(names))
+	for _, name := range names {
+		base := strings.TrimPrefix(name, "migrations/")
+		prefix, _, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s: missing version prefix", base)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version prefix", base)
+		}
+		migrations = append(migrations, migration{Version: version, Name: name})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// runMigrations applies every migration newer than the database's
+// user_version, each in its own transaction.
+func runMigrations(database *sql.DB) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	current, err := schemaVersion(database)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		body, err := migrationFiles.ReadFile(m.Name)
+		if err != nil {
+			return err
+		}
+
+		tx, err := database.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(string(body)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s: %w", m.Name, err)
+		}
+		if step := migrationSteps[m.Version]; step != nil {
+			if err := step(context.Background(), tx); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migration %s: %w", m.Name, err)
+			}
+		}
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", m.Version)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s: %w", m.Name, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		log.Printf("Applied migration %s", m.Name)
+	}
+	return nil
+}
+
+// schemaVersion is the last migration applied to the database.
+func schemaVersion(conn *sql.DB) (int, error) {
+	var version int
+	err := conn.QueryRow("PRAGMA user_version").Scan(&version)
+	return version, err
+}
+
+// latestSchemaVersion is the version of the newest embedded migration.
+func latestSchemaVersion() (int, error) {
+	migrations, err := loadMigrations()
+	if err != nil || len(migrations) == 0 {
+		return 0, err
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
@@ -1,4 +1,4 @@
-CREATE TABLE athletes (
+CREATE TABLE IF NOT EXISTS athletes (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     name TEXT NOT NULL,
     grade INTEGER,
@@ -6,14 +6,14 @@ CREATE TABLE athletes (
     events TEXT
 );
 
-CREATE TABLE meets (
+CREATE TABLE IF NOT EXISTS meets (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     name TEXT NOT NULL,
     date TEXT,
     location TEXT
 );
 
-CREATE TABLE results (
+CREATE TABLE IF NOT EXISTS results (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     athlete_id INTEGER,
     meet_id INTEGER,
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS bibs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    meet_id INTEGER NOT NULL,
+    athlete_id INTEGER NOT NULL,
+    bib INTEGER NOT NULL,
+    FOREIGN KEY (meet_id) REFERENCES meets(id) ON DELETE CASCADE,
+    FOREIGN KEY (athlete_id) REFERENCES athletes(id) ON DELETE CASCADE,
+    UNIQUE (meet_id, bib),
+    UNIQUE (meet_id, athlete_id)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS splits (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    result_id INTEGER NOT NULL,
+    split_index INTEGER NOT NULL,
+    distance_meters REAL NOT NULL,
+    time TEXT NOT NULL,
+    FOREIGN KEY (result_id) REFERENCES results(id) ON DELETE CASCADE,
+    UNIQUE (result_id, split_index)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS courses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    venue TEXT,
+    distance_meters REAL NOT NULL,
+    surface TEXT,
+    elevation_notes TEXT
+);
+
+ALTER TABLE meets ADD COLUMN course_id INTEGER REFERENCES courses(id) ON DELETE SET NULL;
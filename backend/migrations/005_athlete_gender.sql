@@ -0,0 +1 @@
+ALTER TABLE athletes ADD COLUMN gender TEXT;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS historical_marks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    athlete_name TEXT NOT NULL,
+    gender TEXT,
+    grade INTEGER,
+    distance_meters REAL NOT NULL,
+    time TEXT NOT NULL,
+    date TEXT,
+    meet_name TEXT,
+    notes TEXT
+);
+
+CREATE TABLE IF NOT EXISTS record_breaks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    category TEXT NOT NULL,
+    distance_meters REAL NOT NULL,
+    gender TEXT,
+    grade INTEGER,
+    result_id INTEGER,
+    athlete_id INTEGER,
+    athlete_name TEXT NOT NULL,
+    time TEXT NOT NULL,
+    previous_holder TEXT,
+    previous_time TEXT,
+    broken_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (result_id) REFERENCES results(id) ON DELETE SET NULL,
+    FOREIGN KEY (athlete_id) REFERENCES athletes(id) ON DELETE SET NULL
+);
@@ -0,0 +1,38 @@
+CREATE TABLE events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE
+);
+
+CREATE TABLE athlete_events (
+    athlete_id INTEGER NOT NULL REFERENCES athletes(id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    PRIMARY KEY (athlete_id, event_id)
+);
+
+CREATE INDEX idx_athlete_events_event ON athlete_events(event_id);
+
+-- Split the old comma-separated athletes.events strings ("5K,3200m") into
+-- one row per athlete and event name.
+CREATE TEMP TABLE legacy_athlete_events AS
+WITH RECURSIVE split(athlete_id, name, rest) AS (
+    SELECT id, '', events || ',' FROM athletes WHERE events IS NOT NULL
+    UNION ALL
+    SELECT athlete_id,
+           TRIM(SUBSTR(rest, 1, INSTR(rest, ',') - 1)),
+           SUBSTR(rest, INSTR(rest, ',') + 1)
+    FROM split
+    WHERE rest != ''
+)
+SELECT athlete_id, name FROM split WHERE name != '';
+
+INSERT OR IGNORE INTO events (name)
+SELECT name FROM legacy_athlete_events ORDER BY athlete_id;
+
+INSERT OR IGNORE INTO athlete_events (athlete_id, event_id)
+SELECT l.athlete_id, e.id
+FROM legacy_athlete_events l
+JOIN events e ON e.name = l.name;
+
+DROP TABLE legacy_athlete_events;
+
+ALTER TABLE athletes DROP COLUMN events;
@@ -0,0 +1,23 @@
+ALTER TABLE athletes ADD COLUMN graduation_year INTEGER;
+ALTER TABLE athletes ADD COLUMN jersey_number INTEGER;
+
+-- Grade is derived from the graduation year from now on. The school year
+-- starts in August, so a senior during 2026-27 graduates in 2027.
+UPDATE athletes
+SET graduation_year = CAST(strftime('%Y', 'now') AS INTEGER)
+    + (CASE WHEN CAST(strftime('%m', 'now') AS INTEGER) >= 8 THEN 1 ELSE 0 END)
+    + 12 - grade
+WHERE grade IS NOT NULL;
+
+ALTER TABLE athletes DROP COLUMN grade;
+
+CREATE TABLE athlete_statuses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    athlete_id INTEGER NOT NULL REFERENCES athletes(id) ON DELETE CASCADE,
+    status TEXT NOT NULL CHECK (status IN ('active', 'injured', 'inactive', 'alumni')),
+    start_date TEXT NOT NULL,
+    end_date TEXT,
+    note TEXT
+);
+
+CREATE INDEX idx_athlete_statuses_athlete ON athlete_statuses(athlete_id);
@@ -0,0 +1,19 @@
+CREATE TABLE races (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    meet_id INTEGER NOT NULL REFERENCES meets(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    gender TEXT,
+    scorer_limit INTEGER NOT NULL DEFAULT 7,
+    alternate_limit INTEGER NOT NULL DEFAULT 2,
+    entry_deadline TEXT,
+    UNIQUE (meet_id, name)
+);
+
+CREATE TABLE entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    race_id INTEGER NOT NULL REFERENCES races(id) ON DELETE CASCADE,
+    athlete_id INTEGER NOT NULL REFERENCES athletes(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('scorer', 'alternate')),
+    position INTEGER NOT NULL,
+    UNIQUE (race_id, athlete_id)
+);
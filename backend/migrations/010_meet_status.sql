@@ -0,0 +1,9 @@
+ALTER TABLE meets ADD COLUMN status TEXT NOT NULL DEFAULT 'scheduled'
+    CHECK (status IN ('scheduled', 'postponed', 'cancelled', 'in-progress', 'final'));
+ALTER TABLE meets ADD COLUMN start_time TEXT;
+ALTER TABLE meets ADD COLUMN bus_departure TEXT;
+ALTER TABLE meets ADD COLUMN host_school TEXT;
+
+-- Meets that already have results have been run.
+UPDATE meets SET status = 'final'
+WHERE id IN (SELECT meet_id FROM results WHERE meet_id IS NOT NULL);
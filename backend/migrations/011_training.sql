@@ -0,0 +1,31 @@
+CREATE TABLE workout_plans (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    date TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('easy', 'long', 'tempo', 'interval', 'hill', 'recovery', 'race', 'cross-training', 'strength', 'other')),
+    title TEXT NOT NULL,
+    distance_miles REAL,
+    duration_seconds INTEGER,
+    description TEXT
+);
+
+CREATE TABLE workout_plan_athletes (
+    plan_id INTEGER NOT NULL REFERENCES workout_plans(id) ON DELETE CASCADE,
+    athlete_id INTEGER NOT NULL REFERENCES athletes(id) ON DELETE CASCADE,
+    PRIMARY KEY (plan_id, athlete_id)
+);
+
+CREATE TABLE workouts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    athlete_id INTEGER NOT NULL REFERENCES athletes(id) ON DELETE CASCADE,
+    plan_id INTEGER REFERENCES workout_plans(id) ON DELETE SET NULL,
+    date TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('easy', 'long', 'tempo', 'interval', 'hill', 'recovery', 'race', 'cross-training', 'strength', 'other')),
+    distance_miles REAL,
+    duration_seconds INTEGER,
+    rpe INTEGER CHECK (rpe BETWEEN 1 AND 10),
+    notes TEXT,
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_workouts_athlete_date ON workouts(athlete_id, date);
+CREATE INDEX idx_workout_plan_athletes_athlete ON workout_plan_athletes(athlete_id);
@@ -0,0 +1,19 @@
+CREATE TABLE practice_sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    date TEXT NOT NULL,
+    type TEXT NOT NULL DEFAULT 'practice' CHECK (type IN ('practice', 'meet')),
+    meet_id INTEGER REFERENCES meets(id) ON DELETE SET NULL,
+    notes TEXT
+);
+
+CREATE TABLE attendance (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id INTEGER NOT NULL REFERENCES practice_sessions(id) ON DELETE CASCADE,
+    athlete_id INTEGER NOT NULL REFERENCES athletes(id) ON DELETE CASCADE,
+    status TEXT NOT NULL CHECK (status IN ('present', 'absent', 'excused', 'injured')),
+    note TEXT,
+    UNIQUE (session_id, athlete_id)
+);
+
+CREATE INDEX idx_practice_sessions_date ON practice_sessions(date);
+CREATE INDEX idx_attendance_athlete ON attendance(athlete_id);
@@ -0,0 +1,25 @@
+CREATE TABLE email_subscriptions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    email TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    account_id INTEGER UNIQUE REFERENCES accounts(id) ON DELETE CASCADE,
+    meet_changes INTEGER NOT NULL DEFAULT 1,
+    results TEXT NOT NULL DEFAULT 'all' CHECK (results IN ('none', 'linked', 'all')),
+    unsubscribe_token TEXT NOT NULL UNIQUE,
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE email_outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    to_email TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    event TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TEXT NOT NULL,
+    last_error TEXT,
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TEXT
+);
+
+CREATE INDEX idx_email_outbox_due ON email_outbox(status, next_attempt_at);
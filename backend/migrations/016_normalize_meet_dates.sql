@@ -0,0 +1,3 @@
+-- Rewrites meet dates saved before validation existed into YYYY-MM-DD.
+-- The parsing is done in Go; see migrationSteps.
+SELECT 1;
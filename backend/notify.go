@@ -0,0 +1,803 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+)
+
+// Email notifications are queued in the email_outbox table when an event is
+// emitted and sent by a background worker, so a slow or unreachable mail
+// server never holds up a request. The worker only runs when SMTP_ADDR is
+// set; without it messages stay pending until it is.
+
+const (
+	resultsNone   = "none"
+	resultsLinked = "linked"
+	resultsAll    = "all"
+
+	emailPending = "pending"
+	emailSent    = "sent"
+	emailFailed  = "failed"
+
+	maxEmailAttempts  = 5
+	emailBatchSize    = 20
+	defaultOutboxSize = 50
+	maxOutboxSize     = 500
+)
+
+var validResultsScopes = []string{resultsNone, resultsLinked, resultsAll}
+
+type SubscriptionResponse struct {
+	ID          int64  `json:"id"`
+	Email       string `json:"email"`
+	AccountID   *int64 `json:"accountId"`
+	MeetChanges bool   `json:"meetChanges"`
+	Results     string `json:"results"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+type OutboxResponse struct {
+	ID            int64   `json:"id"`
+	To            string  `json:"to"`
+	Subject       string  `json:"subject"`
+	Body          string  `json:"body"`
+	Event         string  `json:"event"`
+	Status        string  `json:"status"`
+	Attempts      int64   `json:"attempts"`
+	NextAttemptAt string  `json:"nextAttemptAt"`
+	LastError     *string `json:"lastError"`
+	CreatedAt     string  `json:"createdAt"`
+	SentAt        *string `json:"sentAt"`
+}
+
+// subscriptionInput is the body for creating or updating a subscription.
+type subscriptionInput struct {
+	Email       string `json:"email"`
+	MeetChanges *bool  `json:"meetChanges"`
+	Results     string `json:"results"`
+}
+
+// validate normalizes the input and returns an error message, or "" when
+// it is valid. linked scopes need an account to know which athletes to
+// follow.
+func (in *subscriptionInput) validate(hasAccount bool) string {
+	addr, err := mail.ParseAddress(strings.TrimSpace(in.Email))
+	if err != nil {
+		return "a valid email is required"
+	}
+	in.Email = addr.Address
+	if in.MeetChanges == nil {
+		on := true
+		in.MeetChanges = &on
+	}
+	if in.Results == "" {
+		in.Results = resultsAll
+		if hasAccount {
+			in.Results = resultsLinked
+		}
+	}
+	if !slices.Contains(validResultsScopes, in.Results) {
+		return "results must be one of " + strings.Join(validResultsScopes, ", ")
+	}
+	if in.Results == resultsLinked && !hasAccount {
+		return "results \"linked\" is only available to athlete and parent accounts"
+	}
+	return ""
+}
+
+// --- Mail configuration ---
+
+// mailConfig is read from the environment at startup and kept on Server.
+type mailConfig struct {
+	Addr      string // SMTP_ADDR, host:port; empty disables sending
+	From      string // SMTP_FROM
+	Username  string // SMTP_USERNAME, optional
+	Password  string // SMTP_PASSWORD, optional
+	PublicURL string // PUBLIC_URL, used for unsubscribe links
+	Interval  time.Duration
+}
+
+// outboxWake nudges the email worker when a message is queued.
+var outboxWake = make(chan struct{}, 1)
+
+// defaultMailConfig has sending disabled. NewServer starts from it so
+// rendered emails have a usable unsubscribe link without any environment.
+func defaultMailConfig() mailConfig {
+	return mailConfig{
+		From:      "Jones County XC <noreply@localhost>",
+		PublicURL: "http://localhost:8080",
+		Interval:  30 * time.Second,
+	}
+}
+
+func loadMailConfig() (mailConfig, error) {
+	cfg := defaultMailConfig()
+	cfg.Addr = os.Getenv("SMTP_ADDR")
+	cfg.Username = os.Getenv("SMTP_USERNAME")
+	cfg.Password = os.Getenv("SMTP_PASSWORD")
+	if v := os.Getenv("SMTP_FROM"); v != "" {
+		cfg.From = v
+	}
+	if v := strings.TrimRight(os.Getenv("PUBLIC_URL"), "/"); v != "" {
+		cfg.PublicURL = v
+	}
+	if v := os.Getenv("SMTP_POLL_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return cfg, fmt.Errorf("invalid SMTP_POLL_INTERVAL %q", v)
+		}
+		cfg.Interval = d
+	}
+	return cfg, nil
+}
+
+// unsubscribeURL is the link at the foot of every email.
+func (cfg mailConfig) unsubscribeURL(token string) string {
+	return cfg.PublicURL + "/api/unsubscribe?token=" + token
+}
+
+// --- Templates ---
+
+var emailTemplates = template.Must(template.New("email").Parse(`
+{{define "meet.updated.subject"}}Schedule change: {{.Meet}}{{end}}
+{{define "meet.updated.body"}}The date for {{.Meet}} has changed.
+
+  Was: {{or .PreviousDate "not set"}}
+  Now: {{or .Date "to be announced"}}
+{{- if .StartTime}}
+  Start time: {{.StartTime}}{{end}}
+{{- if .BusDeparture}}
+  Bus departs: {{.BusDeparture}}{{end}}
+{{- if .Location}}
+  Location: {{.Location}}{{end}}
+{{end}}
+{{define "result.created.subject"}}New result: {{.Athlete}} at {{.Meet}}{{end}}
+{{define "result.created.body"}}{{.Athlete}} ran {{.Time}} at {{.Meet}}{{if .Place}}, finishing {{.Place}}{{end}}.
+{{- if .Pace}}
+  Pace: {{.Pace}} per mile{{end}}
+{{- if .Records}}
+
+New school records:{{range .Records}}
+  - {{.}}{{end}}{{end}}
+{{end}}
+{{define "footer"}}
+--
+Jones County Cross Country
+To stop these emails, visit {{.}}
+{{end}}`))
+
+// renderEmail executes the subject and body templates for an event and
+// appends the unsubscribe footer.
+func renderEmail(event string, data any, unsubscribeURL string) (subject, body string, err error) {
+	var buf bytes.Buffer
+	if err := emailTemplates.ExecuteTemplate(&buf, event+".subject", data); err != nil {
+		return "", "", err
+	}
+	subject = buf.String()
+	buf.Reset()
+	if err := emailTemplates.ExecuteTemplate(&buf, event+".body", data); err != nil {
+		return "", "", err
+	}
+	if err := emailTemplates.ExecuteTemplate(&buf, "footer", unsubscribeURL); err != nil {
+		return "", "", err
+	}
+	return subject, buf.String(), nil
+}
+
+type meetChangeEmail struct {
+	Meet, PreviousDate, Date, StartTime, BusDeparture, Location string
+}
+
+type resultEmail struct {
+	Athlete, Meet, Time, Place, Pace string
+	Records                          []string
+}
+
+// --- Event listener ---
+
+// queueEmails is registered by NewServer. It renders one message per
+// interested subscriber and adds them to the outbox.
+func (s *Server) queueEmails(name string, payload any) {
+	switch name {
+	case EventMeetUpdated:
+		e := payload.(MeetUpdatedEvent)
+		if stringOrEmpty(e.PreviousDate) == stringOrEmpty(e.Meet.Date) {
+			return
+		}
+		data := meetChangeEmail{
+			Meet:         e.Meet.Name,
+			PreviousDate: stringOrEmpty(e.PreviousDate),
+			Date:         stringOrEmpty(e.Meet.Date),
+			StartTime:    stringOrEmpty(e.Meet.StartTime),
+			BusDeparture: stringOrEmpty(e.Meet.BusDeparture),
+			Location:     stringOrEmpty(e.Meet.Location),
+		}
+		s.enqueueForSubscribers(name, data, func(sub db.EmailSubscription) bool {
+			return sub.MeetChanges != 0
+		})
+	case EventResultCreated:
+		r := payload.(CreatedResultResponse)
+		if r.AthleteID == nil || r.MeetID == nil {
+			return
+		}
+		data, err := s.resultEmailData(r)
+		if err != nil {
+			log.Printf("Building result email for result %d failed: %v", r.ID, err)
+			return
+		}
+		s.enqueueForSubscribers(name, data, func(sub db.EmailSubscription) bool {
+			if sub.Results == resultsAll {
+				return true
+			}
+			if sub.Results != resultsLinked || !sub.AccountID.Valid {
+				return false
+			}
+			ids, err := s.store.GetAccountAthleteIDs(context.Background(), sub.AccountID.Int64)
+			return err == nil && containsID(ids, *r.AthleteID)
+		})
+	}
+}
+
+func (s *Server) resultEmailData(r CreatedResultResponse) (resultEmail, error) {
+	athlete, err := s.store.GetAthleteByID(context.Background(), *r.AthleteID)
+	if err != nil {
+		return resultEmail{}, err
+	}
+	meet, err := s.store.GetMeetByID(context.Background(), *r.MeetID)
+	if err != nil {
+		return resultEmail{}, err
+	}
+	data := resultEmail{
+		Athlete: athlete.Name,
+		Meet:    meet.Name,
+		Time:    stringOrEmpty(r.Time),
+		Pace:    stringOrEmpty(r.PacePerMile),
+	}
+	if r.Place != nil && *r.Place > 0 {
+		data.Place = ordinal(*r.Place)
+	}
+	for _, rec := range r.NewRecords {
+		data.Records = append(data.Records, recordLabel(rec))
+	}
+	return data, nil
+}
+
+func (s *Server) enqueueForSubscribers(event string, data any, wants func(db.EmailSubscription) bool) {
+	subs, err := s.store.GetAllSubscriptions(context.Background())
+	if err != nil {
+		log.Printf("Loading email subscriptions failed: %v", err)
+		return
+	}
+	queued := 0
+	for _, sub := range subs {
+		if !wants(sub) {
+			continue
+		}
+		subject, body, err := renderEmail(event, data, s.mail.unsubscribeURL(sub.UnsubscribeToken))
+		if err != nil {
+			log.Printf("Rendering %s email failed: %v", event, err)
+			return
+		}
+		if _, err := s.store.EnqueueEmail(context.Background(), db.EnqueueEmailParams{
+			ToEmail:       sub.Email,
+			Subject:       subject,
+			Body:          body,
+			Event:         event,
+			NextAttemptAt: outboxTime(time.Now()),
+		}); err != nil {
+			log.Printf("Queueing %s email to %s failed: %v", event, sub.Email, err)
+			continue
+		}
+		queued++
+	}
+	if queued > 0 {
+		log.Printf("Queued %d %s email(s)", queued, event)
+		wake(outboxWake)
+	}
+}
+
+// --- Outbox worker ---
+
+// startEmailWorker drains the outbox in the background. It does nothing
+// when SMTP_ADDR is not set.
+func (s *Server) startEmailWorker() {
+	if s.mail.Addr == "" {
+		log.Println("SMTP_ADDR not set; email notifications will stay queued")
+		return
+	}
+	log.Printf("Email worker sending via %s every %s", s.mail.Addr, s.mail.Interval)
+	go runWorker(s.mail.Interval, outboxWake, s.drainOutbox)
+}
+
+// drainOutbox sends every due message once. Failures are retried with
+// exponential backoff until maxEmailAttempts, then marked failed.
+func (s *Server) drainOutbox() {
+	for {
+		due, err := s.store.GetDueEmails(context.Background(), db.GetDueEmailsParams{
+			NextAttemptAt: outboxTime(time.Now()),
+			Limit:         emailBatchSize,
+		})
+		if err != nil {
+			log.Printf("Loading outbox failed: %v", err)
+			return
+		}
+		for _, m := range due {
+			s.deliverEmail(m)
+		}
+		if len(due) < emailBatchSize {
+			return
+		}
+	}
+}
+
+func (s *Server) deliverEmail(m db.EmailOutbox) {
+	err := s.mail.send(m)
+	if err == nil {
+		if err := s.store.MarkEmailSent(context.Background(), db.MarkEmailSentParams{
+			SentAt: sql.NullString{String: outboxTime(time.Now()), Valid: true},
+			ID:     m.ID,
+		}); err != nil {
+			log.Printf("Marking email %d sent failed: %v", m.ID, err)
+		}
+		return
+	}
+
+	attempts := m.Attempts + 1
+	status := emailPending
+	if attempts >= maxEmailAttempts {
+		status = emailFailed
+	}
+	log.Printf("Sending email %d to %s failed (attempt %d): %v", m.ID, m.ToEmail, attempts, err)
+	if err := s.store.MarkEmailAttemptFailed(context.Background(), db.MarkEmailAttemptFailedParams{
+		Status:        status,
+		NextAttemptAt: outboxTime(time.Now().Add(retryBackoff(attempts))),
+		LastError:     sql.NullString{String: err.Error(), Valid: true},
+		ID:            m.ID,
+	}); err != nil {
+		log.Printf("Recording email %d failure failed: %v", m.ID, err)
+	}
+}
+
+// send delivers one message over SMTP.
+func (cfg mailConfig) send(m db.EmailOutbox) error {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP_FROM: %v", err)
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", m.ToEmail)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mimeHeader(m.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		host := cfg.Addr
+		if i := strings.LastIndex(host, ":"); i >= 0 {
+			host = host[:i]
+		}
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+	}
+	return smtp.SendMail(cfg.Addr, auth, from.Address, []string{m.ToEmail}, msg.Bytes())
+}
+
+// mimeHeader encodes a header value when it contains non-ASCII text.
+func mimeHeader(s string) string {
+	for _, r := range s {
+		if r > 127 {
+			return mime.QEncoding.Encode("UTF-8", s)
+		}
+	}
+	return s
+}
+
+// --- Self-service handlers ---
+
+// GetMyNotifications returns the signed-in account's email settings.
+func (s *Server) GetMyNotifications(c *gin.Context) {
+	ctx := c.Request.Context()
+	account := currentAccount(c)
+	if account == nil {
+		c.JSON(400, gin.H{"error": "the admin manages subscriptions under /subscriptions"})
+		return
+	}
+	sub, err := s.store.GetSubscriptionByAccount(ctx, sql.NullInt64{Int64: account.ID, Valid: true})
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "no notification settings"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, subscriptionResponse(sub))
+}
+
+// UpdateMyNotifications creates or replaces the signed-in account's email
+// settings.
+func (s *Server) UpdateMyNotifications(c *gin.Context) {
+	ctx := c.Request.Context()
+	account := currentAccount(c)
+	if account == nil {
+		c.JSON(400, gin.H{"error": "the admin manages subscriptions under /subscriptions"})
+		return
+	}
+	var input subscriptionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := input.validate(true); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	accountID := sql.NullInt64{Int64: account.ID, Valid: true}
+	existing, err := s.store.GetSubscriptionByAccount(ctx, accountID)
+	if err != nil && err != sql.ErrNoRows {
+		serverError(c, err)
+		return
+	}
+	var sub db.EmailSubscription
+	if err == sql.ErrNoRows {
+		sub, err = s.createSubscription(ctx, input, accountID)
+	} else {
+		sub, err = s.store.UpdateSubscription(ctx, db.UpdateSubscriptionParams{
+			Email:       input.Email,
+			MeetChanges: boolToInt64(*input.MeetChanges),
+			Results:     input.Results,
+			ID:          existing.ID,
+		})
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			c.JSON(409, gin.H{"error": "that email is already subscribed"})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, subscriptionResponse(sub))
+}
+
+// DeleteMyNotifications turns off all email for the signed-in account.
+func (s *Server) DeleteMyNotifications(c *gin.Context) {
+	ctx := c.Request.Context()
+	account := currentAccount(c)
+	if account == nil {
+		c.JSON(400, gin.H{"error": "the admin manages subscriptions under /subscriptions"})
+		return
+	}
+	sub, err := s.store.GetSubscriptionByAccount(ctx, sql.NullInt64{Int64: account.ID, Valid: true})
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "no notification settings"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if _, err := s.store.DeleteSubscription(ctx, sub.ID); err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{"message": "notifications turned off"})
+}
+
+// unsubscribePage is shown to someone following an email's unsubscribe
+// link. With a Token it asks them to confirm; otherwise it shows Message.
+var unsubscribePage = htmltemplate.Must(htmltemplate.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><title>Unsubscribe - Jones County XC</title></head>
+<body style="font-family: sans-serif; max-width: 32rem; margin: 4rem auto; padding: 0 1rem">
+<h1>Jones County Cross Country</h1>
+{{if .Token}}<p>Stop sending team emails to {{.Email}}?</p>
+<form method="post" action="/api/unsubscribe?token={{.Token}}"><button type="submit">Unsubscribe</button></form>
+{{else}}<p>{{.Message}}</p>{{end}}
+</body>
+</html>
+`))
+
+type unsubscribeView struct {
+	Email, Token, Message string
+}
+
+// ConfirmUnsubscribe is the page an email's unsubscribe link opens. It only
+// asks for confirmation: mail scanners follow links, so a GET must not
+// delete anything.
+func (s *Server) ConfirmUnsubscribe(c *gin.Context) {
+	sub, ok := s.subscriptionForToken(c)
+	if !ok {
+		return
+	}
+	renderUnsubscribe(c, 200, unsubscribeView{Email: sub.Email, Token: sub.UnsubscribeToken})
+}
+
+// Unsubscribe removes the subscription named by the token. It needs no
+// sign-in, and also serves RFC 8058 one-click unsubscribe requests.
+func (s *Server) Unsubscribe(c *gin.Context) {
+	sub, ok := s.subscriptionForToken(c)
+	if !ok {
+		return
+	}
+	if _, err := s.store.DeleteSubscription(c.Request.Context(), sub.ID); err != nil {
+		serverError(c, err)
+		return
+	}
+	renderUnsubscribe(c, 200, unsubscribeView{Message: sub.Email + " has been unsubscribed."})
+}
+
+// subscriptionForToken looks up ?token. When there is no such
+// subscription it responds itself and returns false.
+func (s *Server) subscriptionForToken(c *gin.Context) (db.EmailSubscription, bool) {
+	token := c.Query("token")
+	if token == "" {
+		renderUnsubscribe(c, 400, unsubscribeView{Message: "This unsubscribe link is missing its token."})
+		return db.EmailSubscription{}, false
+	}
+	sub, err := s.store.GetSubscriptionByToken(c.Request.Context(), token)
+	if err == sql.ErrNoRows {
+		renderUnsubscribe(c, 404, unsubscribeView{Message: "This address is not subscribed. It may already have been removed."})
+		return db.EmailSubscription{}, false
+	}
+	if err != nil {
+		serverError(c, err)
+		return db.EmailSubscription{}, false
+	}
+	return sub, true
+}
+
+func renderUnsubscribe(c *gin.Context, status int, view unsubscribeView) {
+	var buf bytes.Buffer
+	if err := unsubscribePage.Execute(&buf, view); err != nil {
+		serverError(c, err)
+		return
+	}
+	c.Data(status, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// --- Admin handlers ---
+
+func (s *Server) GetSubscriptions(c *gin.Context) {
+	ctx := c.Request.Context()
+	subs, err := s.store.GetAllSubscriptions(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]SubscriptionResponse, len(subs))
+	for i, sub := range subs {
+		response[i] = subscriptionResponse(sub)
+	}
+	c.JSON(200, response)
+}
+
+// CreateSubscription adds an address that is not tied to an account, such
+// as a team mailing list.
+func (s *Server) CreateSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input subscriptionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := input.validate(false); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	sub, err := s.createSubscription(ctx, input, sql.NullInt64{})
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			c.JSON(409, gin.H{"error": "that email is already subscribed"})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+	c.JSON(201, subscriptionResponse(sub))
+}
+
+func (s *Server) DeleteSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var subID int64
+	if _, err := fmt.Sscanf(id, "%d", &subID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid subscription ID"})
+		return
+	}
+
+	n, err := s.store.DeleteSubscription(ctx, subID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "subscription not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "subscription deleted"})
+}
+
+// GetOutbox lists the most recent queued, sent and failed emails.
+func (s *Server) GetOutbox(c *gin.Context) {
+	ctx := c.Request.Context()
+	limit := int64(defaultOutboxSize)
+	if raw := c.Query("limit"); raw != "" {
+		if _, err := fmt.Sscanf(raw, "%d", &limit); err != nil || limit < 1 || limit > maxOutboxSize {
+			c.JSON(400, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", maxOutboxSize)})
+			return
+		}
+	}
+	status := c.Query("status")
+	if status != "" && !slices.Contains([]string{emailPending, emailSent, emailFailed}, status) {
+		c.JSON(400, gin.H{"error": "status must be pending, sent or failed"})
+		return
+	}
+
+	var emails []db.EmailOutbox
+	var err error
+	if status != "" {
+		emails, err = s.store.GetOutboxByStatus(ctx, db.GetOutboxByStatusParams{Status: status, Limit: limit})
+	} else {
+		emails, err = s.store.GetOutbox(ctx, limit)
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]OutboxResponse, len(emails))
+	for i, m := range emails {
+		response[i] = outboxResponse(m)
+	}
+	c.JSON(200, response)
+}
+
+// RetryEmail puts a failed email back in the queue with its attempt count
+// reset.
+func (s *Server) RetryEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var emailID int64
+	if _, err := fmt.Sscanf(id, "%d", &emailID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid email ID"})
+		return
+	}
+
+	n, err := s.store.RetryEmail(ctx, db.RetryEmailParams{
+		NextAttemptAt: outboxTime(time.Now()),
+		ID:            emailID,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "failed email not found"})
+		return
+	}
+	wake(outboxWake)
+	c.JSON(200, gin.H{"message": "email queued for retry"})
+}
+
+// --- Helpers ---
+
+func (s *Server) createSubscription(ctx context.Context, input subscriptionInput, accountID sql.NullInt64) (db.EmailSubscription, error) {
+	token, err := newUnsubscribeToken()
+	if err != nil {
+		return db.EmailSubscription{}, err
+	}
+	return s.store.CreateSubscription(ctx, db.CreateSubscriptionParams{
+		Email:            input.Email,
+		AccountID:        accountID,
+		MeetChanges:      boolToInt64(*input.MeetChanges),
+		Results:          input.Results,
+		UnsubscribeToken: token,
+	})
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// ordinal formats a place as "1st", "2nd", "11th" and so on.
+func ordinal(n int64) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.FormatInt(n, 10) + suffix
+}
+
+// recordLabel describes a record break for an email, e.g. "all-time 5000m
+// girls record (was 19:02.4 by Jane Doe)".
+func recordLabel(r RecordBreakResponse) string {
+	label := r.Category
+	if r.Grade != nil {
+		label = "grade " + strconv.FormatInt(*r.Grade, 10)
+	}
+	label += fmt.Sprintf(" %.0fm", r.DistanceMeters)
+	if r.Gender != nil {
+		label += " " + *r.Gender
+	}
+	label += " record"
+	if r.PreviousTime != nil && r.PreviousHolder != nil {
+		label += fmt.Sprintf(" (was %s by %s)", *r.PreviousTime, *r.PreviousHolder)
+	}
+	return label
+}
+
+func newUnsubscribeToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func subscriptionResponse(s db.EmailSubscription) SubscriptionResponse {
+	return SubscriptionResponse{
+		ID:          s.ID,
+		Email:       s.Email,
+		AccountID:   nullInt64ToPtr(s.AccountID),
+		MeetChanges: s.MeetChanges != 0,
+		Results:     s.Results,
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
+func outboxResponse(m db.EmailOutbox) OutboxResponse {
+	return OutboxResponse{
+		ID:            m.ID,
+		To:            m.ToEmail,
+		Subject:       m.Subject,
+		Body:          m.Body,
+		Event:         m.Event,
+		Status:        m.Status,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     nullStringToPtr(m.LastError),
+		CreatedAt:     m.CreatedAt,
+		SentAt:        nullStringToPtr(m.SentAt),
+	}
+}
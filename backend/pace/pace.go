@@ -0,0 +1,128 @@
+// Package pace parses and formats race times and converts them between
+// distances, so results from courses of different lengths can be compared.
+package pace
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	MetersPerMile      = 1609.344
+	MetersPerKilometer = 1000.0
+	Meters5K           = 5000.0
+
+	// RiegelExponent is the fatigue factor in Riegel's endurance formula.
+	RiegelExponent = 1.06
+
+	// MaxSeconds bounds a race time; anything a day or longer is a typo.
+	MaxSeconds = 24 * 60 * 60
+)
+
+// Parse converts "m:ss", "m:ss.f" or "h:mm:ss" into seconds. Every field is
+// plain digits, and only the last may have a decimal point.
+func Parse(s string) (float64, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid race time %q", s)
+	}
+
+	var total float64
+	for i, p := range parts {
+		// Checked by hand because ParseFloat also takes signs, exponents,
+		// underscores, "NaN" and "Inf".
+		if !digitsOnly(p, i == len(parts)-1) {
+			return 0, fmt.Errorf("invalid race time %q", s)
+		}
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil || (i > 0 && v >= 60) {
+			return 0, fmt.Errorf("invalid race time %q", s)
+		}
+		total = total*60 + v
+	}
+	if total >= MaxSeconds {
+		return 0, fmt.Errorf("invalid race time %q: must be under 24 hours", s)
+	}
+	return total, nil
+}
+
+// digitsOnly reports whether p is one or more digits, optionally followed
+// by a decimal point and more digits when fraction is set.
+func digitsOnly(p string, fraction bool) bool {
+	whole, frac, hasPoint := strings.Cut(p, ".")
+	if hasPoint && (!fraction || frac == "") {
+		return false
+	}
+	for _, part := range []string{whole, frac} {
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+	}
+	return whole != ""
+}
+
+// Format renders seconds as "m:ss" (or "h:mm:ss"), keeping tenths only when
+// they are non-zero.
+func Format(seconds float64) string {
+	tenths := int64(math.Round(seconds * 10))
+	whole, frac := tenths/10, tenths%10
+
+	var s string
+	if whole >= 3600 {
+		s = fmt.Sprintf("%d:%02d:%02d", whole/3600, whole%3600/60, whole%60)
+	} else {
+		s = fmt.Sprintf("%d:%02d", whole/60, whole%60)
+	}
+	if frac != 0 {
+		s += fmt.Sprintf(".%d", frac)
+	}
+	return s
+}
+
+// PerMile returns the average seconds per mile over the distance.
+func PerMile(seconds, meters float64) float64 {
+	return seconds / meters * MetersPerMile
+}
+
+// PerKilometer returns the average seconds per kilometer over the distance.
+func PerKilometer(seconds, meters float64) float64 {
+	return seconds / meters * MetersPerKilometer
+}
+
+// Riegel predicts the time for toMeters from a time run over fromMeters,
+// using T2 = T1 * (D2 / D1) ^ 1.06.
+func Riegel(seconds, fromMeters, toMeters float64) float64 {
+	return seconds * math.Pow(toMeters/fromMeters, RiegelExponent)
+}
+
+// Conversion is a race time expressed every way the API reports it.
+type Conversion struct {
+	Seconds      float64
+	Meters       float64
+	PerMile      float64
+	PerKilometer float64
+	Equivalent5K float64
+}
+
+// Convert parses a race time run over meters and derives its paces and
+// Riegel-equivalent 5K time.
+func Convert(time string, meters float64) (Conversion, error) {
+	if meters <= 0 {
+		return Conversion{}, fmt.Errorf("distance must be positive")
+	}
+	seconds, err := Parse(time)
+	if err != nil {
+		return Conversion{}, err
+	}
+	return Conversion{
+		Seconds:      seconds,
+		Meters:       meters,
+		PerMile:      PerMile(seconds, meters),
+		PerKilometer: PerKilometer(seconds, meters),
+		Equivalent5K: Riegel(seconds, meters, Meters5K),
+	}, nil
+}
@@ -0,0 +1,101 @@
+package pace
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"16:31", 991, false},
+		{"16:31.4", 991.4, false},
+		{"1:02:03", 3723, false},
+		{" 9:59 ", 599, false},
+		{"16", 0, true},
+		{"16:75", 0, true},
+		{"abc", 0, true},
+		{"-1:00", 0, true},
+		{"NaN:00", 0, true},
+		{"16:NaN", 0, true},
+		{"Inf:00", 0, true},
+		{"1e308:00", 0, true},
+		{"1e300:00", 0, true},
+		{"5:1e1", 0, true},
+		{"1_0:00", 0, true},
+		{"+5:00", 0, true},
+		{"5:+1", 0, true},
+		{"5.5:00", 0, true},
+		{"5:00.", 0, true},
+		{"5:.5", 0, true},
+		{"5: 00", 0, true},
+		{"23:59:59.9", 86399.9, false},
+		{"24:00:00", 0, true},
+		{"99999999:00", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Parse(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{991, "16:31"},
+		{991.44, "16:31.4"},
+		{59.96, "1:00"},
+		{3723, "1:02:03"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.in); got != tt.want {
+			t.Errorf("Format(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRiegel(t *testing.T) {
+	if got := Riegel(1000, Meters5K, Meters5K); got != 1000 {
+		t.Errorf("same distance = %v, want 1000", got)
+	}
+
+	// A 12:00 two-mile is about a 19:08 5K.
+	got := Riegel(720, 2*MetersPerMile, Meters5K)
+	if math.Abs(got-1148.4) > 0.1 {
+		t.Errorf("two-mile to 5K = %v, want ~1148.4", got)
+	}
+
+	// Converting there and back returns the original time.
+	back := Riegel(got, Meters5K, 2*MetersPerMile)
+	if math.Abs(back-720) > 1e-9 {
+		t.Errorf("round trip = %v, want 720", back)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	conv, err := Convert("16:00", Meters5K)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conv.PerKilometer != 192 {
+		t.Errorf("PerKilometer = %v, want 192", conv.PerKilometer)
+	}
+	if math.Abs(conv.PerMile-308.99) > 0.01 {
+		t.Errorf("PerMile = %v, want ~308.99", conv.PerMile)
+	}
+
+	if _, err := Convert("16:00", 0); err == nil {
+		t.Error("expected error for zero distance")
+	}
+}
@@ -5,17 +5,17 @@ SELECT * FROM athletes ORDER BY name;
 SELECT * FROM athletes WHERE id = ? LIMIT 1;
 
 -- name: CreateAthlete :one
-INSERT INTO athletes (name, grade, personal_record, events)
-VALUES (?, ?, ?, ?)
+INSERT INTO athletes (name, personal_record, gender, graduation_year, jersey_number)
+VALUES (?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: UpdateAthlete :one
 UPDATE athletes
-SET name = ?, grade = ?, personal_record = ?, events = ?
+SET name = ?, personal_record = ?, gender = ?, graduation_year = ?, jersey_number = ?
 WHERE id = ?
 RETURNING *;
 
--- name: DeleteAthlete :exec
+-- name: DeleteAthlete :execrows
 DELETE FROM athletes WHERE id = ?;
 
 -- name: GetAllMeets :many
@@ -25,36 +25,47 @@ SELECT * FROM meets ORDER BY date;
 SELECT * FROM meets WHERE id = ? LIMIT 1;
 
 -- name: CreateMeet :one
-INSERT INTO meets (name, date, location)
-VALUES (?, ?, ?)
+INSERT INTO meets (name, date, location, course_id, start_time, bus_departure, host_school)
+VALUES (?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: UpdateMeet :one
 UPDATE meets
-SET name = ?, date = ?, location = ?
+SET name = ?, date = ?, location = ?, course_id = ?, start_time = ?, bus_departure = ?, host_school = ?
 WHERE id = ?
 RETURNING *;
 
--- name: DeleteMeet :exec
+-- name: UpdateMeetStatus :one
+UPDATE meets SET status = ? WHERE id = ?
+RETURNING *;
+
+-- name: DeleteMeet :execrows
 DELETE FROM meets WHERE id = ?;
 
 -- name: GetAllResults :many
-SELECT * FROM results ORDER BY id;
+SELECT r.*, c.distance_meters
+FROM results r
+LEFT JOIN meets m ON r.meet_id = m.id
+LEFT JOIN courses c ON m.course_id = c.id
+ORDER BY r.id;
 
 -- name: GetResultByID :one
 SELECT * FROM results WHERE id = ? LIMIT 1;
 
 -- name: GetResultsByAthlete :many
-SELECT r.*, m.name as meet_name, m.date as meet_date
+SELECT r.*, m.name as meet_name, m.date as meet_date, c.distance_meters
 FROM results r
 JOIN meets m ON r.meet_id = m.id
+LEFT JOIN courses c ON m.course_id = c.id
 WHERE r.athlete_id = ?
 ORDER BY m.date;
 
 -- name: GetResultsByMeet :many
-SELECT r.*, a.name as athlete_name
+SELECT r.*, a.name as athlete_name, c.distance_meters
 FROM results r
 JOIN athletes a ON r.athlete_id = a.id
+LEFT JOIN meets m ON r.meet_id = m.id
+LEFT JOIN courses c ON m.course_id = c.id
 WHERE r.meet_id = ?
 ORDER BY r.place;
 
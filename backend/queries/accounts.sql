@@ -0,0 +1,55 @@
+-- name: GetAllAccounts :many
+SELECT * FROM accounts ORDER BY username;
+
+-- name: GetAccountByID :one
+SELECT * FROM accounts WHERE id = ? LIMIT 1;
+
+-- name: GetAccountByUsername :one
+SELECT * FROM accounts WHERE username = ? LIMIT 1;
+
+-- name: CreateAccount :one
+INSERT INTO accounts (username, password_hash, role)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: UpdateAccountPassword :exec
+UPDATE accounts SET password_hash = ? WHERE id = ?;
+
+-- name: DeleteAccount :execrows
+DELETE FROM accounts WHERE id = ?;
+
+-- name: GetAccountAthleteIDs :many
+SELECT athlete_id FROM account_athletes WHERE account_id = ? ORDER BY athlete_id;
+
+-- name: AddAccountAthlete :exec
+INSERT OR IGNORE INTO account_athletes (account_id, athlete_id) VALUES (?, ?);
+
+-- name: GetAllInvites :many
+SELECT * FROM invites ORDER BY created_at DESC, id DESC;
+
+-- name: GetInviteByCode :one
+SELECT * FROM invites WHERE code = ? LIMIT 1;
+
+-- name: CreateInvite :one
+INSERT INTO invites (code, athlete_id, role, expires_at)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: UseInvite :execrows
+UPDATE invites SET used_at = CURRENT_TIMESTAMP, account_id = ?
+WHERE id = ? AND used_at IS NULL;
+
+-- name: DeleteInvite :execrows
+DELETE FROM invites WHERE id = ? AND used_at IS NULL;
+
+-- name: GetAthleteProfile :one
+SELECT * FROM athlete_profiles WHERE athlete_id = ? LIMIT 1;
+
+-- name: UpsertAthleteProfile :one
+INSERT INTO athlete_profiles (athlete_id, bio, emergency_contact, emergency_phone)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (athlete_id) DO UPDATE SET
+    bio = excluded.bio,
+    emergency_contact = excluded.emergency_contact,
+    emergency_phone = excluded.emergency_phone
+RETURNING *;
@@ -0,0 +1,6 @@
+-- name: GetUpcomingCourseMeets :many
+SELECT m.id, m.name, m.date, c.id as course_id, c.name as course_name, c.distance_meters
+FROM meets m
+JOIN courses c ON m.course_id = c.id
+WHERE m.date >= ? AND m.status != 'cancelled'
+ORDER BY m.date;
@@ -0,0 +1,41 @@
+-- Full-table reads and inserts used by the data archive export and import.
+
+-- name: GetAllRaces :many
+SELECT * FROM races ORDER BY id;
+
+-- name: GetAllEntries :many
+SELECT * FROM entries ORDER BY id;
+
+-- name: GetAllBibs :many
+SELECT * FROM bibs ORDER BY id;
+
+-- name: GetAllSplits :many
+SELECT * FROM splits ORDER BY result_id, split_index;
+
+-- name: GetAllAthleteProfiles :many
+SELECT * FROM athlete_profiles ORDER BY athlete_id;
+
+-- name: GetAllWorkoutPlans :many
+SELECT * FROM workout_plans ORDER BY id;
+
+-- name: GetAllWorkoutPlanAthletes :many
+SELECT * FROM workout_plan_athletes ORDER BY plan_id, athlete_id;
+
+-- name: GetAllWorkouts :many
+SELECT * FROM workouts ORDER BY id;
+
+-- name: GetAllPracticeSessions :many
+SELECT * FROM practice_sessions ORDER BY id;
+
+-- name: GetAllAttendance :many
+SELECT * FROM attendance ORDER BY id;
+
+-- name: ImportRecordBreak :one
+INSERT INTO record_breaks (category, distance_meters, gender, grade, result_id, athlete_id, athlete_name, time, previous_holder, previous_time, broken_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: ImportWorkout :one
+INSERT INTO workouts (athlete_id, plan_id, date, type, distance_miles, duration_seconds, rpe, notes, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
@@ -0,0 +1,44 @@
+-- name: GetPracticeSessions :many
+SELECT * FROM practice_sessions
+WHERE date >= sqlc.arg(from_date) AND date <= sqlc.arg(to_date)
+ORDER BY date, id;
+
+-- name: GetPracticeSessionByID :one
+SELECT * FROM practice_sessions WHERE id = ? LIMIT 1;
+
+-- name: CreatePracticeSession :one
+INSERT INTO practice_sessions (date, type, meet_id, notes)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdatePracticeSession :one
+UPDATE practice_sessions
+SET date = ?, type = ?, meet_id = ?, notes = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeletePracticeSession :execrows
+DELETE FROM practice_sessions WHERE id = ?;
+
+-- name: GetAttendanceBySession :many
+SELECT at.id, at.session_id, at.athlete_id, at.status, at.note, a.name as athlete_name
+FROM attendance at
+JOIN athletes a ON at.athlete_id = a.id
+WHERE at.session_id = ?
+ORDER BY a.name;
+
+-- name: GetAttendanceBetween :many
+SELECT at.id, at.session_id, at.athlete_id, at.status, at.note, s.date, s.type
+FROM attendance at
+JOIN practice_sessions s ON at.session_id = s.id
+WHERE s.date >= sqlc.arg(from_date) AND s.date <= sqlc.arg(to_date)
+ORDER BY s.date, s.id;
+
+-- name: UpsertAttendance :one
+INSERT INTO attendance (session_id, athlete_id, status, note)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (session_id, athlete_id) DO UPDATE SET status = excluded.status, note = excluded.note
+RETURNING *;
+
+-- name: DeleteAttendance :execrows
+DELETE FROM attendance WHERE session_id = ? AND athlete_id = ?;
@@ -0,0 +1,27 @@
+-- name: GetBibsByMeet :many
+SELECT b.*, a.name as athlete_name
+FROM bibs b
+JOIN athletes a ON b.athlete_id = a.id
+WHERE b.meet_id = ?
+ORDER BY b.bib;
+
+-- name: GetBibByAthlete :one
+SELECT * FROM bibs WHERE meet_id = ? AND athlete_id = ?;
+
+-- name: GetBibByNumber :one
+SELECT * FROM bibs WHERE meet_id = ? AND bib = ?;
+
+-- name: AssignBib :one
+INSERT INTO bibs (meet_id, athlete_id, bib)
+VALUES (?, ?, ?)
+ON CONFLICT (meet_id, athlete_id) DO UPDATE SET bib = excluded.bib
+RETURNING *;
+
+-- name: DeleteBib :execrows
+DELETE FROM bibs WHERE meet_id = ? AND athlete_id = ?;
+
+-- name: CountResultsByMeet :one
+SELECT COUNT(*) FROM results WHERE meet_id = ?;
+
+-- name: DeleteResultsByMeet :exec
+DELETE FROM results WHERE meet_id = ?;
@@ -0,0 +1,33 @@
+-- name: GetAllCourses :many
+SELECT * FROM courses ORDER BY name;
+
+-- name: GetCourseByID :one
+SELECT * FROM courses WHERE id = ? LIMIT 1;
+
+-- name: CreateCourse :one
+INSERT INTO courses (name, venue, distance_meters, surface, elevation_notes)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateCourse :one
+UPDATE courses
+SET name = ?, venue = ?, distance_meters = ?, surface = ?, elevation_notes = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteCourse :execrows
+DELETE FROM courses WHERE id = ?;
+
+-- name: GetResultsByCourse :many
+SELECT r.*, a.name as athlete_name, m.name as meet_name, m.date as meet_date
+FROM results r
+JOIN athletes a ON r.athlete_id = a.id
+JOIN meets m ON r.meet_id = m.id
+WHERE m.course_id = ?
+ORDER BY m.date;
+
+-- name: GetMeetDistance :one
+SELECT c.distance_meters
+FROM meets m
+JOIN courses c ON m.course_id = c.id
+WHERE m.id = ?;
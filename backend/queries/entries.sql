@@ -0,0 +1,36 @@
+-- name: GetRacesByMeet :many
+SELECT * FROM races WHERE meet_id = ? ORDER BY id;
+
+-- name: GetRaceByID :one
+SELECT * FROM races WHERE id = ? LIMIT 1;
+
+-- name: CreateRace :one
+INSERT INTO races (meet_id, name, gender, scorer_limit, alternate_limit, entry_deadline)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateRace :one
+UPDATE races
+SET name = ?, gender = ?, scorer_limit = ?, alternate_limit = ?, entry_deadline = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteRace :execrows
+DELETE FROM races WHERE id = ?;
+
+-- name: GetEntriesByMeet :many
+SELECT e.*, a.name as athlete_name, a.gender, a.graduation_year, a.jersey_number, b.bib
+FROM entries e
+JOIN races r ON e.race_id = r.id
+JOIN athletes a ON e.athlete_id = a.id
+LEFT JOIN bibs b ON b.meet_id = r.meet_id AND b.athlete_id = e.athlete_id
+WHERE r.meet_id = ?
+ORDER BY e.race_id, CASE e.role WHEN 'scorer' THEN 0 ELSE 1 END, e.position;
+
+-- name: CreateEntry :one
+INSERT INTO entries (race_id, athlete_id, role, position)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: DeleteEntriesByRace :exec
+DELETE FROM entries WHERE race_id = ?;
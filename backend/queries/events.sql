@@ -0,0 +1,32 @@
+-- name: GetAllEvents :many
+SELECT e.*, COUNT(ae.athlete_id) AS athlete_count
+FROM events e
+LEFT JOIN athlete_events ae ON ae.event_id = e.id
+GROUP BY e.id
+ORDER BY e.name;
+
+-- name: GetAllAthleteEvents :many
+SELECT ae.athlete_id, e.name
+FROM athlete_events ae
+JOIN events e ON ae.event_id = e.id
+ORDER BY e.name;
+
+-- name: GetAthleteEventNames :many
+SELECT e.name
+FROM athlete_events ae
+JOIN events e ON ae.event_id = e.id
+WHERE ae.athlete_id = ?
+ORDER BY e.name;
+
+-- name: UpsertEvent :one
+INSERT INTO events (name)
+VALUES (?)
+ON CONFLICT (name) DO UPDATE SET name = events.name
+RETURNING *;
+
+-- name: AddAthleteEvent :exec
+INSERT OR IGNORE INTO athlete_events (athlete_id, event_id)
+VALUES (?, ?);
+
+-- name: DeleteAthleteEvents :exec
+DELETE FROM athlete_events WHERE athlete_id = ?;
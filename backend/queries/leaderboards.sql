@@ -0,0 +1,8 @@
+-- name: GetLeaderboardResults :many
+SELECT r.id, r.athlete_id, r.meet_id, r.time, a.name as athlete_name, a.gender, a.graduation_year,
+       m.name as meet_name, m.date as meet_date, m.course_id, c.distance_meters
+FROM results r
+JOIN athletes a ON r.athlete_id = a.id
+JOIN meets m ON r.meet_id = m.id
+JOIN courses c ON m.course_id = c.id
+ORDER BY c.distance_meters, r.id;
@@ -0,0 +1,54 @@
+-- name: GetAllSubscriptions :many
+SELECT * FROM email_subscriptions ORDER BY email;
+
+-- name: GetSubscriptionByAccount :one
+SELECT * FROM email_subscriptions WHERE account_id = ? LIMIT 1;
+
+-- name: GetSubscriptionByToken :one
+SELECT * FROM email_subscriptions WHERE unsubscribe_token = ? LIMIT 1;
+
+-- name: CreateSubscription :one
+INSERT INTO email_subscriptions (email, account_id, meet_changes, results, unsubscribe_token)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateSubscription :one
+UPDATE email_subscriptions
+SET email = ?, meet_changes = ?, results = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteSubscription :execrows
+DELETE FROM email_subscriptions WHERE id = ?;
+
+-- name: EnqueueEmail :one
+INSERT INTO email_outbox (to_email, subject, body, event, next_attempt_at)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetDueEmails :many
+SELECT * FROM email_outbox
+WHERE status = 'pending' AND next_attempt_at <= ?
+ORDER BY id
+LIMIT ?;
+
+-- name: GetOutbox :many
+SELECT * FROM email_outbox ORDER BY id DESC LIMIT ?;
+
+-- name: GetOutboxByStatus :many
+SELECT * FROM email_outbox WHERE status = ? ORDER BY id DESC LIMIT ?;
+
+-- name: MarkEmailSent :exec
+UPDATE email_outbox
+SET status = 'sent', attempts = attempts + 1, sent_at = ?, last_error = NULL
+WHERE id = ?;
+
+-- name: MarkEmailAttemptFailed :exec
+UPDATE email_outbox
+SET status = ?, attempts = attempts + 1, next_attempt_at = ?, last_error = ?
+WHERE id = ?;
+
+-- name: RetryEmail :execrows
+UPDATE email_outbox
+SET status = 'pending', attempts = 0, next_attempt_at = ?
+WHERE id = ? AND status = 'failed';
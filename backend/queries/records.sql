@@ -0,0 +1,24 @@
+-- name: GetAllHistoricalMarks :many
+SELECT * FROM historical_marks ORDER BY distance_meters, time;
+
+-- name: CreateHistoricalMark :one
+INSERT INTO historical_marks (athlete_name, gender, grade, distance_meters, time, date, meet_name, notes)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateHistoricalMark :one
+UPDATE historical_marks
+SET athlete_name = ?, gender = ?, grade = ?, distance_meters = ?, time = ?, date = ?, meet_name = ?, notes = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteHistoricalMark :execrows
+DELETE FROM historical_marks WHERE id = ?;
+
+-- name: GetRecordBreaks :many
+SELECT * FROM record_breaks ORDER BY broken_at DESC, id DESC;
+
+-- name: CreateRecordBreak :one
+INSERT INTO record_breaks (category, distance_meters, gender, grade, result_id, athlete_id, athlete_name, time, previous_holder, previous_time)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
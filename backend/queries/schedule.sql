@@ -0,0 +1,15 @@
+-- name: GetUpcomingMeets :many
+SELECT * FROM meets
+WHERE date >= ?
+ORDER BY date, start_time, id;
+
+-- name: GetPastMeets :many
+SELECT * FROM meets
+WHERE date < ?
+ORDER BY date DESC, start_time DESC, id DESC;
+
+-- name: GetNextMeet :one
+SELECT * FROM meets
+WHERE date >= ? AND status NOT IN ('cancelled', 'final')
+ORDER BY date, start_time, id
+LIMIT 1;
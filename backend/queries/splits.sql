@@ -0,0 +1,10 @@
+-- name: GetSplitsByResult :many
+SELECT * FROM splits WHERE result_id = ? ORDER BY split_index;
+
+-- name: CreateSplit :one
+INSERT INTO splits (result_id, split_index, distance_meters, time)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: DeleteSplitsByResult :exec
+DELETE FROM splits WHERE result_id = ?;
@@ -0,0 +1,19 @@
+-- name: GetAllAthleteStatuses :many
+SELECT * FROM athlete_statuses ORDER BY athlete_id, start_date, id;
+
+-- name: GetAthleteStatuses :many
+SELECT * FROM athlete_statuses WHERE athlete_id = ? ORDER BY start_date, id;
+
+-- name: CreateAthleteStatus :one
+INSERT INTO athlete_statuses (athlete_id, status, start_date, end_date, note)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateAthleteStatus :one
+UPDATE athlete_statuses
+SET status = ?, start_date = ?, end_date = ?, note = ?
+WHERE id = ? AND athlete_id = ?
+RETURNING *;
+
+-- name: DeleteAthleteStatus :execrows
+DELETE FROM athlete_statuses WHERE id = ? AND athlete_id = ?;
@@ -0,0 +1,63 @@
+-- name: GetWorkoutsByAthlete :many
+SELECT * FROM workouts
+WHERE athlete_id = sqlc.arg(athlete_id) AND date >= sqlc.arg(from_date) AND date <= sqlc.arg(to_date)
+ORDER BY date DESC, id DESC;
+
+-- name: GetWorkoutsBetween :many
+SELECT * FROM workouts
+WHERE date >= sqlc.arg(from_date) AND date <= sqlc.arg(to_date)
+ORDER BY date, id;
+
+-- name: GetWorkoutByID :one
+SELECT * FROM workouts WHERE id = ? LIMIT 1;
+
+-- name: CreateWorkout :one
+INSERT INTO workouts (athlete_id, plan_id, date, type, distance_miles, duration_seconds, rpe, notes)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateWorkout :one
+UPDATE workouts
+SET plan_id = ?, date = ?, type = ?, distance_miles = ?, duration_seconds = ?, rpe = ?, notes = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteWorkout :execrows
+DELETE FROM workouts WHERE id = ?;
+
+-- name: GetWorkoutPlans :many
+SELECT * FROM workout_plans
+WHERE date >= sqlc.arg(from_date) AND date <= sqlc.arg(to_date)
+ORDER BY date, id;
+
+-- name: GetWorkoutPlansByAthlete :many
+SELECT p.* FROM workout_plans p
+JOIN workout_plan_athletes pa ON pa.plan_id = p.id
+WHERE pa.athlete_id = sqlc.arg(athlete_id) AND p.date >= sqlc.arg(from_date) AND p.date <= sqlc.arg(to_date)
+ORDER BY p.date, p.id;
+
+-- name: GetWorkoutPlanByID :one
+SELECT * FROM workout_plans WHERE id = ? LIMIT 1;
+
+-- name: CreateWorkoutPlan :one
+INSERT INTO workout_plans (date, type, title, distance_miles, duration_seconds, description)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateWorkoutPlan :one
+UPDATE workout_plans
+SET date = ?, type = ?, title = ?, distance_miles = ?, duration_seconds = ?, description = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteWorkoutPlan :execrows
+DELETE FROM workout_plans WHERE id = ?;
+
+-- name: GetWorkoutPlanAthleteIDs :many
+SELECT athlete_id FROM workout_plan_athletes WHERE plan_id = ? ORDER BY athlete_id;
+
+-- name: AddWorkoutPlanAthlete :exec
+INSERT OR IGNORE INTO workout_plan_athletes (plan_id, athlete_id) VALUES (?, ?);
+
+-- name: DeleteWorkoutPlanAthletes :exec
+DELETE FROM workout_plan_athletes WHERE plan_id = ?;
@@ -0,0 +1,65 @@
+-- name: GetAllWebhooks :many
+SELECT * FROM webhooks ORDER BY id;
+
+-- name: GetWebhookByID :one
+SELECT * FROM webhooks WHERE id = ? LIMIT 1;
+
+-- name: GetWebhooksForEvent :many
+SELECT w.* FROM webhooks w
+JOIN webhook_events e ON e.webhook_id = w.id
+WHERE e.event = ? AND w.active = 1
+ORDER BY w.id;
+
+-- name: CreateWebhook :one
+INSERT INTO webhooks (url, secret, active)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: UpdateWebhook :one
+UPDATE webhooks SET url = ?, secret = ?, active = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks WHERE id = ?;
+
+-- name: GetWebhookEvents :many
+SELECT event FROM webhook_events WHERE webhook_id = ? ORDER BY event;
+
+-- name: AddWebhookEvent :exec
+INSERT OR IGNORE INTO webhook_events (webhook_id, event) VALUES (?, ?);
+
+-- name: DeleteWebhookEvents :exec
+DELETE FROM webhook_events WHERE webhook_id = ?;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (webhook_id, event, payload, next_attempt_at)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetDueWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE status = 'pending' AND next_attempt_at <= ?
+ORDER BY id
+LIMIT ?;
+
+-- name: GetWebhookDeliveries :many
+SELECT * FROM webhook_deliveries WHERE webhook_id = ? ORDER BY id DESC LIMIT ?;
+
+-- name: GetWebhookDeliveriesByStatus :many
+SELECT * FROM webhook_deliveries WHERE webhook_id = ? AND status = ? ORDER BY id DESC LIMIT ?;
+
+-- name: MarkWebhookDelivered :exec
+UPDATE webhook_deliveries
+SET status = 'delivered', attempts = attempts + 1, response_status = ?, last_error = NULL, delivered_at = ?
+WHERE id = ?;
+
+-- name: MarkWebhookAttemptFailed :exec
+UPDATE webhook_deliveries
+SET status = ?, attempts = attempts + 1, next_attempt_at = ?, response_status = ?, last_error = ?
+WHERE id = ?;
+
+-- name: RetryWebhookDelivery :execrows
+UPDATE webhook_deliveries
+SET status = 'pending', attempts = 0, next_attempt_at = ?
+WHERE id = ? AND webhook_id = ? AND status = 'failed';
@@ -0,0 +1,49 @@
+package main
+
+import "sort"
+
+// markCandidate is one timed result considered for a ranking. Row is the
+// index of the caller's source row the mark came from.
+type markCandidate struct {
+	AthleteID int64
+	Name      string
+	Seconds   float64
+	Row       int
+}
+
+// bestPerAthlete keeps each athlete's fastest mark and returns them fastest
+// first. Equal times are ordered by name so the output is stable.
+func bestPerAthlete(candidates []markCandidate) []markCandidate {
+	best := map[int64]markCandidate{}
+	for _, c := range candidates {
+		if prev, ok := best[c.AthleteID]; ok && prev.Seconds <= c.Seconds {
+			continue
+		}
+		best[c.AthleteID] = c
+	}
+
+	marks := make([]markCandidate, 0, len(best))
+	for _, m := range best {
+		marks = append(marks, m)
+	}
+	sort.Slice(marks, func(i, j int) bool {
+		if marks[i].Seconds != marks[j].Seconds {
+			return marks[i].Seconds < marks[j].Seconds
+		}
+		return marks[i].Name < marks[j].Name
+	})
+	return marks
+}
+
+// competitionRanks returns "1, 2, 2, 4" style ranks for marks that are
+// already sorted fastest first, so tied times share a place.
+func competitionRanks(marks []markCandidate) []int {
+	ranks := make([]int, len(marks))
+	for i := range marks {
+		ranks[i] = i + 1
+		if i > 0 && marks[i].Seconds == marks[i-1].Seconds {
+			ranks[i] = ranks[i-1]
+		}
+	}
+	return ranks
+}
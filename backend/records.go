@@ -0,0 +1,539 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+	"jones-county-xc/backend/pace"
+)
+
+const recordBookSize = 10
+
+// Record categories stored on record_breaks.
+const (
+	recordAllTime = "all-time"
+	recordGrade   = "grade"
+)
+
+type RecordEntry struct {
+	Rank         int     `json:"rank"`
+	AthleteID    *int64  `json:"athleteId"`
+	AthleteName  string  `json:"athleteName"`
+	Grade        *int64  `json:"grade"`
+	Time         string  `json:"time"`
+	Date         *string `json:"date"`
+	MeetName     *string `json:"meetName"`
+	ResultID     *int64  `json:"resultId"`
+	HistoricalID *int64  `json:"historicalId"`
+}
+
+type GradeRecord struct {
+	Grade  int64       `json:"grade"`
+	Record RecordEntry `json:"record"`
+}
+
+type RecordBookResponse struct {
+	DistanceMeters float64       `json:"distanceMeters"`
+	Gender         *string       `json:"gender"`
+	AllTime        []RecordEntry `json:"allTime"`
+	ByGrade        []GradeRecord `json:"byGrade"`
+}
+
+type RecordBreakResponse struct {
+	ID             int64   `json:"id"`
+	Category       string  `json:"category"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	Gender         *string `json:"gender"`
+	Grade          *int64  `json:"grade"`
+	ResultID       *int64  `json:"resultId"`
+	AthleteID      *int64  `json:"athleteId"`
+	AthleteName    string  `json:"athleteName"`
+	Time           string  `json:"time"`
+	PreviousHolder *string `json:"previousHolder"`
+	PreviousTime   *string `json:"previousTime"`
+	BrokenAt       string  `json:"brokenAt"`
+}
+
+type HistoricalMarkResponse struct {
+	ID             int64   `json:"id"`
+	AthleteName    string  `json:"athleteName"`
+	Gender         *string `json:"gender"`
+	Grade          *int64  `json:"grade"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	Time           string  `json:"time"`
+	Date           *string `json:"date"`
+	MeetName       *string `json:"meetName"`
+	Notes          *string `json:"notes"`
+}
+
+type historicalMarkInput struct {
+	AthleteName    string  `json:"athleteName"`
+	Gender         *string `json:"gender"`
+	Grade          *int64  `json:"grade"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	Time           string  `json:"time"`
+	Date           *string `json:"date"`
+	MeetName       *string `json:"meetName"`
+	Notes          *string `json:"notes"`
+}
+
+// recordMark is a single mark eligible for the record book, either a result
+// on a course or a manual historical entry.
+type recordMark struct {
+	Key            int64
+	Seconds        float64
+	DistanceMeters float64
+	Gender         string
+	Grade          sql.NullInt64
+	Entry          RecordEntry
+}
+
+// --- Read handlers ---
+
+// GetRecords returns the school record book: the all-time top 10 and the
+// record for each grade, per distance and gender. Optional filters:
+// distance (meters) and gender.
+func (s *Server) GetRecords(c *gin.Context) {
+	ctx := c.Request.Context()
+	var distance float64
+	if v := c.Query("distance"); v != "" {
+		meters, err := strconv.ParseFloat(v, 64)
+		if err != nil || meters <= 0 {
+			c.JSON(400, gin.H{"error": "invalid distance"})
+			return
+		}
+		distance = meters
+	}
+	gender := c.Query("gender")
+
+	marks, err := s.loadRecordMarks(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := []RecordBookResponse{}
+	for _, book := range buildRecordBooks(marks) {
+		if distance != 0 && book.DistanceMeters != distance {
+			continue
+		}
+		if gender != "" && (book.Gender == nil || *book.Gender != gender) {
+			continue
+		}
+		response = append(response, book)
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) GetRecordBreaks(c *gin.Context) {
+	ctx := c.Request.Context()
+	breaks, err := s.store.GetRecordBreaks(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]RecordBreakResponse, len(breaks))
+	for i, b := range breaks {
+		response[i] = recordBreakResponse(b)
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) GetHistoricalMarks(c *gin.Context) {
+	ctx := c.Request.Context()
+	marks, err := s.store.GetAllHistoricalMarks(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]HistoricalMarkResponse, len(marks))
+	for i, m := range marks {
+		response[i] = historicalMarkResponse(m)
+	}
+	c.JSON(200, response)
+}
+
+// --- Historical mark write handlers ---
+
+func (s *Server) CreateHistoricalMark(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input historicalMarkInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateHistoricalMark(input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	mark, err := s.store.CreateHistoricalMark(ctx, db.CreateHistoricalMarkParams{
+		AthleteName:    input.AthleteName,
+		Gender:         ptrToNullString(input.Gender),
+		Grade:          ptrToNullInt64(input.Grade),
+		DistanceMeters: input.DistanceMeters,
+		Time:           input.Time,
+		Date:           ptrToNullString(input.Date),
+		MeetName:       ptrToNullString(input.MeetName),
+		Notes:          ptrToNullString(input.Notes),
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(201, historicalMarkResponse(mark))
+}
+
+func (s *Server) UpdateHistoricalMark(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var markID int64
+	if _, err := fmt.Sscanf(id, "%d", &markID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid historical mark ID"})
+		return
+	}
+
+	var input historicalMarkInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateHistoricalMark(input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	mark, err := s.store.UpdateHistoricalMark(ctx, db.UpdateHistoricalMarkParams{
+		ID:             markID,
+		AthleteName:    input.AthleteName,
+		Gender:         ptrToNullString(input.Gender),
+		Grade:          ptrToNullInt64(input.Grade),
+		DistanceMeters: input.DistanceMeters,
+		Time:           input.Time,
+		Date:           ptrToNullString(input.Date),
+		MeetName:       ptrToNullString(input.MeetName),
+		Notes:          ptrToNullString(input.Notes),
+	})
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "historical mark not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, historicalMarkResponse(mark))
+}
+
+func (s *Server) DeleteHistoricalMark(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var markID int64
+	if _, err := fmt.Sscanf(id, "%d", &markID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid historical mark ID"})
+		return
+	}
+
+	n, err := s.store.DeleteHistoricalMark(ctx, markID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "historical mark not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "historical mark deleted"})
+}
+
+// --- Record detection ---
+
+// checkRecords compares newly saved results with the all-time and grade
+// records at their distance and gender. Each record a result beats is logged
+// to record_breaks and emitted as EventRecordBroken. A first-ever mark in a
+// category sets the record without counting as a break. Results checked
+// together, such as a whole imported meet, are measured against the records
+// that stood before them rather than against each other. Failures are
+// logged rather than returned so they never fail the write itself.
+func (s *Server) checkRecords(ctx context.Context, resultIDs ...int64) []RecordBreakResponse {
+	if len(resultIDs) == 0 {
+		return nil
+	}
+	marks, err := s.loadRecordMarks(ctx)
+	if err != nil {
+		log.Printf("Record check for %d result(s) failed: %v", len(resultIDs), err)
+		return nil
+	}
+
+	checking := map[int64]bool{}
+	for _, id := range resultIDs {
+		checking[id] = true
+	}
+	var broken []RecordBreakResponse
+	for i := range marks {
+		if id := marks[i].Entry.ResultID; id != nil && checking[*id] {
+			broken = append(broken, s.recordBreaks(ctx, marks, &marks[i], checking)...)
+		}
+	}
+	return broken
+}
+
+// recordBreaks logs the records mark beats among marks, skipping the other
+// results being checked with it.
+func (s *Server) recordBreaks(ctx context.Context, marks []recordMark, mark *recordMark, checking map[int64]bool) []RecordBreakResponse {
+	resultID := *mark.Entry.ResultID
+	var allTime, grade *recordMark
+	for i := range marks {
+		m := &marks[i]
+		if m.DistanceMeters != mark.DistanceMeters || m.Gender != mark.Gender {
+			continue
+		}
+		if m.Entry.ResultID != nil && checking[*m.Entry.ResultID] {
+			continue
+		}
+		if allTime == nil || m.Seconds < allTime.Seconds {
+			allTime = m
+		}
+		if mark.Grade.Valid && m.Grade == mark.Grade && (grade == nil || m.Seconds < grade.Seconds) {
+			grade = m
+		}
+	}
+
+	var broken []RecordBreakResponse
+	for _, prev := range []struct {
+		category string
+		mark     *recordMark
+	}{{recordAllTime, allTime}, {recordGrade, grade}} {
+		if prev.mark == nil || mark.Seconds >= prev.mark.Seconds {
+			continue
+		}
+
+		params := db.CreateRecordBreakParams{
+			Category:       prev.category,
+			DistanceMeters: mark.DistanceMeters,
+			Gender:         sql.NullString{String: mark.Gender, Valid: mark.Gender != ""},
+			ResultID:       sql.NullInt64{Int64: resultID, Valid: true},
+			AthleteID:      ptrToNullInt64(mark.Entry.AthleteID),
+			AthleteName:    mark.Entry.AthleteName,
+			Time:           mark.Entry.Time,
+			PreviousHolder: sql.NullString{String: prev.mark.Entry.AthleteName, Valid: true},
+			PreviousTime:   sql.NullString{String: prev.mark.Entry.Time, Valid: true},
+		}
+		if prev.category == recordGrade {
+			params.Grade = mark.Grade
+		}
+
+		b, err := s.store.CreateRecordBreak(ctx, params)
+		if err != nil {
+			log.Printf("Saving record break for result %d failed: %v", resultID, err)
+			continue
+		}
+		resp := recordBreakResponse(b)
+		s.emitEvent(EventRecordBroken, resp)
+		broken = append(broken, resp)
+	}
+	return broken
+}
+
+// --- Helpers ---
+
+// loadRecordMarks gathers every course result and historical entry. Results
+// are keyed by athlete ID; historical entries get negative keys per distinct
+// name so the same pre-system runner is only listed once.
+func (s *Server) loadRecordMarks(ctx context.Context) ([]recordMark, error) {
+	results, err := s.store.GetLeaderboardResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	historical, err := s.store.GetAllHistoricalMarks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var marks []recordMark
+	for _, r := range results {
+		if !r.AthleteID.Valid || !r.Time.Valid {
+			continue
+		}
+		seconds, err := pace.Parse(r.Time.String)
+		if err != nil {
+			continue
+		}
+		resultID := r.ID
+		grade := gradeAtMeet(r.GraduationYear, r.MeetDate)
+		marks = append(marks, recordMark{
+			Key:            r.AthleteID.Int64,
+			Seconds:        seconds,
+			DistanceMeters: r.DistanceMeters,
+			Gender:         r.Gender.String,
+			Grade:          grade,
+			Entry: RecordEntry{
+				AthleteID:   nullInt64ToPtr(r.AthleteID),
+				AthleteName: r.AthleteName,
+				Grade:       nullInt64ToPtr(grade),
+				Time:        r.Time.String,
+				Date:        nullStringToPtr(r.MeetDate),
+				MeetName:    &r.MeetName,
+				ResultID:    &resultID,
+			},
+		})
+	}
+
+	historicalKeys := map[string]int64{}
+	for _, h := range historical {
+		seconds, err := pace.Parse(h.Time)
+		if err != nil {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSpace(h.AthleteName))
+		key, ok := historicalKeys[name]
+		if !ok {
+			key = -int64(len(historicalKeys) + 1)
+			historicalKeys[name] = key
+		}
+		historicalID := h.ID
+		marks = append(marks, recordMark{
+			Key:            key,
+			Seconds:        seconds,
+			DistanceMeters: h.DistanceMeters,
+			Gender:         h.Gender.String,
+			Grade:          h.Grade,
+			Entry: RecordEntry{
+				AthleteName:  h.AthleteName,
+				Grade:        nullInt64ToPtr(h.Grade),
+				Time:         h.Time,
+				Date:         nullStringToPtr(h.Date),
+				MeetName:     nullStringToPtr(h.MeetName),
+				HistoricalID: &historicalID,
+			},
+		})
+	}
+	return marks, nil
+}
+
+// buildRecordBooks groups marks by distance and gender. The all-time list
+// holds each athlete's best mark; grade records are the single fastest mark
+// run in that grade.
+func buildRecordBooks(marks []recordMark) []RecordBookResponse {
+	type bookKey struct {
+		distance float64
+		gender   string
+	}
+	groups := map[bookKey][]int{}
+	var keys []bookKey
+	for i, m := range marks {
+		k := bookKey{m.DistanceMeters, m.Gender}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], i)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].distance != keys[j].distance {
+			return keys[i].distance < keys[j].distance
+		}
+		return keys[i].gender < keys[j].gender
+	})
+
+	books := make([]RecordBookResponse, 0, len(keys))
+	for _, k := range keys {
+		book := RecordBookResponse{DistanceMeters: k.distance, AllTime: []RecordEntry{}, ByGrade: []GradeRecord{}}
+		if k.gender != "" {
+			gender := k.gender
+			book.Gender = &gender
+		}
+
+		var candidates []markCandidate
+		byGrade := map[int64][]markCandidate{}
+		for _, i := range groups[k] {
+			m := marks[i]
+			cand := markCandidate{AthleteID: m.Key, Name: m.Entry.AthleteName, Seconds: m.Seconds, Row: i}
+			candidates = append(candidates, cand)
+			if m.Grade.Valid {
+				byGrade[m.Grade.Int64] = append(byGrade[m.Grade.Int64], cand)
+			}
+		}
+
+		best := bestPerAthlete(candidates)
+		ranks := competitionRanks(best)
+		for i, m := range best {
+			if ranks[i] > recordBookSize {
+				break
+			}
+			entry := marks[m.Row].Entry
+			entry.Rank = ranks[i]
+			book.AllTime = append(book.AllTime, entry)
+		}
+
+		grades := make([]int64, 0, len(byGrade))
+		for g := range byGrade {
+			grades = append(grades, g)
+		}
+		sort.Slice(grades, func(i, j int) bool { return grades[i] < grades[j] })
+		for _, g := range grades {
+			entry := marks[bestPerAthlete(byGrade[g])[0].Row].Entry
+			entry.Rank = 1
+			book.ByGrade = append(book.ByGrade, GradeRecord{Grade: g, Record: entry})
+		}
+
+		books = append(books, book)
+	}
+	return books
+}
+
+func validateHistoricalMark(input historicalMarkInput) error {
+	if strings.TrimSpace(input.AthleteName) == "" {
+		return fmt.Errorf("athleteName is required")
+	}
+	if input.DistanceMeters <= 0 {
+		return fmt.Errorf("distanceMeters must be positive")
+	}
+	if _, err := pace.Parse(input.Time); err != nil {
+		return err
+	}
+	if !validGender(input.Gender) {
+		return fmt.Errorf("gender must be \"M\" or \"F\"")
+	}
+	return nil
+}
+
+func recordBreakResponse(b db.RecordBreak) RecordBreakResponse {
+	return RecordBreakResponse{
+		ID:             b.ID,
+		Category:       b.Category,
+		DistanceMeters: b.DistanceMeters,
+		Gender:         nullStringToPtr(b.Gender),
+		Grade:          nullInt64ToPtr(b.Grade),
+		ResultID:       nullInt64ToPtr(b.ResultID),
+		AthleteID:      nullInt64ToPtr(b.AthleteID),
+		AthleteName:    b.AthleteName,
+		Time:           b.Time,
+		PreviousHolder: nullStringToPtr(b.PreviousHolder),
+		PreviousTime:   nullStringToPtr(b.PreviousTime),
+		BrokenAt:       b.BrokenAt,
+	}
+}
+
+func historicalMarkResponse(m db.HistoricalMark) HistoricalMarkResponse {
+	return HistoricalMarkResponse{
+		ID:             m.ID,
+		AthleteName:    m.AthleteName,
+		Gender:         nullStringToPtr(m.Gender),
+		Grade:          nullInt64ToPtr(m.Grade),
+		DistanceMeters: m.DistanceMeters,
+		Time:           m.Time,
+		Date:           nullStringToPtr(m.Date),
+		MeetName:       nullStringToPtr(m.MeetName),
+		Notes:          nullStringToPtr(m.Notes),
+	}
+}
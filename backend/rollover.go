@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+)
+
+type RolloverAthlete struct {
+	AthleteID      int64  `json:"athleteId"`
+	Name           string `json:"name"`
+	GraduationYear *int64 `json:"graduationYear"`
+	FromGrade      *int64 `json:"fromGrade"`
+	ToGrade        *int64 `json:"toGrade"`
+}
+
+// RolloverPreview describes what closing a school year changes. Grades are
+// derived from graduation years, so advancing them needs no writes; the
+// preview lists them so the coach can check the new roster. Applying the
+// rollover only moves graduates to the alumni archive.
+type RolloverPreview struct {
+	Year                  int64             `json:"year"`
+	Graduates             []RolloverAthlete `json:"graduates"`
+	Advancing             []RolloverAthlete `json:"advancing"`
+	MissingGraduationYear []RolloverAthlete `json:"missingGraduationYear"`
+	Applied               bool              `json:"applied"`
+}
+
+type AlumniClass struct {
+	GraduationYear *int64            `json:"graduationYear"`
+	Athletes       []AthleteResponse `json:"athletes"`
+}
+
+// --- Handlers ---
+
+// PreviewRollover shows what closing the school year ending in ?year=
+// (default: this calendar year) would change, without writing anything.
+func (s *Server) PreviewRollover(c *gin.Context) {
+	ctx := c.Request.Context()
+	year := int64(time.Now().Year())
+	if v, ok := optionalInt64Query(c, "year"); !ok {
+		c.JSON(400, gin.H{"error": "invalid year"})
+		return
+	} else if v.Valid {
+		year = v.Int64
+	}
+
+	preview, err := s.planRollover(ctx, year)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, preview)
+}
+
+// ApplyRollover closes a school year: every athlete graduating that year or
+// earlier who isn't already an alumnus gets an alumni status from today.
+// Athlete rows, results and records are left as they are. Running it twice
+// is harmless.
+func (s *Server) ApplyRollover(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input struct {
+		Year *int64 `json:"year"`
+	}
+	// The body is optional; an empty one applies the current year.
+	if err := c.ShouldBindJSON(&input); err != nil && err != io.EOF {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	year := int64(time.Now().Year())
+	if input.Year != nil {
+		year = *input.Year
+	}
+
+	preview, err := s.applyRollover(ctx, year)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, preview)
+}
+
+// GetAlumni lists the alumni archive grouped by class, newest class first.
+// ?q= filters by name.
+func (s *Server) GetAlumni(c *gin.Context) {
+	ctx := c.Request.Context()
+	athletes, err := s.store.GetAllAthletes(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	events, err := s.athleteEventNames(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	statuses, err := s.athleteCurrentStatuses(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	q := strings.ToLower(strings.TrimSpace(c.Query("q")))
+	byClass := map[int64]*AlumniClass{}
+	var years []int64
+	for _, a := range athletes {
+		if statuses[a.ID] != statusAlumni {
+			continue
+		}
+		if q != "" && !strings.Contains(strings.ToLower(a.Name), q) {
+			continue
+		}
+		// Alumni without a graduation year are grouped under key 0.
+		key := a.GraduationYear.Int64
+		class, ok := byClass[key]
+		if !ok {
+			class = &AlumniClass{GraduationYear: nullInt64ToPtr(a.GraduationYear), Athletes: []AthleteResponse{}}
+			byClass[key] = class
+			years = append(years, key)
+		}
+		class.Athletes = append(class.Athletes, athleteResponse(a, events[a.ID], statusAlumni))
+	}
+	sort.Slice(years, func(i, j int) bool { return years[i] > years[j] })
+
+	response := make([]AlumniClass, len(years))
+	for i, year := range years {
+		response[i] = *byClass[year]
+	}
+	c.JSON(200, response)
+}
+
+// --- CLI ---
+
+// runRolloverCommand implements `backend rollover [-year N] [-apply]`. It
+// prints the preview as JSON and only writes with -apply.
+func runRolloverCommand(s *Server, args []string) error {
+	fs := flag.NewFlagSet("rollover", flag.ContinueOnError)
+	year := fs.Int64("year", int64(time.Now().Year()), "school year to close, by the calendar year it ends in")
+	apply := fs.Bool("apply", false, "move graduates to the alumni archive (default: preview only)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var preview RolloverPreview
+	var err error
+	if *apply {
+		preview, err = s.applyRollover(context.Background(), *year)
+	} else {
+		preview, err = s.planRollover(context.Background(), *year)
+	}
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(preview); err != nil {
+		return err
+	}
+	if !*apply {
+		fmt.Fprintln(os.Stderr, "Preview only; run again with -apply to move graduates to the alumni archive.")
+	}
+	return nil
+}
+
+// --- Helpers ---
+
+// planRollover sorts the current roster for closing the school year that
+// ends in year. Athletes already in the alumni archive are skipped.
+func (s *Server) planRollover(ctx context.Context, year int64) (RolloverPreview, error) {
+	preview := RolloverPreview{
+		Year:                  year,
+		Graduates:             []RolloverAthlete{},
+		Advancing:             []RolloverAthlete{},
+		MissingGraduationYear: []RolloverAthlete{},
+	}
+
+	athletes, err := s.store.GetAllAthletes(ctx)
+	if err != nil {
+		return preview, err
+	}
+	statuses, err := s.athleteCurrentStatuses(ctx)
+	if err != nil {
+		return preview, err
+	}
+
+	// Any day in the closing school year and in the one after it.
+	closing := time.Date(int(year), time.January, 1, 0, 0, 0, 0, time.UTC)
+	next := time.Date(int(year), time.September, 1, 0, 0, 0, 0, time.UTC)
+	for _, a := range athletes {
+		if statuses[a.ID] == statusAlumni {
+			continue
+		}
+		ra := RolloverAthlete{
+			AthleteID:      a.ID,
+			Name:           a.Name,
+			GraduationYear: nullInt64ToPtr(a.GraduationYear),
+			FromGrade:      nullInt64ToPtr(gradeOn(a.GraduationYear, closing)),
+			ToGrade:        nullInt64ToPtr(gradeOn(a.GraduationYear, next)),
+		}
+		switch {
+		case !a.GraduationYear.Valid:
+			preview.MissingGraduationYear = append(preview.MissingGraduationYear, ra)
+		case a.GraduationYear.Int64 <= year:
+			ra.ToGrade = nil
+			preview.Graduates = append(preview.Graduates, ra)
+		default:
+			preview.Advancing = append(preview.Advancing, ra)
+		}
+	}
+	return preview, nil
+}
+
+func (s *Server) applyRollover(ctx context.Context, year int64) (RolloverPreview, error) {
+	preview, err := s.planRollover(ctx, year)
+	if err != nil {
+		return preview, err
+	}
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		return preview, err
+	}
+	defer tx.Rollback()
+
+	today := time.Now().Format("2006-01-02")
+	for _, g := range preview.Graduates {
+		if _, err := tx.CreateAthleteStatus(ctx, db.CreateAthleteStatusParams{
+			AthleteID: g.AthleteID,
+			Status:    statusAlumni,
+			StartDate: today,
+			Note:      sql.NullString{String: fmt.Sprintf("Class of %d", *g.GraduationYear), Valid: true},
+		}); err != nil {
+			return preview, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return preview, err
+	}
+	preview.Applied = true
+	return preview, nil
+}
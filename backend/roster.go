@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+)
+
+const (
+	statusActive   = "active"
+	statusInjured  = "injured"
+	statusInactive = "inactive"
+	statusAlumni   = "alumni"
+)
+
+type AthleteStatusResponse struct {
+	ID        int64   `json:"id"`
+	AthleteID int64   `json:"athleteId"`
+	Status    string  `json:"status"`
+	StartDate string  `json:"startDate"`
+	EndDate   *string `json:"endDate"`
+	Note      *string `json:"note"`
+}
+
+type statusInput struct {
+	Status    string  `json:"status"`
+	StartDate string  `json:"startDate"`
+	EndDate   *string `json:"endDate"`
+	Note      *string `json:"note"`
+}
+
+// --- Read handlers ---
+
+// GetAthleteStatuses returns an athlete's status history, oldest first.
+func (s *Server) GetAthleteStatuses(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+
+	statuses, err := s.store.GetAthleteStatuses(ctx, athleteID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]AthleteStatusResponse, len(statuses))
+	for i, st := range statuses {
+		response[i] = athleteStatusResponse(st)
+	}
+	c.JSON(200, response)
+}
+
+// --- Write handlers ---
+
+func (s *Server) CreateAthleteStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+	if _, err := s.store.GetAthleteByID(ctx, athleteID); err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "athlete not found"})
+		return
+	} else if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	var input statusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := validateStatus(&input); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	status, err := s.store.CreateAthleteStatus(ctx, db.CreateAthleteStatusParams{
+		AthleteID: athleteID,
+		Status:    input.Status,
+		StartDate: input.StartDate,
+		EndDate:   ptrToNullString(input.EndDate),
+		Note:      ptrToNullString(input.Note),
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(201, athleteStatusResponse(status))
+}
+
+func (s *Server) UpdateAthleteStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	var athleteID, statusID int64
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+	if _, err := fmt.Sscanf(c.Param("statusId"), "%d", &statusID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid status ID"})
+		return
+	}
+
+	var input statusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := validateStatus(&input); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	status, err := s.store.UpdateAthleteStatus(ctx, db.UpdateAthleteStatusParams{
+		Status:    input.Status,
+		StartDate: input.StartDate,
+		EndDate:   ptrToNullString(input.EndDate),
+		Note:      ptrToNullString(input.Note),
+		ID:        statusID,
+		AthleteID: athleteID,
+	})
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "status not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, athleteStatusResponse(status))
+}
+
+func (s *Server) DeleteAthleteStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	var athleteID, statusID int64
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+	if _, err := fmt.Sscanf(c.Param("statusId"), "%d", &statusID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid status ID"})
+		return
+	}
+
+	n, err := s.store.DeleteAthleteStatus(ctx, db.DeleteAthleteStatusParams{
+		ID:        statusID,
+		AthleteID: athleteID,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "status not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "status deleted"})
+}
+
+// --- Helpers ---
+
+func athleteStatusResponse(s db.AthleteStatus) AthleteStatusResponse {
+	return AthleteStatusResponse{
+		ID:        s.ID,
+		AthleteID: s.AthleteID,
+		Status:    s.Status,
+		StartDate: s.StartDate,
+		EndDate:   nullStringToPtr(s.EndDate),
+		Note:      nullStringToPtr(s.Note),
+	}
+}
+
+// validateStatus checks a status entry and defaults its start date to today.
+// It returns an error message, or "" if the input is valid.
+func validateStatus(input *statusInput) string {
+	if !validStatus(input.Status) {
+		return "status must be one of active, injured, inactive, alumni"
+	}
+	if input.StartDate == "" {
+		input.StartDate = time.Now().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", input.StartDate); err != nil {
+		return "startDate must be YYYY-MM-DD"
+	}
+	if input.EndDate != nil {
+		if _, err := time.Parse("2006-01-02", *input.EndDate); err != nil {
+			return "endDate must be YYYY-MM-DD"
+		}
+		if *input.EndDate < input.StartDate {
+			return "endDate must not be before startDate"
+		}
+	}
+	return ""
+}
+
+func validStatus(s string) bool {
+	switch s {
+	case statusActive, statusInjured, statusInactive, statusAlumni:
+		return true
+	}
+	return false
+}
+
+// currentStatus picks the status in effect on day (YYYY-MM-DD) from
+// statuses ordered by start date. The latest-starting entry whose range
+// covers the day wins; with none, the athlete is active.
+func currentStatus(statuses []db.AthleteStatus, day string) string {
+	status := statusActive
+	for _, s := range statuses {
+		if s.StartDate <= day && (!s.EndDate.Valid || s.EndDate.String >= day) {
+			status = s.Status
+		}
+	}
+	return status
+}
+
+// athleteCurrentStatuses maps each athlete ID to its status today.
+func (s *Server) athleteCurrentStatuses(ctx context.Context) (map[int64]string, error) {
+	rows, err := s.store.GetAllAthleteStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byAthlete := map[int64][]db.AthleteStatus{}
+	for _, st := range rows {
+		byAthlete[st.AthleteID] = append(byAthlete[st.AthleteID], st)
+	}
+	today := time.Now().Format("2006-01-02")
+	statuses := map[int64]string{}
+	for id, history := range byAthlete {
+		statuses[id] = currentStatus(history, today)
+	}
+	return statuses, nil
+}
+
+// schoolYearEnd returns the calendar year in which the school year
+// containing t ends. School years start in August.
+func schoolYearEnd(t time.Time) int64 {
+	if t.Month() >= time.August {
+		return int64(t.Year()) + 1
+	}
+	return int64(t.Year())
+}
+
+// gradeOn derives an athlete's grade on a given day from the graduation
+// year. It is null once the athlete has graduated or when the year is
+// unknown.
+func gradeOn(graduationYear sql.NullInt64, t time.Time) sql.NullInt64 {
+	if !graduationYear.Valid {
+		return sql.NullInt64{}
+	}
+	grade := 12 - (graduationYear.Int64 - schoolYearEnd(t))
+	if grade < 1 || grade > 12 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: grade, Valid: true}
+}
+
+// gradeAtMeet is the athlete's grade on the meet date, used so records and
+// history keep the grade a mark was run in.
+func gradeAtMeet(graduationYear sql.NullInt64, date sql.NullString) sql.NullInt64 {
+	t, ok := parseMeetDate(date)
+	if !ok {
+		return sql.NullInt64{}
+	}
+	return gradeOn(graduationYear, t)
+}
+
+// graduationYearFor converts a grade this school year into a graduation
+// year.
+func graduationYearFor(grade int64) int64 {
+	return schoolYearEnd(time.Now()) + 12 - grade
+}
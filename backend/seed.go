@@ -0,0 +1,521 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"jones-county-xc/backend/db"
+	"jones-county-xc/backend/pace"
+)
+
+// The seed generator fills a database with a believable season: a roster
+// spread over four grades, a fall schedule on a handful of courses, and
+// results for every meet already run. Each athlete starts the season at a
+// base fitness and improves toward the championship meets, with day-to-day
+// noise and the odd bad race, so PRs fall the way they do on a real team.
+//
+// Everything comes from one random source. The same seed, roster size and
+// date always produce the same data, which makes it usable for fixtures.
+
+// seedOptions controls the generated data.
+type seedOptions struct {
+	Seed     uint64    // random seed
+	Athletes int       // roster size, split evenly between boys and girls
+	Today    time.Time // meets before this date are final, the rest scheduled
+}
+
+// seedSummary counts what seedDatabase created.
+type seedSummary struct {
+	Courses         int
+	Athletes        int
+	Meets           int
+	Results         int
+	Splits          int
+	Entries         int
+	HistoricalMarks int
+}
+
+type seedCourse struct {
+	name      string
+	venue     string
+	distance  float64
+	surface   string
+	elevation string
+	factor    float64 // time multiplier relative to a flat, fast 5K
+}
+
+var seedCourses = []seedCourse{
+	{"Greyhound Trails", "Jones County High School", 5000, "grass", "Two laps with a long climb behind the baseball fields", 1.01},
+	{"Ocmulgee Riverside", "Amerson River Park", 5000, "mixed", "Flat and fast along the river; gravel in the second mile", 0.985},
+	{"Piedmont Ridge", "Piedmont Ridge Park", 5000, "trail", "Rolling hills throughout, steep finish", 1.035},
+	{"Lake Tobesofkee", "Claystone Park", 4828, "grass", "Three-mile course, mostly flat", 0.995},
+	{"Carrollton Farms", "Carrollton Farms Recreation Area", 5000, "grass", "State course; open fields with one short hill", 1.0},
+}
+
+type seedMeet struct {
+	name   string
+	host   string
+	place  string
+	course int // index into seedCourses
+	week   int // Saturdays after the opener
+	start  string
+}
+
+var seedMeets = []seedMeet{
+	{"Greyhound Opener", "Jones County High School", "Gray, GA", 0, 0, "08:00"},
+	{"Riverside Invitational", "Central High School", "Macon, GA", 1, 1, "08:30"},
+	{"Piedmont Ridge Classic", "Piedmont Academy", "Monticello, GA", 2, 3, "08:00"},
+	{"Tobesofkee Challenge", "Rutland High School", "Macon, GA", 3, 4, "09:00"},
+	{"Jones County Invitational", "Jones County High School", "Gray, GA", 0, 6, "08:00"},
+	{"Middle Georgia Championship", "Houston County High School", "Macon, GA", 1, 8, "08:30"},
+	{"Region Championship", "Baldwin High School", "Monticello, GA", 2, 9, "09:00"},
+	{"State Championship", "GHSA", "Carrollton, GA", 4, 11, "10:00"},
+}
+
+var (
+	seedBoyNames = []string{
+		"Marcus", "Eli", "Jaden", "Owen", "Caleb", "Isaiah", "Landon", "Micah", "Wyatt", "Carter",
+		"Dylan", "Gavin", "Hudson", "Jonah", "Levi", "Mason", "Nolan", "Reid", "Tyler", "Zane",
+	}
+	seedGirlNames = []string{
+		"Sarah", "Ava", "Lily", "Grace", "Chloe", "Emma", "Hannah", "Kate", "Mia", "Nora",
+		"Olivia", "Paige", "Riley", "Sadie", "Taylor", "Addison", "Brooke", "Ella", "Jenna", "Maya",
+	}
+	seedSurnames = []string{
+		"Thompson", "Carter", "Brooks", "Fields", "Mitchell", "Johnson", "Harper", "Whitfield", "Pittman", "Holloway",
+		"Barnes", "Chambers", "Dawson", "Ellis", "Franklin", "Greer", "Hendricks", "Jennings", "Lawson", "McBride",
+		"Nash", "Odom", "Pruitt", "Rowland", "Sizemore", "Tucker", "Vance", "Walden", "Yancey", "Avery",
+	}
+)
+
+// runSeedCommand fills an empty database with a generated season for local
+// development and demos. It refuses to touch a database that already has
+// athletes unless -force is given.
+func runSeedCommand(s *Server, args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	force := fs.Bool("force", false, "seed even if the database already has athletes")
+	seed := fs.Uint64("seed", 1, "random seed; the same seed and -today give the same data")
+	athletes := fs.Int("athletes", 24, "roster size")
+	today := fs.String("today", "", "treat this date (YYYY-MM-DD) as today (default: the real date)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *athletes < 2 || *athletes > 2*len(seedSurnames) {
+		return fmt.Errorf("-athletes must be between 2 and %d", 2*len(seedSurnames))
+	}
+	opts := seedOptions{Seed: *seed, Athletes: *athletes, Today: time.Now()}
+	if *today != "" {
+		t, err := time.Parse("2006-01-02", *today)
+		if err != nil {
+			return fmt.Errorf("-today must be YYYY-MM-DD")
+		}
+		opts.Today = t
+	}
+
+	existing, err := s.store.GetAllAthletes(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 && !*force {
+		return fmt.Errorf("%s already has %d athletes; use -force to add sample data anyway", dbPath, len(existing))
+	}
+
+	summary, err := seedDatabase(s.store, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Seeded %s with %d athletes, %d courses, %d meets, %d results (%d splits), %d entries and %d historical marks (seed %d)\n",
+		dbPath, summary.Athletes, summary.Courses, summary.Meets, summary.Results, summary.Splits,
+		summary.Entries, summary.HistoricalMarks, opts.Seed)
+	return nil
+}
+
+// seedAthlete is the generator's view of a roster spot.
+type seedAthlete struct {
+	id     int64
+	name   string
+	gender string
+	grade  int64
+	jersey int64
+	base   float64 // 5K seconds on a neutral course at the start of the season
+	gain   float64 // fraction of base shaved off by the end of the season
+	best   float64 // fastest 5000m time so far, 0 before the first race
+
+	injuredFrom, injuredTo string // dates missed, empty when healthy
+}
+
+// seedDatabase writes a generated season through the store in one
+// transaction.
+func seedDatabase(store Store, opts seedOptions) (seedSummary, error) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15))
+	var summary seedSummary
+
+	tx, err := store.Begin(context.Background())
+	if err != nil {
+		return summary, err
+	}
+	defer tx.Rollback()
+
+	// The season is the fall of the current school year.
+	schoolYear := schoolYearEnd(opts.Today)
+	season := int(schoolYear) - 1
+	today := opts.Today.Format("2006-01-02")
+
+	courseIDs := make([]int64, len(seedCourses))
+	for i, c := range seedCourses {
+		course, err := tx.CreateCourse(ctx, db.CreateCourseParams{
+			Name:           c.name,
+			Venue:          sql.NullString{String: c.venue, Valid: true},
+			DistanceMeters: c.distance,
+			Surface:        sql.NullString{String: c.surface, Valid: true},
+			ElevationNotes: sql.NullString{String: c.elevation, Valid: true},
+		})
+		if err != nil {
+			return summary, err
+		}
+		courseIDs[i] = course.ID
+		summary.Courses++
+	}
+
+	roster := seedRoster(rng, opts.Athletes)
+	jerseys := rng.Perm(len(roster))
+	for i := range roster {
+		a := &roster[i]
+		a.jersey = int64(jerseys[i] + 1)
+		created, err := tx.CreateAthlete(ctx, db.CreateAthleteParams{
+			Name:           a.name,
+			Gender:         sql.NullString{String: a.gender, Valid: true},
+			GraduationYear: sql.NullInt64{Int64: schoolYear + 12 - a.grade, Valid: true},
+			JerseyNumber:   sql.NullInt64{Int64: a.jersey, Valid: true},
+		})
+		if err != nil {
+			return summary, err
+		}
+		a.id = created.ID
+		summary.Athletes++
+
+		events := []string{"5K"}
+		if a.base < seedMedian(roster, a.gender) {
+			events = append(events, "1600m", "3200m")
+		} else {
+			events = append(events, "800m")
+		}
+		if _, err := setAthleteEvents(context.Background(), tx, a.id, events); err != nil {
+			return summary, err
+		}
+	}
+
+	// Opener on the last Saturday of August.
+	opener := time.Date(season, time.August, 31, 0, 0, 0, 0, time.UTC)
+	for opener.Weekday() != time.Saturday {
+		opener = opener.AddDate(0, 0, -1)
+	}
+	dates := make([]string, len(seedMeets))
+	for i, m := range seedMeets {
+		dates[i] = opener.AddDate(0, 0, 7*m.week).Format("2006-01-02")
+	}
+
+	// A couple of athletes miss a few weeks mid-season.
+	for _, i := range rng.Perm(len(roster))[:min(2, len(roster))] {
+		from := opener.AddDate(0, 0, 7*(2+rng.IntN(4))+rng.IntN(5))
+		roster[i].injuredFrom = from.Format("2006-01-02")
+		roster[i].injuredTo = from.AddDate(0, 0, 14+rng.IntN(10)).Format("2006-01-02")
+		if _, err := tx.CreateAthleteStatus(ctx, db.CreateAthleteStatusParams{
+			AthleteID: roster[i].id,
+			Status:    statusInjured,
+			StartDate: roster[i].injuredFrom,
+			EndDate:   sql.NullString{String: roster[i].injuredTo, Valid: true},
+			Note:      sql.NullString{String: "Shin splints", Valid: true},
+		}); err != nil {
+			return summary, err
+		}
+	}
+
+	var nextMeet int64
+	for i, m := range seedMeets {
+		c := seedCourses[m.course]
+		meet, err := tx.CreateMeet(ctx, db.CreateMeetParams{
+			Name:         m.name,
+			Date:         sql.NullString{String: dates[i], Valid: true},
+			Location:     sql.NullString{String: m.place, Valid: true},
+			CourseID:     sql.NullInt64{Int64: courseIDs[m.course], Valid: true},
+			StartTime:    sql.NullString{String: m.start, Valid: true},
+			BusDeparture: sql.NullString{String: seedBusTime(m.start, m.place), Valid: true},
+			HostSchool:   sql.NullString{String: m.host, Valid: true},
+		})
+		if err != nil {
+			return summary, err
+		}
+		summary.Meets++
+
+		if dates[i] >= today {
+			if nextMeet == 0 {
+				nextMeet = meet.ID
+				n, err := seedEntries(tx, meet.ID, dates[i], roster)
+				if err != nil {
+					return summary, err
+				}
+				summary.Entries += n
+			}
+			continue
+		}
+
+		progress := float64(i) / float64(len(seedMeets)-1)
+		results, splits, err := seedResults(tx, rng, meet.ID, dates[i], c, progress, roster)
+		if err != nil {
+			return summary, err
+		}
+		summary.Results += results
+		summary.Splits += splits
+
+		if _, err := tx.UpdateMeetStatus(ctx, db.UpdateMeetStatusParams{Status: meetFinal, ID: meet.ID}); err != nil {
+			return summary, err
+		}
+	}
+
+	for _, a := range roster {
+		if a.best == 0 {
+			continue
+		}
+		if _, err := tx.UpdateAthlete(ctx, db.UpdateAthleteParams{
+			ID:             a.id,
+			Name:           a.name,
+			PersonalRecord: sql.NullString{String: pace.Format(a.best), Valid: true},
+			Gender:         sql.NullString{String: a.gender, Valid: true},
+			GraduationYear: sql.NullInt64{Int64: schoolYear + 12 - a.grade, Valid: true},
+			JerseyNumber:   sql.NullInt64{Int64: a.jersey, Valid: true},
+		}); err != nil {
+			return summary, err
+		}
+	}
+
+	n, err := seedHistoricalMarks(tx, rng, season)
+	if err != nil {
+		return summary, err
+	}
+	summary.HistoricalMarks = n
+
+	return summary, tx.Commit()
+}
+
+// seedRoster draws unique names and a base fitness for each athlete. Older
+// runners are faster on average, with plenty of overlap.
+func seedRoster(rng *rand.Rand, size int) []seedAthlete {
+	boys := rng.Perm(len(seedBoyNames))
+	girls := rng.Perm(len(seedGirlNames))
+	surnames := rng.Perm(len(seedSurnames))
+
+	roster := make([]seedAthlete, size)
+	for i := range roster {
+		a := &roster[i]
+		a.grade = 9 + int64(i/2%4)
+		surname := seedSurnames[surnames[i%len(surnames)]]
+		if i%2 == 0 {
+			a.gender = "M"
+			a.name = seedBoyNames[boys[i/2%len(boys)]] + " " + surname
+			a.base = 1140 + rng.NormFloat64()*60 // about 19:00
+		} else {
+			a.gender = "F"
+			a.name = seedGirlNames[girls[i/2%len(girls)]] + " " + surname
+			a.base = 1370 + rng.NormFloat64()*75 // about 22:50
+		}
+		a.base -= float64(a.grade-9) * 25
+		if a.gender == "M" {
+			a.base = math.Max(a.base, 930)
+		} else {
+			a.base = math.Max(a.base, 1080)
+		}
+		// Younger runners improve more over a season.
+		a.gain = 0.02 + rng.Float64()*0.03 + float64(12-a.grade)*0.007
+	}
+	return roster
+}
+
+// seedResults runs one meet: a time for every healthy athlete, overall
+// places in a field of other schools, and mile splits.
+func seedResults(q db.Querier, rng *rand.Rand, meetID int64, date string, c seedCourse, progress float64, roster []seedAthlete) (int, int, error) {
+	ctx := context.Background()
+	type run struct {
+		athlete *seedAthlete
+		seconds float64
+	}
+	var runs []run
+	for i := range roster {
+		a := &roster[i]
+		if a.injuredFrom != "" && date >= a.injuredFrom && date <= a.injuredTo {
+			continue
+		}
+		if rng.Float64() < 0.06 { // sick, SAT, family trip
+			continue
+		}
+		t := a.base * c.factor * (1 - a.gain*progress) * (1 + rng.NormFloat64()*0.012)
+		if rng.Float64() < 0.08 {
+			t *= 1.03 + rng.Float64()*0.04 // a bad day
+		}
+		if c.distance != pace.Meters5K {
+			t = pace.Riegel(t, pace.Meters5K, c.distance)
+		}
+		runs = append(runs, run{a, math.Round(t)})
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].seconds < runs[j].seconds })
+
+	var results, splits int
+	place := map[string]int64{"M": 0, "F": 0}
+	for _, r := range runs {
+		a := r.athlete
+		// Runners from other schools finish between ours.
+		place[a.gender] += 1 + int64(rng.IntN(4+results/3))
+
+		result, err := q.CreateResult(ctx, db.CreateResultParams{
+			AthleteID: sql.NullInt64{Int64: a.id, Valid: true},
+			MeetID:    sql.NullInt64{Int64: meetID, Valid: true},
+			Time:      sql.NullString{String: pace.Format(r.seconds), Valid: true},
+			Place:     sql.NullInt64{Int64: place[a.gender], Valid: true},
+		})
+		if err != nil {
+			return results, splits, err
+		}
+		results++
+
+		// Most runners go out fast and fade a little.
+		perMeter := r.seconds / c.distance
+		mile1 := math.Round(perMeter * pace.MetersPerMile * (0.96 + rng.Float64()*0.03))
+		mile2 := math.Round(mile1 + perMeter*pace.MetersPerMile*(1.01+rng.Float64()*0.03))
+		for i, s := range []float64{mile1, mile2} {
+			if _, err := q.CreateSplit(ctx, db.CreateSplitParams{
+				ResultID:       result.ID,
+				SplitIndex:     int64(i + 1),
+				DistanceMeters: math.Round(pace.MetersPerMile * float64(i+1)),
+				Time:           pace.Format(s),
+			}); err != nil {
+				return results, splits, err
+			}
+			splits++
+		}
+
+		if c.distance == pace.Meters5K && (a.best == 0 || r.seconds < a.best) {
+			a.best = r.seconds
+		}
+	}
+	return results, splits, nil
+}
+
+// seedEntries fills varsity races for the next meet from the current PRs.
+func seedEntries(q db.Querier, meetID int64, date string, roster []seedAthlete) (int, error) {
+	ctx := context.Background()
+	deadline, _ := time.Parse("2006-01-02", date)
+	var entries int
+	for _, race := range []struct{ name, gender string }{{"Varsity Boys", "M"}, {"Varsity Girls", "F"}} {
+		created, err := q.CreateRace(ctx, db.CreateRaceParams{
+			MeetID:         meetID,
+			Name:           race.name,
+			Gender:         sql.NullString{String: race.gender, Valid: true},
+			ScorerLimit:    defaultScorerLimit,
+			AlternateLimit: defaultAlternateLimit,
+			EntryDeadline:  sql.NullString{String: deadline.AddDate(0, 0, -3).Format("2006-01-02"), Valid: true},
+		})
+		if err != nil {
+			return entries, err
+		}
+
+		var team []*seedAthlete
+		for i := range roster {
+			if roster[i].gender == race.gender {
+				team = append(team, &roster[i])
+			}
+		}
+		sort.SliceStable(team, func(i, j int) bool { return seedRanking(team[i]) < seedRanking(team[j]) })
+		for i, a := range team {
+			role, position := roleScorer, int64(i+1)
+			if i >= defaultScorerLimit {
+				role, position = roleAlternate, int64(i-defaultScorerLimit+1)
+			}
+			if i >= defaultScorerLimit+defaultAlternateLimit {
+				break
+			}
+			if _, err := q.CreateEntry(ctx, db.CreateEntryParams{
+				RaceID:    created.ID,
+				AthleteID: a.id,
+				Role:      role,
+				Position:  position,
+			}); err != nil {
+				return entries, err
+			}
+			entries++
+		}
+	}
+	return entries, nil
+}
+
+// seedHistoricalMarks adds a few pre-system school records, set close to
+// what the generated roster can run so that some fall and some survive.
+func seedHistoricalMarks(q db.Querier, rng *rand.Rand, season int) (int, error) {
+	ctx := context.Background()
+	marks := []struct {
+		name   string
+		gender string
+		grade  int64
+		base   float64
+		years  int
+	}{
+		{"Darnell Whitaker", "M", 12, 950, 19},
+		{"Travis Odom", "M", 11, 985, 11},
+		{"Kendra Lyle", "F", 12, 1120, 15},
+		{"Beth Ann Cole", "F", 10, 1180, 7},
+	}
+	for _, m := range marks {
+		seconds := math.Round(m.base + rng.NormFloat64()*10)
+		if _, err := q.CreateHistoricalMark(ctx, db.CreateHistoricalMarkParams{
+			AthleteName:    m.name,
+			Gender:         sql.NullString{String: m.gender, Valid: true},
+			Grade:          sql.NullInt64{Int64: m.grade, Valid: true},
+			DistanceMeters: pace.Meters5K,
+			Time:           pace.Format(seconds),
+			Date:           sql.NullString{String: fmt.Sprintf("%d-11-0%d", season-m.years, 1+rng.IntN(8)), Valid: true},
+			MeetName:       sql.NullString{String: "State Championship", Valid: true},
+		}); err != nil {
+			return 0, err
+		}
+	}
+	return len(marks), nil
+}
+
+// --- Helpers ---
+
+// seedRanking orders athletes for entries: PR when they have one, base
+// fitness otherwise.
+func seedRanking(a *seedAthlete) float64 {
+	if a.best > 0 {
+		return a.best
+	}
+	return a.base
+}
+
+func seedMedian(roster []seedAthlete, gender string) float64 {
+	var xs []float64
+	for _, a := range roster {
+		if a.gender == gender {
+			xs = append(xs, a.base)
+		}
+	}
+	sort.Float64s(xs)
+	return xs[len(xs)/2]
+}
+
+// seedBusTime leaves 45 minutes before the start for home meets and two
+// hours for away ones.
+func seedBusTime(start, place string) string {
+	t, _ := time.Parse("15:04", start)
+	lead := 2 * time.Hour
+	if place == "Gray, GA" {
+		lead = 45 * time.Minute
+	}
+	return t.Add(-lead).Format("15:04")
+}
@@ -0,0 +1,1582 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"jones-county-xc/backend/db"
+	"jones-county-xc/backend/pace"
+)
+
+// testPasswordHash is "password123" at bcrypt's lowest cost, so fixture
+// accounts are cheap to create and log in with.
+var testPasswordHash string
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	log.SetOutput(io.Discard)
+	initAuth()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		panic(err)
+	}
+	testPasswordHash = string(hash)
+
+	backups, err := os.MkdirTemp("", "xc-backups-")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("BACKUP_DIR", backups)
+
+	code := m.Run()
+	os.RemoveAll(backups)
+	os.Exit(code)
+}
+
+// newTestServer returns a server backed by a fresh in-memory database.
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	conn, err := openDatabase(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each connection to ":memory:" is a separate empty database, so the
+	// pool must keep reusing the one that was migrated.
+	conn.SetMaxOpenConns(1)
+	if err := runMigrations(conn); err != nil {
+		t.Fatal(err)
+	}
+	store := newSQLStore(conn)
+	t.Cleanup(func() { store.Close() })
+	return NewServer(store)
+}
+
+// seedToday is the date generated seasons are built around: mid-season,
+// so some meets are final and the rest still to come.
+var seedToday = time.Date(2025, time.October, 15, 0, 0, 0, 0, time.UTC)
+
+// newSeededServer is a server holding a generated season (see seed.go). The
+// same seed always gives the same data.
+func newSeededServer(t *testing.T, seed uint64) *Server {
+	t.Helper()
+	s := newTestServer(t)
+	if _, err := seedDatabase(s.store, seedOptions{Seed: seed, Athletes: 24, Today: seedToday}); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// fixture is a server with a small team already entered:
+//
+//   - course 1; athletes 1 (boy, grade 11), 2 (girl, grade 10, injured)
+//     and 3 (boy, grade 9), entered by graduation year
+//   - meet 1, two weeks ago and in progress, with results 1 and 2, a split
+//     on result 1 and bib 101 for athlete 1
+//   - meet 2, two weeks out, with race 1 "Varsity Boys" entering athlete 1
+//   - historical mark 1, plan 1 and workout 1 for athlete 1, session 1
+//     with athlete 1 present
+//   - an athlete account linked to athlete 1 and a parent account linked to
+//     athlete 2; the athlete account has notification settings
+//   - subscription 2 for team@example.com, webhook 1 and an unused parent
+//     invite for athlete 3
+type fixture struct {
+	t      *testing.T
+	server *Server
+	router http.Handler
+	tokens map[string]string
+	vars   *strings.Replacer
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	s := newTestServer(t)
+	f := &fixture{
+		t:      t,
+		server: s,
+		router: NewRouter(s),
+		tokens: map[string]string{"admin": generateToken(adminUsername), "bad": "not-a-token"},
+	}
+
+	today := time.Now()
+	past := today.AddDate(0, 0, -14).Format("2006-01-02")
+	future := today.AddDate(0, 0, 14).Format("2006-01-02")
+	date := today.Format("2006-01-02")
+	// classOf is the graduation year of an athlete now in the given grade.
+	classOf := func(grade int64) string {
+		return fmt.Sprint(schoolYearEnd(today) + 12 - grade)
+	}
+
+	f.mustDo("POST", "/api/courses", `{"name":"Jones County Course","distanceMeters":5000}`)
+	sam := f.mustDo("POST", "/api/athletes", `{"name":"Sam Runner","gender":"M","graduationYear":`+classOf(11)+`,"events":["5K"]}`)
+	if sam["grade"] != float64(11) {
+		t.Fatalf("athlete 1 grade = %v, want 11", sam["grade"])
+	}
+	f.mustDo("POST", "/api/athletes", `{"name":"Ava Strider","gender":"F","graduationYear":`+classOf(10)+`}`)
+	f.mustDo("POST", "/api/athletes", `{"name":"Ben Pacer","gender":"M","graduationYear":`+classOf(9)+`}`)
+	f.mustDo("POST", "/api/athletes/2/statuses", `{"status":"injured","startDate":"`+date+`"}`)
+	f.mustDo("POST", "/api/meets", `{"name":"Early Invitational","date":"`+past+`","courseId":1}`)
+	f.mustDo("POST", "/api/meets", `{"name":"Region Championship","date":"`+future+`","courseId":1}`)
+	f.mustDo("POST", "/api/meets/1/status", `{"status":"in-progress"}`)
+	f.mustDo("POST", "/api/results", `{"athleteId":1,"meetId":1,"time":"17:30","place":1}`)
+	f.mustDo("POST", "/api/results", `{"athleteId":3,"meetId":1,"time":"18:10","place":2}`)
+	f.mustDo("PUT", "/api/results/1/splits", `{"splits":[{"distanceMeters":1609,"time":"5:30"}]}`)
+	f.mustDo("POST", "/api/meets/1/bibs", `{"athleteId":1,"bib":101}`)
+	f.mustDo("POST", "/api/meets/2/races", `{"name":"Varsity Boys","gender":"M"}`)
+	f.mustDo("PUT", "/api/races/1/entries", `{"scorers":[1]}`)
+	f.mustDo("POST", "/api/records/historical", `{"athleteName":"Old Timer","gender":"M","distanceMeters":5000,"time":"15:59"}`)
+	f.mustDo("POST", "/api/plans", `{"date":"`+date+`","type":"easy","title":"Shakeout","distanceMiles":4,"athleteIds":[1]}`)
+	f.mustDo("POST", "/api/athletes/1/workouts", `{"date":"`+date+`","type":"easy","title":"Recovery run","distanceMiles":5}`)
+	f.mustDo("POST", "/api/sessions", `{"date":"`+date+`","type":"practice"}`)
+	f.mustDo("PUT", "/api/sessions/1/attendance", `{"records":[{"athleteId":1,"status":"present"}]}`)
+	f.mustDo("POST", "/api/webhooks", `{"url":"http://127.0.0.1:9/hook","events":["result.created"]}`)
+	invite := f.mustDo("POST", "/api/athletes/3/invites", `{"role":"parent"}`)
+
+	f.tokens["athlete"] = f.addAccount(s, "sam", roleAthlete, 1)
+	f.tokens["parent"] = f.addAccount(s, "ava-parent", roleParent, 2)
+	f.mustDo("PUT", "/api/me/notifications", `{"email":"sam@example.com"}`, "athlete")
+	f.mustDo("POST", "/api/subscriptions", `{"email":"team@example.com"}`)
+
+	sub, err := s.store.GetAllSubscriptions(context.Background())
+	if err != nil || len(sub) == 0 {
+		t.Fatalf("loading subscriptions: %v", err)
+	}
+	f.vars = strings.NewReplacer(
+		"{invite}", invite["code"].(string),
+		"{unsubscribe}", sub[len(sub)-1].UnsubscribeToken,
+		"{today}", date,
+		"{class9}", classOf(9),
+		"{class11}", classOf(11),
+	)
+	return f
+}
+
+// addAccount creates an account linked to an athlete and returns its token.
+func (f *fixture) addAccount(s *Server, username, role string, athleteID int64) string {
+	f.t.Helper()
+	ctx := context.Background()
+	account, err := s.store.CreateAccount(ctx, db.CreateAccountParams{
+		Username:     username,
+		PasswordHash: testPasswordHash,
+		Role:         role,
+	})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	if err := s.store.AddAccountAthlete(ctx, db.AddAccountAthleteParams{AccountID: account.ID, AthleteID: athleteID}); err != nil {
+		f.t.Fatal(err)
+	}
+	return accountToken(account)
+}
+
+// do sends a request as one of the fixture's tokens ("" for none).
+func (f *fixture) do(method, path, body, as string) *httptest.ResponseRecorder {
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, r)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if as != "" {
+		req.Header.Set("Authorization", "Bearer "+f.tokens[as])
+	}
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	return w
+}
+
+// mustDo sends a request as the admin, or as the given token, and fails the
+// test unless it succeeds. It returns the decoded JSON object.
+func (f *fixture) mustDo(method, path, body string, as ...string) map[string]any {
+	f.t.Helper()
+	token := "admin"
+	if len(as) > 0 {
+		token = as[0]
+	}
+	w := f.do(method, path, body, token)
+	if w.Code >= 300 {
+		f.t.Fatalf("%s %s = %d: %s", method, path, w.Code, w.Body)
+	}
+	var out map[string]any
+	json.Unmarshal(w.Body.Bytes(), &out)
+	return out
+}
+
+// routeTests has a case for every route in NewRouter; TestRouteTestsCover
+// checks that. Each case runs against its own fixture. as is the token to
+// send: "admin", "athlete", "parent", "bad" or "" for none. {invite},
+// {unsubscribe}, {today} and {classN} (the graduation year of grade N) in
+// the path or body are filled in from the fixture. Cases named in
+// routeChecks also have their response and resulting state checked.
+var routeTests = []struct {
+	name   string
+	method string
+	path   string
+	body   string
+	as     string
+	want   int
+}{
+	{"health", "GET", "/health", "", "", 200},
+	{"api health", "GET", "/api/health", "", "", 200},
+
+	// Auth
+	{"admin login", "POST", "/api/auth/login", `{"username":"admin","password":"greyhounds2025"}`, "", 200},
+	{"admin wrong password", "POST", "/api/auth/login", `{"username":"admin","password":"nope"}`, "", 401},
+	{"account login", "POST", "/api/auth/login", `{"username":"sam","password":"password123"}`, "", 200},
+	{"account wrong password", "POST", "/api/auth/login", `{"username":"sam","password":"password124"}`, "", 401},
+	{"unknown user", "POST", "/api/auth/login", `{"username":"nobody","password":"password123"}`, "", 401},
+	{"login bad json", "POST", "/api/auth/login", `{`, "", 400},
+	{"register", "POST", "/api/accounts", `{"code":"{invite}","username":"ben-parent","password":"password123"}`, "", 201},
+	{"register unknown code", "POST", "/api/accounts", `{"code":"NOPE","username":"ben-parent","password":"password123"}`, "", 400},
+	{"register short password", "POST", "/api/accounts", `{"code":"{invite}","username":"ben-parent","password":"short"}`, "", 400},
+	{"register bad username", "POST", "/api/accounts", `{"code":"{invite}","username":"a b","password":"password123"}`, "", 400},
+	{"register taken username", "POST", "/api/accounts", `{"code":"{invite}","username":"sam","password":"password123"}`, "", 409},
+	{"unsubscribe page", "GET", "/api/unsubscribe?token={unsubscribe}", "", "", 200},
+	{"unsubscribe page no token", "GET", "/api/unsubscribe", "", "", 400},
+	{"unsubscribe page unknown token", "GET", "/api/unsubscribe?token=nope", "", "", 404},
+	{"unsubscribe", "POST", "/api/unsubscribe?token={unsubscribe}", "", "", 200},
+	{"unsubscribe no token", "POST", "/api/unsubscribe", "", "", 400},
+	{"unsubscribe unknown token", "POST", "/api/unsubscribe?token=nope", "", "", 404},
+
+	// Athletes
+	{"list athletes", "GET", "/api/athletes?gender=M", "", "", 200},
+	{"list athletes bad grade", "GET", "/api/athletes?grade=x", "", "", 400},
+	{"list athletes bad status", "GET", "/api/athletes?status=retired", "", "", 400},
+	{"get athlete", "GET", "/api/athletes/1", "", "", 200},
+	{"get athlete bad id", "GET", "/api/athletes/abc", "", "", 400},
+	{"get athlete missing", "GET", "/api/athletes/999", "", "", 404},
+	{"athlete results", "GET", "/api/athletes/1/results", "", "", 200},
+	{"athlete results bad id", "GET", "/api/athletes/abc/results", "", "", 400},
+	{"athlete analytics", "GET", "/api/athletes/1/analytics", "", "", 200},
+	{"athlete analytics bad id", "GET", "/api/athletes/abc/analytics", "", "", 400},
+	{"athlete analytics missing", "GET", "/api/athletes/999/analytics", "", "", 404},
+	{"athlete statuses", "GET", "/api/athletes/2/statuses", "", "", 200},
+	{"athlete statuses bad id", "GET", "/api/athletes/abc/statuses", "", "", 400},
+	{"alumni", "GET", "/api/alumni", "", "", 200},
+	{"compare", "GET", "/api/compare?athletes=1,3", "", "", 200},
+	{"compare one athlete", "GET", "/api/compare?athletes=1", "", "", 400},
+	{"compare bad id", "GET", "/api/compare?athletes=1,x", "", "", 400},
+	{"compare missing athlete", "GET", "/api/compare?athletes=1,999", "", "", 404},
+	{"create athlete", "POST", "/api/athletes", `{"name":"New Runner","gender":"F","graduationYear":{class9}}`, "admin", 201},
+	{"create athlete no name", "POST", "/api/athletes", `{"gender":"F"}`, "admin", 400},
+	{"create athlete bad gender", "POST", "/api/athletes", `{"name":"New Runner","gender":"X"}`, "admin", 400},
+	{"create athlete bad grade", "POST", "/api/athletes", `{"name":"New Runner","gender":"F","grade":13}`, "admin", 400},
+	{"create athlete bad json", "POST", "/api/athletes", `{`, "admin", 400},
+	{"create athlete no token", "POST", "/api/athletes", `{"name":"New Runner","gender":"F"}`, "", 401},
+	{"create athlete bad token", "POST", "/api/athletes", `{"name":"New Runner","gender":"F"}`, "bad", 401},
+	{"create athlete as account", "POST", "/api/athletes", `{"name":"New Runner","gender":"F"}`, "athlete", 401},
+	{"update athlete", "PUT", "/api/athletes/1", `{"name":"Sam Runner","gender":"M","grade":12}`, "admin", 200},
+	{"update athlete name only", "PUT", "/api/athletes/1", `{"name":"Samuel Runner","personal_record":"17:20"}`, "admin", 200},
+	{"update athlete bad id", "PUT", "/api/athletes/abc", `{"name":"Sam Runner","gender":"M"}`, "admin", 400},
+	{"update athlete invalid", "PUT", "/api/athletes/1", `{"name":"Sam Runner","gender":"M","grade":0}`, "admin", 400},
+	{"update athlete missing", "PUT", "/api/athletes/999", `{"name":"Sam Runner","gender":"M"}`, "admin", 404},
+	{"update athlete no token", "PUT", "/api/athletes/1", `{"name":"Sam Runner","gender":"M"}`, "", 401},
+	{"delete athlete", "DELETE", "/api/athletes/2", "", "admin", 200},
+	{"delete athlete with results", "DELETE", "/api/athletes/3", "", "admin", 409},
+	{"delete athlete missing", "DELETE", "/api/athletes/999", "", "admin", 404},
+	{"delete athlete bad id", "DELETE", "/api/athletes/abc", "", "admin", 400},
+	{"delete athlete no token", "DELETE", "/api/athletes/3", "", "", 401},
+	{"create status", "POST", "/api/athletes/1/statuses", `{"status":"inactive","startDate":"{today}"}`, "admin", 201},
+	{"create status invalid", "POST", "/api/athletes/1/statuses", `{"status":"tired","startDate":"{today}"}`, "admin", 400},
+	{"create status missing athlete", "POST", "/api/athletes/999/statuses", `{"status":"inactive","startDate":"{today}"}`, "admin", 404},
+	{"create status no token", "POST", "/api/athletes/1/statuses", `{"status":"inactive","startDate":"{today}"}`, "", 401},
+	{"update status", "PUT", "/api/athletes/2/statuses/1", `{"status":"injured","startDate":"{today}","note":"ankle"}`, "admin", 200},
+	{"update status bad id", "PUT", "/api/athletes/2/statuses/abc", `{"status":"injured","startDate":"{today}"}`, "admin", 400},
+	{"update status missing", "PUT", "/api/athletes/2/statuses/999", `{"status":"injured","startDate":"{today}"}`, "admin", 404},
+	{"update status no token", "PUT", "/api/athletes/2/statuses/1", `{"status":"injured","startDate":"{today}"}`, "", 401},
+	{"delete status", "DELETE", "/api/athletes/2/statuses/1", "", "admin", 200},
+	{"delete status missing", "DELETE", "/api/athletes/1/statuses/1", "", "admin", 404},
+	{"delete status bad id", "DELETE", "/api/athletes/2/statuses/abc", "", "admin", 400},
+	{"delete status no token", "DELETE", "/api/athletes/2/statuses/1", "", "", 401},
+
+	// Meets
+	{"list meets", "GET", "/api/meets?upcoming=true", "", "", 200},
+	{"list meets bad filter", "GET", "/api/meets?upcoming=maybe", "", "", 400},
+	{"next meet", "GET", "/api/meets/next", "", "", 200},
+	{"get meet", "GET", "/api/meets/1", "", "", 200},
+	{"get meet bad id", "GET", "/api/meets/abc", "", "", 400},
+	{"get meet missing", "GET", "/api/meets/999", "", "", 404},
+	{"meet results", "GET", "/api/meets/1/results", "", "", 200},
+	{"meet results bad id", "GET", "/api/meets/abc/results", "", "", 400},
+	{"create meet", "POST", "/api/meets", `{"name":"Dual Meet","date":"{today}"}`, "admin", 201},
+	{"create meet bad date", "POST", "/api/meets", `{"name":"Dual Meet","date":"someday"}`, "admin", 400},
+	{"create meet no token", "POST", "/api/meets", `{"name":"Dual Meet"}`, "", 401},
+	{"update meet", "PUT", "/api/meets/2", `{"name":"Region Championship","date":"{today}","courseId":1}`, "admin", 200},
+	{"update meet name only", "PUT", "/api/meets/2", `{"name":"Region Finals"}`, "admin", 200},
+	{"update meet bad id", "PUT", "/api/meets/abc", `{"name":"Region Championship"}`, "admin", 400},
+	{"update meet missing", "PUT", "/api/meets/999", `{"name":"Region Championship"}`, "admin", 404},
+	{"update meet no token", "PUT", "/api/meets/2", `{"name":"Region Championship"}`, "", 401},
+	{"delete meet", "DELETE", "/api/meets/2", "", "admin", 200},
+	{"delete meet with results", "DELETE", "/api/meets/1", "", "admin", 409},
+	{"delete meet missing", "DELETE", "/api/meets/999", "", "admin", 404},
+	{"delete meet bad id", "DELETE", "/api/meets/abc", "", "admin", 400},
+	{"delete meet no token", "DELETE", "/api/meets/2", "", "", 401},
+	{"meet status", "POST", "/api/meets/2/status", `{"status":"postponed"}`, "admin", 200},
+	{"meet status unknown", "POST", "/api/meets/2/status", `{"status":"done"}`, "admin", 400},
+	{"meet status final", "POST", "/api/meets/1/status", `{"status":"final"}`, "admin", 200},
+	{"meet status bad transition", "POST", "/api/meets/1/status", `{"status":"scheduled"}`, "admin", 409},
+	{"meet status missing", "POST", "/api/meets/999/status", `{"status":"postponed"}`, "admin", 404},
+	{"meet status no token", "POST", "/api/meets/2/status", `{"status":"postponed"}`, "", 401},
+
+	// Bibs and timing
+	{"meet bibs", "GET", "/api/meets/1/bibs", "", "", 200},
+	{"meet bibs bad id", "GET", "/api/meets/abc/bibs", "", "", 400},
+	{"assign bib", "POST", "/api/meets/1/bibs", `{"athleteId":3,"bib":103}`, "admin", 201},
+	{"reassign bib", "POST", "/api/meets/1/bibs", `{"athleteId":1,"bib":111}`, "admin", 200},
+	{"assign own bib again", "POST", "/api/meets/1/bibs", `{"athleteId":1,"bib":101}`, "admin", 200},
+	{"assign bib taken", "POST", "/api/meets/1/bibs", `{"athleteId":3,"bib":101}`, "admin", 409},
+	{"assign bib incomplete", "POST", "/api/meets/1/bibs", `{"athleteId":3}`, "admin", 400},
+	{"assign bib no token", "POST", "/api/meets/1/bibs", `{"athleteId":3,"bib":103}`, "", 401},
+	{"remove bib", "DELETE", "/api/meets/1/bibs/1", "", "admin", 200},
+	{"remove bib missing", "DELETE", "/api/meets/1/bibs/2", "", "admin", 404},
+	{"remove bib bad id", "DELETE", "/api/meets/1/bibs/abc", "", "admin", 400},
+	{"remove bib no token", "DELETE", "/api/meets/1/bibs/1", "", "", 401},
+	{"timing preview", "POST", "/api/meets/1/timing/preview", `{"times":["17:00"],"bibs":[101]}`, "admin", 200},
+	{"timing preview bad id", "POST", "/api/meets/abc/timing/preview", `{"times":[]}`, "admin", 400},
+	{"timing preview no token", "POST", "/api/meets/1/timing/preview", `{"times":[]}`, "", 401},
+	{"timing commit", "POST", "/api/meets/1/timing/commit", `{"times":["17:00"],"bibs":[101],"replace":true}`, "admin", 201},
+	{"timing commit over results", "POST", "/api/meets/1/timing/commit", `{"times":["17:00"],"bibs":[101]}`, "admin", 409},
+	{"timing commit empty", "POST", "/api/meets/1/timing/commit", `{"times":[],"bibs":[],"replace":true}`, "admin", 400},
+	{"timing commit with problems", "POST", "/api/meets/1/timing/commit", `{"times":["17:00","17:05"],"bibs":[101],"replace":true}`, "admin", 422},
+	{"timing commit not running", "POST", "/api/meets/2/timing/commit", `{"times":["17:00"],"bibs":[101]}`, "admin", 409},
+	{"timing commit no token", "POST", "/api/meets/1/timing/commit", `{"times":[]}`, "", 401},
+
+	// Races and entries
+	{"meet races", "GET", "/api/meets/2/races", "", "", 200},
+	{"meet races bad id", "GET", "/api/meets/abc/races", "", "", 400},
+	{"export entries", "GET", "/api/meets/2/entries/export", "", "", 200},
+	{"export entries bad id", "GET", "/api/meets/abc/entries/export", "", "", 400},
+	{"export entries missing", "GET", "/api/meets/999/entries/export", "", "", 404},
+	{"create race", "POST", "/api/meets/2/races", `{"name":"Varsity Girls","gender":"F"}`, "admin", 201},
+	{"create race duplicate", "POST", "/api/meets/2/races", `{"name":"Varsity Boys","gender":"M"}`, "admin", 409},
+	{"create race missing meet", "POST", "/api/meets/999/races", `{"name":"Varsity Girls"}`, "admin", 404},
+	{"create race no token", "POST", "/api/meets/2/races", `{"name":"Varsity Girls"}`, "", 401},
+	{"update race", "PUT", "/api/races/1", `{"name":"Boys 5K","gender":"M"}`, "admin", 200},
+	{"update race missing", "PUT", "/api/races/999", `{"name":"Boys 5K"}`, "admin", 404},
+	{"update race no token", "PUT", "/api/races/1", `{"name":"Boys 5K"}`, "", 401},
+	{"delete race", "DELETE", "/api/races/1", "", "admin", 200},
+	{"delete race missing", "DELETE", "/api/races/999", "", "admin", 404},
+	{"delete race bad id", "DELETE", "/api/races/abc", "", "admin", 400},
+	{"delete race no token", "DELETE", "/api/races/1", "", "", 401},
+	{"set entries", "PUT", "/api/races/1/entries", `{"scorers":[1],"alternates":[3]}`, "admin", 200},
+	{"set entries wrong gender", "PUT", "/api/races/1/entries", `{"scorers":[2]}`, "admin", 400},
+	{"set entries unknown athlete", "PUT", "/api/races/1/entries", `{"scorers":[999]}`, "admin", 400},
+	{"set entries twice", "PUT", "/api/races/1/entries", `{"scorers":[1],"alternates":[1]}`, "admin", 400},
+	{"set entries missing race", "PUT", "/api/races/999/entries", `{"scorers":[1]}`, "admin", 404},
+	{"set entries no token", "PUT", "/api/races/1/entries", `{"scorers":[1]}`, "", 401},
+	{"result sheet", "GET", "/api/meets/2/results/sheet", "", "admin", 200},
+	{"result sheet csv", "GET", "/api/meets/2/results/sheet?format=csv", "", "admin", 200},
+	{"result sheet bad id", "GET", "/api/meets/abc/results/sheet", "", "admin", 400},
+	{"result sheet no token", "GET", "/api/meets/2/results/sheet", "", "", 401},
+
+	// Results and splits
+	{"list results", "GET", "/api/results", "", "", 200},
+	{"get result", "GET", "/api/results/1", "", "", 200},
+	{"get result bad id", "GET", "/api/results/abc", "", "", 400},
+	{"get result missing", "GET", "/api/results/999", "", "", 404},
+	{"result splits", "GET", "/api/results/1/splits", "", "", 200},
+	{"result splits missing result", "GET", "/api/results/999/splits", "", "", 404},
+	{"result splits bad id", "GET", "/api/results/abc/splits", "", "", 400},
+	{"create result", "POST", "/api/results", `{"athleteId":2,"meetId":1,"time":"19:45","place":3}`, "admin", 201},
+	{"create result bad time", "POST", "/api/results", `{"athleteId":2,"meetId":1,"time":"garbage","place":3}`, "admin", 400},
+	{"create result meet not running", "POST", "/api/results", `{"athleteId":2,"meetId":2,"time":"19:45","place":1}`, "admin", 409},
+	{"create result missing meet", "POST", "/api/results", `{"athleteId":2,"meetId":999,"time":"19:45","place":1}`, "admin", 404},
+	{"create result bad json", "POST", "/api/results", `{"athleteId":"two"}`, "admin", 400},
+	{"create result no token", "POST", "/api/results", `{"athleteId":2,"meetId":1,"time":"19:45","place":3}`, "", 401},
+	{"update result", "PUT", "/api/results/1", `{"athleteId":1,"meetId":1,"time":"17:25","place":1}`, "admin", 200},
+	{"update result bad time", "PUT", "/api/results/1", `{"athleteId":1,"meetId":1,"time":"1e300:00","place":1}`, "admin", 400},
+	{"update result bad id", "PUT", "/api/results/abc", `{"athleteId":1,"meetId":1,"time":"17:25","place":1}`, "admin", 400},
+	{"update result missing", "PUT", "/api/results/999", `{"athleteId":1,"meetId":1,"time":"17:25","place":1}`, "admin", 404},
+	{"update result no token", "PUT", "/api/results/1", `{"athleteId":1,"meetId":1,"time":"17:25","place":1}`, "", 401},
+	{"delete result", "DELETE", "/api/results/2", "", "admin", 200},
+	{"delete result bad id", "DELETE", "/api/results/abc", "", "admin", 400},
+	{"delete result missing", "DELETE", "/api/results/999", "", "admin", 404},
+	{"delete result no token", "DELETE", "/api/results/2", "", "", 401},
+	{"set splits", "PUT", "/api/results/1/splits", `{"splits":[{"distanceMeters":1609,"time":"5:25"},{"distanceMeters":3218,"time":"11:00"}]}`, "admin", 200},
+	{"set splits invalid", "PUT", "/api/results/1/splits", `{"splits":[{"distanceMeters":1609,"time":"fast"}]}`, "admin", 400},
+	{"set splits missing result", "PUT", "/api/results/999/splits", `{"splits":[]}`, "admin", 404},
+	{"set splits no token", "PUT", "/api/results/1/splits", `{"splits":[]}`, "", 401},
+	{"import results", "POST", "/api/meets/1/results/import", "athleteId,place,time,split_1609\n2,3,19:45,6:10\n", "admin", 201},
+	{"import results unknown athlete", "POST", "/api/meets/1/results/import", "athleteId,place,time\n2,3,19:45\n99,4,20:10\n", "admin", 400},
+	{"import results bad header", "POST", "/api/meets/1/results/import", "name,time\nAva,19:45\n", "admin", 400},
+	{"import results meet not running", "POST", "/api/meets/2/results/import", "athleteId,place,time\n2,1,19:45\n", "admin", 409},
+	{"import results no token", "POST", "/api/meets/1/results/import", "athleteId,place,time\n", "", 401},
+
+	// Leaderboards and records
+	{"leaderboards", "GET", "/api/leaderboards?gender=M", "", "", 200},
+	{"leaderboards bad limit", "GET", "/api/leaderboards?limit=0", "", "", 400},
+	{"leaderboards bad course", "GET", "/api/leaderboards?courseId=x", "", "", 400},
+	{"records", "GET", "/api/records", "", "", 200},
+	{"records bad distance", "GET", "/api/records?distance=x", "", "", 400},
+	{"record breaks", "GET", "/api/records/breaks", "", "", 200},
+	{"historical marks", "GET", "/api/records/historical", "", "", 200},
+	{"create historical mark", "POST", "/api/records/historical", `{"athleteName":"Fast Alum","gender":"F","distanceMeters":5000,"time":"18:01"}`, "admin", 201},
+	{"create historical mark bad time", "POST", "/api/records/historical", `{"athleteName":"Fast Alum","gender":"F","distanceMeters":5000,"time":"soon"}`, "admin", 400},
+	{"create historical mark no token", "POST", "/api/records/historical", `{}`, "", 401},
+	{"update historical mark", "PUT", "/api/records/historical/1", `{"athleteName":"Old Timer","gender":"M","distanceMeters":5000,"time":"15:58"}`, "admin", 200},
+	{"update historical mark missing", "PUT", "/api/records/historical/999", `{"athleteName":"Old Timer","gender":"M","distanceMeters":5000,"time":"15:58"}`, "admin", 404},
+	{"update historical mark bad id", "PUT", "/api/records/historical/abc", `{}`, "admin", 400},
+	{"update historical mark no token", "PUT", "/api/records/historical/1", `{}`, "", 401},
+	{"delete historical mark", "DELETE", "/api/records/historical/1", "", "admin", 200},
+	{"delete historical mark missing", "DELETE", "/api/records/historical/999", "", "admin", 404},
+	{"delete historical mark bad id", "DELETE", "/api/records/historical/abc", "", "admin", 400},
+	{"delete historical mark no token", "DELETE", "/api/records/historical/1", "", "", 401},
+
+	// Courses and events
+	{"list courses", "GET", "/api/courses", "", "", 200},
+	{"get course", "GET", "/api/courses/1", "", "", 200},
+	{"get course bad id", "GET", "/api/courses/abc", "", "", 400},
+	{"get course missing", "GET", "/api/courses/999", "", "", 404},
+	{"course bests", "GET", "/api/courses/1/bests", "", "", 200},
+	{"course bests bad id", "GET", "/api/courses/abc/bests", "", "", 400},
+	{"course bests missing", "GET", "/api/courses/999/bests", "", "", 404},
+	{"create course", "POST", "/api/courses", `{"name":"River Park","distanceMeters":4828}`, "admin", 201},
+	{"create course no name", "POST", "/api/courses", `{"distanceMeters":4828}`, "admin", 400},
+	{"create course bad distance", "POST", "/api/courses", `{"name":"River Park","distanceMeters":-1}`, "admin", 400},
+	{"create course no token", "POST", "/api/courses", `{"name":"River Park","distanceMeters":4828}`, "", 401},
+	{"update course", "PUT", "/api/courses/1", `{"name":"Jones County Course","distanceMeters":5000,"surface":"grass"}`, "admin", 200},
+	{"update course bad id", "PUT", "/api/courses/abc", `{"name":"Jones County Course","distanceMeters":5000}`, "admin", 400},
+	{"update course bad distance", "PUT", "/api/courses/1", `{"name":"Jones County Course","distanceMeters":0}`, "admin", 400},
+	{"update course blank name", "PUT", "/api/courses/1", `{"name":"","distanceMeters":5000}`, "admin", 400},
+	{"update course missing", "PUT", "/api/courses/999", `{"name":"Nowhere","distanceMeters":5000}`, "admin", 404},
+	{"update course no token", "PUT", "/api/courses/1", `{"name":"Jones County Course","distanceMeters":5000}`, "", 401},
+	{"delete course", "DELETE", "/api/courses/1", "", "admin", 200},
+	{"delete course missing", "DELETE", "/api/courses/999", "", "admin", 404},
+	{"delete course bad id", "DELETE", "/api/courses/abc", "", "admin", 400},
+	{"delete course no token", "DELETE", "/api/courses/1", "", "", 401},
+	{"events", "GET", "/api/events", "", "", 200},
+
+	// Accounts
+	{"me", "GET", "/api/me", "", "athlete", 200},
+	{"me as admin", "GET", "/api/me", "", "admin", 404},
+	{"me no token", "GET", "/api/me", "", "", 401},
+	{"me bad token", "GET", "/api/me", "", "bad", 401},
+	{"link athlete", "POST", "/api/me/athletes", `{"code":"{invite}"}`, "parent", 200},
+	{"link athlete bad code", "POST", "/api/me/athletes", `{"code":"NOPE"}`, "parent", 400},
+	{"link athlete as athlete", "POST", "/api/me/athletes", `{"code":"{invite}"}`, "athlete", 403},
+	{"link athlete no token", "POST", "/api/me/athletes", `{"code":"{invite}"}`, "", 401},
+	{"my notifications", "GET", "/api/me/notifications", "", "athlete", 200},
+	{"my notifications unset", "GET", "/api/me/notifications", "", "parent", 404},
+	{"my notifications as admin", "GET", "/api/me/notifications", "", "admin", 400},
+	{"update my notifications", "PUT", "/api/me/notifications", `{"email":"parent@example.com","results":"linked"}`, "parent", 200},
+	{"update my notifications bad email", "PUT", "/api/me/notifications", `{"email":"parent"}`, "parent", 400},
+	{"update my notifications taken", "PUT", "/api/me/notifications", `{"email":"team@example.com"}`, "parent", 409},
+	{"update my notifications no token", "PUT", "/api/me/notifications", `{"email":"parent@example.com"}`, "", 401},
+	{"delete my notifications", "DELETE", "/api/me/notifications", "", "athlete", 200},
+	{"delete my notifications unset", "DELETE", "/api/me/notifications", "", "parent", 404},
+	{"create invite", "POST", "/api/athletes/1/invites", `{"role":"athlete","expiresInDays":7}`, "admin", 201},
+	{"create invite bad role", "POST", "/api/athletes/1/invites", `{"role":"coach"}`, "admin", 400},
+	{"create invite bad expiry", "POST", "/api/athletes/1/invites", `{"role":"parent","expiresInDays":365}`, "admin", 400},
+	{"create invite missing athlete", "POST", "/api/athletes/999/invites", `{"role":"parent"}`, "admin", 404},
+	{"create invite no token", "POST", "/api/athletes/1/invites", `{"role":"parent"}`, "", 401},
+	{"list invites", "GET", "/api/invites", "", "admin", 200},
+	{"list invites no token", "GET", "/api/invites", "", "", 401},
+	{"delete invite", "DELETE", "/api/invites/1", "", "admin", 200},
+	{"delete invite missing", "DELETE", "/api/invites/999", "", "admin", 404},
+	{"delete invite no token", "DELETE", "/api/invites/1", "", "", 401},
+	{"list accounts", "GET", "/api/accounts", "", "admin", 200},
+	{"list accounts as account", "GET", "/api/accounts", "", "parent", 401},
+	{"delete account", "DELETE", "/api/accounts/2", "", "admin", 200},
+	{"delete account bad id", "DELETE", "/api/accounts/abc", "", "admin", 400},
+	{"delete account not found", "DELETE", "/api/accounts/999", "", "admin", 404},
+	{"delete account no token", "DELETE", "/api/accounts/2", "", "", 401},
+
+	// Athlete-owned data
+	{"workouts", "GET", "/api/athletes/1/workouts", "", "athlete", 200},
+	{"workouts as admin", "GET", "/api/athletes/1/workouts", "", "admin", 200},
+	{"workouts bad range", "GET", "/api/athletes/1/workouts?from=yesterday", "", "athlete", 400},
+	{"workouts bad id", "GET", "/api/athletes/abc/workouts", "", "athlete", 400},
+	{"workouts other athlete", "GET", "/api/athletes/1/workouts", "", "parent", 403},
+	{"workouts no token", "GET", "/api/athletes/1/workouts", "", "", 401},
+	{"log workout", "POST", "/api/athletes/1/workouts", `{"date":"{today}","type":"tempo","title":"Tempo","distanceMiles":6,"rpe":7,"planId":1}`, "athlete", 201},
+	{"log workout bad type", "POST", "/api/athletes/1/workouts", `{"date":"{today}","type":"nap","title":"Nap","distanceMiles":1}`, "athlete", 400},
+	{"log workout no distance", "POST", "/api/athletes/1/workouts", `{"date":"{today}","type":"easy","title":"Easy"}`, "athlete", 400},
+	{"log workout unassigned plan", "POST", "/api/athletes/2/workouts", `{"date":"{today}","type":"easy","title":"Easy","distanceMiles":3,"planId":1}`, "parent", 400},
+	{"log workout missing athlete", "POST", "/api/athletes/999/workouts", `{"date":"{today}","type":"easy","title":"Easy","distanceMiles":3}`, "admin", 404},
+	{"log workout other athlete", "POST", "/api/athletes/1/workouts", `{"date":"{today}","type":"easy","title":"Easy","distanceMiles":3}`, "parent", 403},
+	{"update workout", "PUT", "/api/workouts/1", `{"date":"{today}","type":"easy","title":"Recovery run","distanceMiles":4}`, "athlete", 200},
+	{"update workout invalid", "PUT", "/api/workouts/1", `{"date":"{today}","type":"easy","title":"Recovery run","rpe":11,"distanceMiles":4}`, "athlete", 400},
+	{"update workout bad id", "PUT", "/api/workouts/abc", `{}`, "athlete", 400},
+	{"update workout missing", "PUT", "/api/workouts/999", `{}`, "athlete", 404},
+	{"update workout other athlete", "PUT", "/api/workouts/1", `{"date":"{today}","type":"easy","title":"Mine now","distanceMiles":4}`, "parent", 403},
+	{"delete workout", "DELETE", "/api/workouts/1", "", "athlete", 200},
+	{"delete workout missing", "DELETE", "/api/workouts/999", "", "admin", 404},
+	{"delete workout other athlete", "DELETE", "/api/workouts/1", "", "parent", 403},
+	{"delete workout no token", "DELETE", "/api/workouts/1", "", "", 401},
+	{"mileage", "GET", "/api/athletes/1/mileage?weeks=4", "", "athlete", 200},
+	{"mileage bad weeks", "GET", "/api/athletes/1/mileage?weeks=0", "", "athlete", 400},
+	{"mileage other athlete", "GET", "/api/athletes/1/mileage", "", "parent", 403},
+	{"athlete plans", "GET", "/api/athletes/1/plans", "", "athlete", 200},
+	{"athlete plans other athlete", "GET", "/api/athletes/1/plans", "", "parent", 403},
+	{"athlete attendance", "GET", "/api/athletes/1/attendance", "", "athlete", 200},
+	{"athlete attendance missing", "GET", "/api/athletes/999/attendance", "", "admin", 404},
+	{"athlete attendance other athlete", "GET", "/api/athletes/1/attendance", "", "parent", 403},
+	{"profile", "GET", "/api/athletes/2/profile", "", "parent", 200},
+	{"profile missing", "GET", "/api/athletes/999/profile", "", "admin", 404},
+	{"profile other athlete", "GET", "/api/athletes/2/profile", "", "athlete", 403},
+	{"profile no token", "GET", "/api/athletes/2/profile", "", "", 401},
+	{"update profile", "PUT", "/api/athletes/2/profile", `{"bio":"Loves hills","emergencyPhone":"555-0100"}`, "parent", 200},
+	{"update profile long bio", "PUT", "/api/athletes/2/profile", `{"bio":"` + strings.Repeat("x", 1001) + `"}`, "parent", 400},
+	{"update profile other athlete", "PUT", "/api/athletes/2/profile", `{"bio":"hi"}`, "athlete", 403},
+
+	// Training plans and mileage
+	{"team mileage", "GET", "/api/mileage", "", "admin", 200},
+	{"team mileage bad week", "GET", "/api/mileage?week=monday", "", "admin", 400},
+	{"team mileage no token", "GET", "/api/mileage", "", "", 401},
+	{"list plans", "GET", "/api/plans", "", "admin", 200},
+	{"list plans no token", "GET", "/api/plans", "", "", 401},
+	{"create plan", "POST", "/api/plans", `{"date":"{today}","type":"interval","title":"6x800","athleteIds":[1,3]}`, "admin", 201},
+	{"create plan no title", "POST", "/api/plans", `{"date":"{today}","type":"interval"}`, "admin", 400},
+	{"create plan unknown athlete", "POST", "/api/plans", `{"date":"{today}","type":"interval","title":"6x800","athleteIds":[999]}`, "admin", 400},
+	{"create plan no token", "POST", "/api/plans", `{}`, "", 401},
+	{"update plan", "PUT", "/api/plans/1", `{"date":"{today}","type":"easy","title":"Shakeout","athleteIds":[1,2]}`, "admin", 200},
+	{"update plan bad id", "PUT", "/api/plans/abc", `{}`, "admin", 400},
+	{"update plan missing", "PUT", "/api/plans/999", `{"date":"{today}","type":"easy","title":"Shakeout"}`, "admin", 404},
+	{"update plan no token", "PUT", "/api/plans/1", `{}`, "", 401},
+	{"delete plan", "DELETE", "/api/plans/1", "", "admin", 200},
+	{"delete plan missing", "DELETE", "/api/plans/999", "", "admin", 404},
+	{"delete plan bad id", "DELETE", "/api/plans/abc", "", "admin", 400},
+	{"delete plan no token", "DELETE", "/api/plans/1", "", "", 401},
+
+	// Attendance
+	{"list sessions", "GET", "/api/sessions?type=practice", "", "admin", 200},
+	{"list sessions bad type", "GET", "/api/sessions?type=race", "", "admin", 400},
+	{"list sessions no token", "GET", "/api/sessions", "", "", 401},
+	{"get session", "GET", "/api/sessions/1", "", "admin", 200},
+	{"get session bad id", "GET", "/api/sessions/abc", "", "admin", 400},
+	{"get session missing", "GET", "/api/sessions/999", "", "admin", 404},
+	{"create session", "POST", "/api/sessions", `{"type":"meet","meetId":2}`, "admin", 201},
+	{"create session no date", "POST", "/api/sessions", `{"type":"practice"}`, "admin", 400},
+	{"create session missing meet", "POST", "/api/sessions", `{"type":"meet","meetId":999}`, "admin", 400},
+	{"create session no token", "POST", "/api/sessions", `{"date":"{today}"}`, "", 401},
+	{"update session", "PUT", "/api/sessions/1", `{"date":"{today}","type":"practice","notes":"Hills"}`, "admin", 200},
+	{"update session missing", "PUT", "/api/sessions/999", `{"date":"{today}"}`, "admin", 404},
+	{"update session no token", "PUT", "/api/sessions/1", `{"date":"{today}"}`, "", 401},
+	{"delete session", "DELETE", "/api/sessions/1", "", "admin", 200},
+	{"delete session missing", "DELETE", "/api/sessions/999", "", "admin", 404},
+	{"delete session bad id", "DELETE", "/api/sessions/abc", "", "admin", 400},
+	{"delete session no token", "DELETE", "/api/sessions/1", "", "", 401},
+	{"set attendance", "PUT", "/api/sessions/1/attendance", `{"records":[{"athleteId":2,"status":"injured"},{"athleteId":3,"status":"absent"}]}`, "admin", 200},
+	{"set attendance bad status", "PUT", "/api/sessions/1/attendance", `{"records":[{"athleteId":2,"status":"late"}]}`, "admin", 400},
+	{"set attendance unknown athlete", "PUT", "/api/sessions/1/attendance", `{"records":[{"athleteId":999,"status":"present"}]}`, "admin", 400},
+	{"set attendance missing session", "PUT", "/api/sessions/999/attendance", `{"records":[]}`, "admin", 404},
+	{"set attendance no token", "PUT", "/api/sessions/1/attendance", `{"records":[]}`, "", 401},
+	{"delete attendance", "DELETE", "/api/sessions/1/attendance/1", "", "admin", 200},
+	{"delete attendance missing", "DELETE", "/api/sessions/1/attendance/2", "", "admin", 404},
+	{"delete attendance bad id", "DELETE", "/api/sessions/1/attendance/abc", "", "admin", 400},
+	{"delete attendance no token", "DELETE", "/api/sessions/1/attendance/1", "", "", 401},
+	{"attendance summary", "GET", "/api/attendance", "", "admin", 200},
+	{"attendance summary bad season", "GET", "/api/attendance?season=fall", "", "admin", 400},
+	{"attendance summary no token", "GET", "/api/attendance", "", "", 401},
+
+	// Email subscriptions
+	{"list subscriptions", "GET", "/api/subscriptions", "", "admin", 200},
+	{"list subscriptions no token", "GET", "/api/subscriptions", "", "", 401},
+	{"create subscription", "POST", "/api/subscriptions", `{"email":"boosters@example.com","meetChanges":false}`, "admin", 201},
+	{"create subscription taken", "POST", "/api/subscriptions", `{"email":"team@example.com"}`, "admin", 409},
+	{"create subscription bad email", "POST", "/api/subscriptions", `{"email":"boosters"}`, "admin", 400},
+	{"create subscription linked", "POST", "/api/subscriptions", `{"email":"boosters@example.com","results":"linked"}`, "admin", 400},
+	{"create subscription no token", "POST", "/api/subscriptions", `{"email":"boosters@example.com"}`, "", 401},
+	{"delete subscription", "DELETE", "/api/subscriptions/2", "", "admin", 200},
+	{"delete subscription missing", "DELETE", "/api/subscriptions/999", "", "admin", 404},
+	{"delete subscription bad id", "DELETE", "/api/subscriptions/abc", "", "admin", 400},
+	{"delete subscription no token", "DELETE", "/api/subscriptions/2", "", "", 401},
+	{"outbox", "GET", "/api/outbox?status=pending", "", "admin", 200},
+	{"outbox bad limit", "GET", "/api/outbox?limit=0", "", "admin", 400},
+	{"outbox bad status", "GET", "/api/outbox?status=lost", "", "admin", 400},
+	{"outbox no token", "GET", "/api/outbox", "", "", 401},
+	{"retry email missing", "POST", "/api/outbox/999/retry", "", "admin", 404},
+	{"retry email bad id", "POST", "/api/outbox/abc/retry", "", "admin", 400},
+	{"retry email no token", "POST", "/api/outbox/1/retry", "", "", 401},
+
+	// Webhooks
+	{"list webhooks", "GET", "/api/webhooks", "", "admin", 200},
+	{"list webhooks no token", "GET", "/api/webhooks", "", "", 401},
+	{"create webhook", "POST", "/api/webhooks", `{"url":"https://example.com/hook","events":["meet.created","meet.updated"],"secret":"0123456789abcdef"}`, "admin", 201},
+	{"create webhook bad url", "POST", "/api/webhooks", `{"url":"example.com","events":["meet.created"]}`, "admin", 400},
+	{"create webhook unknown event", "POST", "/api/webhooks", `{"url":"https://example.com/hook","events":["meet.exploded"]}`, "admin", 400},
+	{"create webhook short secret", "POST", "/api/webhooks", `{"url":"https://example.com/hook","events":["meet.created"],"secret":"abc"}`, "admin", 400},
+	{"create webhook no token", "POST", "/api/webhooks", `{}`, "", 401},
+	{"update webhook", "PUT", "/api/webhooks/1", `{"url":"http://127.0.0.1:9/hook","events":["result.created"],"active":false}`, "admin", 200},
+	{"update webhook bad id", "PUT", "/api/webhooks/abc", `{}`, "admin", 400},
+	{"update webhook missing", "PUT", "/api/webhooks/999", `{"url":"https://example.com/hook","events":["meet.created"]}`, "admin", 404},
+	{"update webhook no token", "PUT", "/api/webhooks/1", `{}`, "", 401},
+	{"delete webhook", "DELETE", "/api/webhooks/1", "", "admin", 200},
+	{"delete webhook missing", "DELETE", "/api/webhooks/999", "", "admin", 404},
+	{"delete webhook bad id", "DELETE", "/api/webhooks/abc", "", "admin", 400},
+	{"delete webhook no token", "DELETE", "/api/webhooks/1", "", "", 401},
+	{"ping webhook", "POST", "/api/webhooks/1/ping", "", "admin", 202},
+	{"ping webhook missing", "POST", "/api/webhooks/999/ping", "", "admin", 404},
+	{"ping webhook no token", "POST", "/api/webhooks/1/ping", "", "", 401},
+	{"webhook deliveries", "GET", "/api/webhooks/1/deliveries", "", "admin", 200},
+	{"webhook deliveries bad status", "GET", "/api/webhooks/1/deliveries?status=lost", "", "admin", 400},
+	{"webhook deliveries bad id", "GET", "/api/webhooks/abc/deliveries", "", "admin", 400},
+	{"webhook deliveries no token", "GET", "/api/webhooks/1/deliveries", "", "", 401},
+	{"retry delivery missing", "POST", "/api/webhooks/1/deliveries/999/retry", "", "admin", 404},
+	{"retry delivery bad id", "POST", "/api/webhooks/1/deliveries/abc/retry", "", "admin", 400},
+	{"retry delivery no token", "POST", "/api/webhooks/1/deliveries/1/retry", "", "", 401},
+
+	// Archive, backups and rollover
+	{"export archive", "GET", "/api/admin/export", "", "admin", 200},
+	{"export archive no token", "GET", "/api/admin/export", "", "", 401},
+	{"import archive", "POST", "/api/admin/import", `{"format":"jones-county-xc-archive","version":1,"courses":[{"id":7,"name":"River Park","distanceMeters":4828}]}`, "admin", 201},
+	{"import archive dry run", "POST", "/api/admin/import?dryRun=true", `{"format":"jones-county-xc-archive","version":1}`, "admin", 200},
+	{"import archive duplicates", "POST", "/api/admin/import?onDuplicate=fail", `{"format":"jones-county-xc-archive","version":1,"courses":[{"id":7,"name":"Jones County Course","distanceMeters":5000}]}`, "admin", 409},
+	{"import archive bad format", "POST", "/api/admin/import", `{"format":"spreadsheet","version":1}`, "admin", 400},
+	{"import archive bad option", "POST", "/api/admin/import?onDuplicate=merge", `{}`, "admin", 400},
+	{"import archive no token", "POST", "/api/admin/import", `{}`, "", 401},
+	{"list backups", "GET", "/api/backups", "", "admin", 200},
+	{"list backups no token", "GET", "/api/backups", "", "", 401},
+	{"create backup", "POST", "/api/backups", "", "admin", 201},
+	{"create backup no token", "POST", "/api/backups", "", "", 401},
+	{"rollover preview", "GET", "/api/rollover/preview", "", "admin", 200},
+	{"rollover preview bad year", "GET", "/api/rollover/preview?year=next", "", "admin", 400},
+	{"rollover preview no token", "GET", "/api/rollover/preview", "", "", 401},
+	{"rollover", "POST", "/api/rollover", `{"year":{class11}}`, "admin", 200},
+	{"rollover empty body", "POST", "/api/rollover", "", "admin", 200},
+	{"rollover bad json", "POST", "/api/rollover", `{"year":"next"}`, "admin", 400},
+	{"rollover no token", "POST", "/api/rollover", `{}`, "", 401},
+}
+
+func TestRoutes(t *testing.T) {
+	for _, tt := range routeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f := newFixture(t)
+			w := f.do(tt.method, f.vars.Replace(tt.path), f.vars.Replace(tt.body), tt.as)
+			if w.Code != tt.want {
+				t.Fatalf("%s %s = %d, want %d: %s", tt.method, tt.path, w.Code, tt.want, w.Body)
+			}
+			if check := routeChecks[tt.name]; check != nil {
+				check(f, w)
+			}
+		})
+	}
+}
+
+// routeChecks look past the status code of the main flows: what the
+// response holds and what the request changed. Each runs on the fixture
+// right after its routeTests case.
+var routeChecks = map[string]func(f *fixture, w *httptest.ResponseRecorder){
+	"create athlete": func(f *fixture, w *httptest.ResponseRecorder) {
+		var created AthleteResponse
+		decode(f.t, w, &created)
+		if created.ID != 4 || created.Name != "New Runner" || created.Grade == nil || *created.Grade != 9 {
+			f.t.Errorf("created athlete = %+v, want id 4, New Runner, grade 9", created)
+		}
+		var got AthleteResponse
+		decode(f.t, f.do("GET", "/api/athletes/4", "", ""), &got)
+		if got.Name != "New Runner" || stringOrEmpty(got.Gender) != "F" || got.Grade == nil || *got.Grade != 9 {
+			f.t.Errorf("stored athlete = %+v", got)
+		}
+	},
+	"update athlete name only": func(f *fixture, w *httptest.ResponseRecorder) {
+		var updated AthleteResponse
+		decode(f.t, w, &updated)
+		if updated.Name != "Samuel Runner" || stringOrEmpty(updated.PersonalRecord) != "17:20" ||
+			stringOrEmpty(updated.Gender) != "M" || updated.Grade == nil || *updated.Grade != 11 ||
+			!slices.Equal(updated.Events, []string{"5K"}) {
+			f.t.Errorf("updated athlete = %+v, want the new name and PR with gender, grade and events kept", updated)
+		}
+		var got AthleteResponse
+		decode(f.t, f.do("GET", "/api/athletes/1", "", ""), &got)
+		if got.Name != "Samuel Runner" || !slices.Equal(got.Events, []string{"5K"}) {
+			f.t.Errorf("stored athlete = %+v", got)
+		}
+	},
+	"create meet": func(f *fixture, w *httptest.ResponseRecorder) {
+		var created MeetResponse
+		decode(f.t, w, &created)
+		today := f.vars.Replace("{today}")
+		if created.ID != 3 || created.Name != "Dual Meet" || stringOrEmpty(created.Date) != today || created.Status != meetScheduled {
+			f.t.Errorf("created meet = %+v", created)
+		}
+		var got MeetResponse
+		decode(f.t, f.do("GET", "/api/meets/3", "", ""), &got)
+		if got.Name != "Dual Meet" || stringOrEmpty(got.Date) != today {
+			f.t.Errorf("stored meet = %+v", got)
+		}
+	},
+	"update meet name only": func(f *fixture, w *httptest.ResponseRecorder) {
+		var updated MeetResponse
+		decode(f.t, w, &updated)
+		if updated.Name != "Region Finals" || updated.CourseID == nil || *updated.CourseID != 1 || updated.Date == nil {
+			f.t.Errorf("updated meet = %+v, want the new name with course 1 and the date kept", updated)
+		}
+		var cleared MeetResponse
+		decode(f.t, f.do("PUT", "/api/meets/2", `{"courseId":null}`, "admin"), &cleared)
+		if cleared.Name != "Region Finals" || cleared.CourseID != nil {
+			f.t.Errorf("after clearing the course: %+v", cleared)
+		}
+	},
+	"timing commit empty": func(f *fixture, w *httptest.ResponseRecorder) {
+		var results []MeetResultResponse
+		decode(f.t, f.do("GET", "/api/meets/1/results", "", ""), &results)
+		if len(results) != 2 {
+			f.t.Errorf("meet 1 has %d results after an empty commit, want 2", len(results))
+		}
+	},
+	"create result": func(f *fixture, w *httptest.ResponseRecorder) {
+		var created CreatedResultResponse
+		decode(f.t, w, &created)
+		if created.ID != 3 || created.AthleteID == nil || *created.AthleteID != 2 || created.MeetID == nil || *created.MeetID != 1 ||
+			stringOrEmpty(created.Time) != "19:45" || created.Place == nil || *created.Place != 3 {
+			f.t.Errorf("created result = %+v", created.ResultResponse)
+		}
+		var results []MeetResultResponse
+		decode(f.t, f.do("GET", "/api/meets/1/results", "", ""), &results)
+		if !slices.ContainsFunc(results, func(r MeetResultResponse) bool { return r.ID == 3 && r.AthleteName == "Ava Strider" }) {
+			f.t.Errorf("meet 1 results = %+v, want result 3 for Ava Strider", results)
+		}
+	},
+	"create course": func(f *fixture, w *httptest.ResponseRecorder) {
+		var created CourseResponse
+		decode(f.t, w, &created)
+		if created.ID != 2 || created.Name != "River Park" || created.DistanceMeters != 4828 {
+			f.t.Errorf("created course = %+v", created)
+		}
+	},
+	"create webhook": func(f *fixture, w *httptest.ResponseRecorder) {
+		var created WebhookResponse
+		decode(f.t, w, &created)
+		if created.ID != 2 || created.Secret != "0123456789abcdef" || !created.Active {
+			f.t.Errorf("created webhook = %+v, want id 2, active, with its secret", created)
+		}
+		// The secret is shown once; listings leave it out.
+		var hooks []map[string]any
+		decode(f.t, f.do("GET", "/api/webhooks", "", "admin"), &hooks)
+		if len(hooks) != 2 {
+			f.t.Fatalf("webhooks = %v, want 2", hooks)
+		}
+		for _, h := range hooks {
+			if _, ok := h["secret"]; ok {
+				f.t.Errorf("webhook %v lists its secret", h["id"])
+			}
+		}
+	},
+	"register": func(f *fixture, w *httptest.ResponseRecorder) {
+		var created struct {
+			Token   string          `json:"token"`
+			Role    string          `json:"role"`
+			Account AccountResponse `json:"account"`
+		}
+		decode(f.t, w, &created)
+		if created.Token == "" || created.Role != roleParent || !slices.Equal(created.Account.AthleteIDs, []int64{3}) {
+			f.t.Errorf("registered = %+v, want a parent of athlete 3 with a token", created)
+		}
+		f.checkInviteRedeemed(created.Account.ID)
+
+		f.tokens["ben-parent"] = created.Token
+		var me MeResponse
+		decode(f.t, f.do("GET", "/api/me", "", "ben-parent"), &me)
+		if me.Account.Username != "ben-parent" || len(me.Athletes) != 1 || me.Athletes[0].ID != 3 {
+			f.t.Errorf("me = %+v", me)
+		}
+		if w := f.do("POST", "/api/accounts", f.vars.Replace(`{"code":"{invite}","username":"ben-parent2","password":"password123"}`), ""); w.Code != 400 {
+			f.t.Errorf("reusing the invite: status %d, want 400: %s", w.Code, w.Body)
+		}
+	},
+	"link athlete": func(f *fixture, w *httptest.ResponseRecorder) {
+		var me MeResponse
+		decode(f.t, f.do("GET", "/api/me", "", "parent"), &me)
+		var ids []int64
+		for _, a := range me.Athletes {
+			ids = append(ids, a.ID)
+		}
+		if !slices.Equal(ids, []int64{2, 3}) {
+			f.t.Errorf("parent athletes = %v, want [2 3]", ids)
+		}
+		f.checkInviteRedeemed(me.Account.ID)
+	},
+	"rollover": func(f *fixture, w *httptest.ResponseRecorder) {
+		var preview RolloverPreview
+		decode(f.t, w, &preview)
+		if !preview.Applied || len(preview.Graduates) != 1 || preview.Graduates[0].AthleteID != 1 {
+			f.t.Errorf("rollover = %+v, want athlete 1 graduated", preview)
+		}
+		var roster []AthleteResponse
+		decode(f.t, f.do("GET", "/api/athletes", "", ""), &roster)
+		for _, a := range roster {
+			if a.ID == 1 {
+				f.t.Errorf("graduate still on the roster: %+v", a)
+			}
+		}
+		var alumni []AlumniClass
+		decode(f.t, f.do("GET", "/api/alumni", "", ""), &alumni)
+		if len(alumni) != 1 || len(alumni[0].Athletes) != 1 || alumni[0].Athletes[0].ID != 1 {
+			f.t.Errorf("alumni = %+v, want athlete 1 only", alumni)
+		}
+	},
+	"export archive": func(f *fixture, w *httptest.ResponseRecorder) {
+		var exported Archive
+		decode(f.t, w, &exported)
+		if len(exported.Athletes) != 3 || len(exported.Meets) != 2 || len(exported.Results) != 2 || len(exported.Workouts) != 1 {
+			f.t.Errorf("archive has %d athletes, %d meets, %d results, %d workouts; want 3, 2, 2, 1",
+				len(exported.Athletes), len(exported.Meets), len(exported.Results), len(exported.Workouts))
+		}
+
+		// Importing into an empty database and exporting again gives the
+		// same archive. Athletes are exported by name, so the import numbers
+		// them in that order.
+		g := &fixture{t: f.t, router: NewRouter(newTestServer(f.t)), tokens: f.tokens}
+		g.mustDo("POST", "/api/admin/import", w.Body.String())
+		var reexported Archive
+		decode(f.t, g.do("GET", "/api/admin/export", "", "admin"), &reexported)
+		exported.ExportedAt, reexported.ExportedAt = "", ""
+		renumberAthletes(&exported)
+		want, _ := json.Marshal(exported)
+		got, _ := json.Marshal(reexported)
+		if string(got) != string(want) {
+			f.t.Errorf("round trip changed the archive:\n got %s\nwant %s", got, want)
+		}
+	},
+}
+
+// renumberAthletes gives an archive's athletes the IDs an import assigns:
+// 1, 2, ... in the order they are listed.
+func renumberAthletes(a *Archive) {
+	ids := map[int64]int64{}
+	for i := range a.Athletes {
+		ids[a.Athletes[i].ID] = int64(i + 1)
+		a.Athletes[i].ID = int64(i + 1)
+	}
+	for i := range a.Entries {
+		a.Entries[i].AthleteID = ids[a.Entries[i].AthleteID]
+	}
+	for i := range a.Bibs {
+		a.Bibs[i].AthleteID = ids[a.Bibs[i].AthleteID]
+	}
+	for i := range a.Results {
+		if id := a.Results[i].AthleteID; id != nil {
+			renumbered := ids[*id]
+			a.Results[i].AthleteID = &renumbered
+		}
+	}
+	for i := range a.WorkoutPlans {
+		for j, id := range a.WorkoutPlans[i].AthleteIDs {
+			a.WorkoutPlans[i].AthleteIDs[j] = ids[id]
+		}
+	}
+	for i := range a.Workouts {
+		a.Workouts[i].AthleteID = ids[a.Workouts[i].AthleteID]
+	}
+	for i := range a.Attendance {
+		a.Attendance[i].AthleteID = ids[a.Attendance[i].AthleteID]
+	}
+}
+
+// checkInviteRedeemed checks that the fixture's invite was used by the
+// given account.
+func (f *fixture) checkInviteRedeemed(accountID int64) {
+	f.t.Helper()
+	var invites []InviteResponse
+	decode(f.t, f.do("GET", "/api/invites", "", "admin"), &invites)
+	code := f.vars.Replace("{invite}")
+	for _, inv := range invites {
+		if inv.Code != code {
+			continue
+		}
+		if inv.UsedAt == nil || inv.AccountID == nil || *inv.AccountID != accountID {
+			f.t.Errorf("invite = %+v, want used by account %d", inv, accountID)
+		}
+		return
+	}
+	f.t.Errorf("invite %s not listed", code)
+}
+
+// TestRouteChecksNamed fails when a routeChecks entry names no routeTests
+// case, so a renamed case can't silently drop its checks.
+func TestRouteChecksNamed(t *testing.T) {
+	names := map[string]bool{}
+	for _, tt := range routeTests {
+		names[tt.name] = true
+	}
+	for name := range routeChecks {
+		if !names[name] {
+			t.Errorf("routeChecks has %q, which is not a routeTests case", name)
+		}
+	}
+}
+
+// TestRouteTestsCover fails when a route is added to NewRouter without a
+// case in routeTests.
+func TestRouteTestsCover(t *testing.T) {
+	router := NewRouter(newTestServer(t))
+	for _, route := range router.Routes() {
+		covered := false
+		for _, tt := range routeTests {
+			if tt.method == route.Method && routeMatches(route.Path, tt.path) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			t.Errorf("no test for %s %s", route.Method, route.Path)
+		}
+	}
+}
+
+// routeMatches reports whether a request path fits a gin route pattern.
+func routeMatches(pattern, path string) bool {
+	path, _, _ = strings.Cut(path, "?")
+	want := strings.Split(pattern, "/")
+	got := strings.Split(path, "/")
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if !strings.HasPrefix(want[i], ":") && want[i] != got[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestEventPayloads checks that writes through the router reach the
+// server's event listeners, and that the email and webhook queues
+// registered by NewServer turn them into outbox emails and deliveries.
+func TestEventPayloads(t *testing.T) {
+	f := newFixture(t)
+	var got []string
+	f.server.onEvent(func(name string, payload any) {
+		got = append(got, name)
+	})
+
+	updated := f.mustDo("PUT", "/api/athletes/1", f.vars.Replace(`{"name":"Sam Runner","gender":"M","graduationYear":{class11}}`))
+	if updated["grade"] != float64(11) {
+		t.Errorf("updated athlete grade = %v, want 11", updated["grade"])
+	}
+	f.mustDo("GET", "/api/athletes/1", "")
+	f.mustDo("DELETE", "/api/results/2", "")
+	next := time.Now().AddDate(0, 0, 21).Format("2006-01-02")
+	f.mustDo("PUT", "/api/meets/2", `{"name":"Region Championship","date":"`+next+`","courseId":1}`)
+	f.mustDo("POST", "/api/results", `{"athleteId":3,"meetId":1,"time":"18:05","place":2}`)
+
+	want := []string{EventAthleteUpdated, EventResultDeleted, EventMeetUpdated, EventResultCreated}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	var outbox []OutboxResponse
+	decode(t, f.do("GET", "/api/outbox", "", "admin"), &outbox)
+	if !slices.ContainsFunc(outbox, func(m OutboxResponse) bool {
+		return m.Event == EventMeetUpdated && m.To == "team@example.com" && m.Status == emailPending
+	}) {
+		t.Errorf("outbox = %+v, want a pending meet.updated email to team@example.com", outbox)
+	}
+	if !slices.ContainsFunc(outbox, func(m OutboxResponse) bool { return m.Event == EventResultCreated }) {
+		t.Errorf("outbox = %+v, want a result.created email", outbox)
+	}
+
+	// Webhook 1 only listens for result.created.
+	var deliveries []WebhookDeliveryResponse
+	decode(t, f.do("GET", "/api/webhooks/1/deliveries", "", "admin"), &deliveries)
+	if len(deliveries) != 1 || deliveries[0].Event != EventResultCreated || deliveries[0].Status != deliveryPending {
+		t.Fatalf("deliveries = %+v, want one pending result.created", deliveries)
+	}
+	var payload ResultResponse
+	if err := json.Unmarshal(deliveries[0].Payload, &payload); err != nil || stringOrEmpty(payload.Time) != "18:05" {
+		t.Errorf("delivery payload = %s (%v)", deliveries[0].Payload, err)
+	}
+}
+
+// TestRequestContext checks that requests whose context ends before the
+// handler reaches the database are answered as cancelled or timed out, and
+// that a timed-out write is not applied.
+func TestRequestContext(t *testing.T) {
+	f := newFixture(t)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest("GET", "/api/athletes", nil).WithContext(cancelled)
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	if w.Code != statusClientClosedRequest {
+		t.Errorf("cancelled request: status %d, want %d: %s", w.Code, statusClientClosedRequest, w.Body)
+	}
+
+	s := newTestServer(t)
+	s.requestTimeout = time.Nanosecond
+	f.router = NewRouter(s)
+	if w := f.do("GET", "/api/athletes", "", ""); w.Code != 503 {
+		t.Errorf("timed-out read: status %d, want 503: %s", w.Code, w.Body)
+	}
+	if w := f.do("POST", "/api/athletes", `{"name":"Late Runner","gender":"F","grade":9}`, "admin"); w.Code != 503 {
+		t.Errorf("timed-out write: status %d, want 503: %s", w.Code, w.Body)
+	}
+	athletes, err := s.store.GetAllAthletes(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(athletes) != 0 {
+		t.Errorf("timed-out write created %d athletes", len(athletes))
+	}
+}
+
+// failingStore fails the lookups handlers use to check that a row exists,
+// the way a locked or damaged database would.
+type failingStore struct{ Store }
+
+var errStoreFailed = errors.New("database is locked")
+
+func (failingStore) GetAthleteByID(context.Context, int64) (db.Athlete, error) {
+	return db.Athlete{}, errStoreFailed
+}
+
+func (failingStore) GetMeetByID(context.Context, int64) (db.Meet, error) {
+	return db.Meet{}, errStoreFailed
+}
+
+func (failingStore) GetResultByID(context.Context, int64) (db.Result, error) {
+	return db.Result{}, errStoreFailed
+}
+
+func (failingStore) GetWorkoutPlanAthleteIDs(context.Context, int64) ([]int64, error) {
+	return nil, errStoreFailed
+}
+
+// TestLookupErrors checks that only a missing row is reported as 404; any
+// other failure loading it is a server error.
+func TestLookupErrors(t *testing.T) {
+	f := newFixture(t)
+	f.server.store = failingStore{f.server.store}
+
+	for _, tt := range []struct{ method, path, body, as string }{
+		{"GET", "/api/athletes/1", "", ""},
+		{"GET", "/api/athletes/1/analytics", "", ""},
+		{"GET", "/api/meets/1", "", ""},
+		{"GET", "/api/results/1", "", ""},
+		{"PUT", "/api/results/1", `{"athleteId":1,"meetId":1,"time":"17:20","place":1}`, "admin"},
+		{"POST", "/api/meets/1/status", `{"status":"final"}`, "admin"},
+		{"POST", "/api/athletes/1/workouts", `{"date":"{today}","type":"easy","distanceMiles":3}`, "athlete"},
+		{"PUT", "/api/workouts/1", `{"date":"{today}","type":"easy","distanceMiles":3,"planId":1}`, "athlete"},
+		{"GET", "/api/me", "", "athlete"},
+	} {
+		w := f.do(tt.method, tt.path, f.vars.Replace(tt.body), tt.as)
+		if w.Code != 500 || !strings.Contains(w.Body.String(), errStoreFailed.Error()) {
+			t.Errorf("%s %s = %d, want 500: %s", tt.method, tt.path, w.Code, w.Body)
+		}
+	}
+}
+
+// TestDatabaseSettings checks that the connection settings reach every
+// connection in the pool, not only the first one opened.
+func TestDatabaseSettings(t *testing.T) {
+	conn, err := openDatabase(t.TempDir() + "/settings.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	for i := range 3 {
+		// Holding each connection open forces the pool to dial a new one.
+		c, err := conn.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+
+		var foreignKeys, busy int64
+		var journal string
+		if err := c.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&busy); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&journal); err != nil {
+			t.Fatal(err)
+		}
+		if foreignKeys != 1 || busy != busyTimeout.Milliseconds() || journal != "wal" {
+			t.Errorf("connection %d: foreign_keys=%d busy_timeout=%d journal_mode=%s", i, foreignKeys, busy, journal)
+		}
+	}
+}
+
+// TestMeetDateMigration checks that migration 016 rewrites old free-text
+// meet dates once and leaves unparseable ones alone.
+func TestMeetDateMigration(t *testing.T) {
+	conn, err := openDatabase(t.TempDir() + "/dates.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if err := runMigrations(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	// Roll back to the schema before 016 and store dates the old way.
+	for _, stmt := range []string{
+		"PRAGMA user_version = 15",
+		"INSERT INTO meets (id, name, date) VALUES (1, 'Old', 'Sep 5, 2025'), (2, 'Odd', 'sometime'), (3, 'New', '2025-09-12')",
+	} {
+		if _, err := conn.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := runMigrations(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[int64]string{1: "2025-09-05", 2: "sometime", 3: "2025-09-12"}
+	for id, date := range want {
+		var got string
+		if err := conn.QueryRow("SELECT date FROM meets WHERE id = ?", id).Scan(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got != date {
+			t.Errorf("meet %d: date = %q, want %q", id, got, date)
+		}
+	}
+}
+
+// TestRecordChecks checks that every way of saving a result can break a
+// school record, not only POST /api/results.
+func TestRecordChecks(t *testing.T) {
+	f := newFixture(t)
+
+	// The boys' 5000m record is Old Timer's 15:59.
+	updated := f.mustDo("PUT", "/api/results/1", `{"athleteId":1,"meetId":1,"time":"15:50","place":1}`)
+	if got := categories(updated["newRecords"]); got != "all-time" {
+		t.Errorf("update: new record categories = %q, want all-time", got)
+	}
+	unchanged := f.mustDo("PUT", "/api/results/1", `{"athleteId":1,"meetId":1,"time":"15:50","place":1}`)
+	if unchanged["newRecords"] != nil {
+		t.Errorf("saving the same time again: newRecords = %v, want none", unchanged["newRecords"])
+	}
+
+	// Ben's 18:10 was the grade 9 record, so he breaks both.
+	imported := f.mustDo("POST", "/api/meets/1/results/import", "athleteId,place,time\n3,1,15:45\n")
+	if got := categories(imported["newRecords"]); got != "all-time,grade" {
+		t.Errorf("import: new record categories = %q, want all-time,grade", got)
+	}
+
+	if w := f.do("POST", "/api/meets/1/timing/commit", `{"times":["15:30"],"bibs":[101],"replace":true}`, "admin"); w.Code != 201 {
+		t.Fatalf("timing commit: status %d: %s", w.Code, w.Body)
+	}
+
+	var breaks []RecordBreakResponse
+	decode(t, f.do("GET", "/api/records/breaks", "", ""), &breaks)
+	var times []string
+	for _, b := range breaks {
+		if b.Category == recordAllTime {
+			times = append(times, b.Time)
+		}
+	}
+	slices.Sort(times)
+	if want := []string{"15:30", "15:45", "15:50"}; !slices.Equal(times, want) {
+		t.Errorf("all-time breaks = %v, want %v", times, want)
+	}
+}
+
+// categories lists the categories of a decoded newRecords array.
+func categories(records any) string {
+	var out []string
+	list, _ := records.([]any)
+	for _, r := range list {
+		out = append(out, r.(map[string]any)["category"].(string))
+	}
+	return strings.Join(out, ",")
+}
+
+// decode reads a JSON response into v, failing the test on a non-2xx status.
+func decode(t *testing.T, w *httptest.ResponseRecorder, v any) {
+	t.Helper()
+	if w.Code < 200 || w.Code > 299 {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("decoding %s: %v", w.Body, err)
+	}
+}
+
+// TestCompareRaces checks that only athletes who ran the same race are
+// compared: entered athletes by race, the rest by gender.
+func TestCompareRaces(t *testing.T) {
+	f := newFixture(t)
+	f.mustDo("POST", "/api/results", `{"athleteId":2,"meetId":1,"time":"19:40","place":1}`)
+
+	var got CompareResponse
+	decode(t, f.do("GET", "/api/compare?athletes=1,2,3", "", ""), &got)
+	if len(got.Meets) != 1 || got.Meets[0].Gender == nil || *got.Meets[0].Gender != "M" || len(got.Meets[0].Finishers) != 2 {
+		t.Fatalf("meets = %+v, want the boys at meet 1", got.Meets)
+	}
+	if h := got.HeadToHead[0]; h.AthleteID != 1 || h.OpponentID != 2 || h.Wins+h.Losses+h.Ties != 0 {
+		t.Errorf("boy against girl = %+v, want no shared races", h)
+	}
+	if h := got.HeadToHead[1]; h.AthleteID != 1 || h.OpponentID != 3 || h.Wins != 1 {
+		t.Errorf("1 against 3 = %+v, want one win", h)
+	}
+
+	// Entering Sam in a race at meet 1 separates him from Ben, who was not.
+	f.mustDo("POST", "/api/meets/1/races", `{"name":"Varsity Boys","gender":"M"}`)
+	f.mustDo("PUT", "/api/races/2/entries", `{"scorers":[1]}`)
+	decode(t, f.do("GET", "/api/compare?athletes=1,3", "", ""), &got)
+	if len(got.Meets) != 0 {
+		t.Errorf("meets = %+v, want none shared", got.Meets)
+	}
+
+	f.mustDo("PUT", "/api/races/2/entries", `{"scorers":[1,3]}`)
+	decode(t, f.do("GET", "/api/compare?athletes=1,3", "", ""), &got)
+	if len(got.Meets) != 1 || got.Meets[0].Race == nil || *got.Meets[0].Race != "Varsity Boys" {
+		t.Errorf("meets = %+v, want Varsity Boys at meet 1", got.Meets)
+	}
+}
+
+// TestLeaderboardGrades checks that leaderboards use the grade an athlete
+// was in at the meet, not today's.
+func TestLeaderboardGrades(t *testing.T) {
+	f := newFixture(t)
+	// A year before meet 1, so always the previous school year.
+	meet1 := time.Now().AddDate(0, 0, -14)
+	lastYear := meet1.AddDate(-1, 0, 0)
+	f.mustDo("POST", "/api/meets", `{"name":"Last Season Opener","date":"`+lastYear.Format("2006-01-02")+`","courseId":1}`)
+	f.mustDo("POST", "/api/meets/3/status", `{"status":"in-progress"}`)
+	f.mustDo("POST", "/api/results", `{"athleteId":1,"meetId":3,"time":"18:40","place":3}`)
+
+	athlete := f.mustDo("GET", "/api/athletes/1", "")
+	year := sql.NullInt64{Int64: int64(athlete["graduationYear"].(float64)), Valid: true}
+	for _, tt := range []struct {
+		grade int64
+		time  string
+	}{{gradeOn(year, lastYear).Int64, "18:40"}, {gradeOn(year, meet1).Int64, "17:30"}} {
+		var boards []LeaderboardResponse
+		decode(t, f.do("GET", fmt.Sprintf("/api/leaderboards?gender=M&grade=%d", tt.grade), "", ""), &boards)
+		var sam *LeaderboardEntry
+		for _, b := range boards {
+			for i, e := range b.Entries {
+				if e.AthleteID == 1 {
+					sam = &b.Entries[i]
+				}
+			}
+		}
+		if sam == nil || sam.Time != tt.time || sam.Grade == nil || *sam.Grade != tt.grade {
+			t.Errorf("grade %d: Sam's entry = %+v, want %s in grade %d", tt.grade, sam, tt.time, tt.grade)
+		}
+	}
+}
+
+// TestMeetReopen checks that a final meet locks its results and that
+// reopening it unlocks them again.
+func TestMeetReopen(t *testing.T) {
+	f := newFixture(t)
+	if meet := f.mustDo("POST", "/api/meets/1/status", `{"status":"final"}`); meet["locked"] != true {
+		t.Errorf("final meet locked = %v, want true", meet["locked"])
+	}
+	if w := f.do("PUT", "/api/results/1", `{"athleteId":1,"meetId":1,"time":"17:20","place":1}`, "admin"); w.Code != 409 {
+		t.Errorf("editing a final meet's result: status %d, want 409", w.Code)
+	}
+
+	if meet := f.mustDo("POST", "/api/meets/1/status", `{"status":"in-progress"}`); meet["locked"] != false {
+		t.Errorf("reopened meet locked = %v, want false", meet["locked"])
+	}
+	if result := f.mustDo("PUT", "/api/results/1", `{"athleteId":1,"meetId":1,"time":"17:20","place":1}`); result["time"] != "17:20" {
+		t.Errorf("corrected time = %v, want 17:20", result["time"])
+	}
+}
+
+// TestPlanAthletes checks that plan lists carry each plan's own athletes.
+func TestPlanAthletes(t *testing.T) {
+	f := newFixture(t)
+	plan := f.mustDo("POST", "/api/plans", `{"date":"`+time.Now().Format(meetDateLayout)+`","type":"interval","title":"6x800","athleteIds":[3,1]}`)
+	want := map[int64]string{1: "[1]", int64(plan["id"].(float64)): "[1 3]"}
+
+	var plans []WorkoutPlanResponse
+	decode(t, f.do("GET", "/api/plans", "", "admin"), &plans)
+	var athletePlans []AthletePlanResponse
+	decode(t, f.do("GET", "/api/athletes/1/plans", "", "admin"), &athletePlans)
+	for _, p := range athletePlans {
+		plans = append(plans, p.WorkoutPlanResponse)
+	}
+	if len(plans) != 4 {
+		t.Fatalf("got %d plans, want 2 from each list", len(plans))
+	}
+	for _, p := range plans {
+		if got := fmt.Sprint(p.AthleteIDs); got != want[p.ID] {
+			t.Errorf("plan %d athletes = %s, want %s", p.ID, got, want[p.ID])
+		}
+	}
+}
+
+// smtpStub is a minimal SMTP server for delivery tests. It answers MAIL
+// FROM with reply, so a 4xx reply makes every send fail.
+type smtpStub struct {
+	addr     string
+	mu       sync.Mutex
+	reply    string
+	messages []string
+}
+
+func newSMTPStub(t *testing.T) *smtpStub {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	stub := &smtpStub{addr: ln.Addr().String(), reply: "250 OK"}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go stub.serve(conn)
+		}
+	}()
+	return stub
+}
+
+func (s *smtpStub) setReply(reply string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reply = reply
+}
+
+func (s *smtpStub) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.messages)
+}
+
+func (s *smtpStub) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	fmt.Fprint(conn, "220 stub ESMTP\r\n")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			fmt.Fprint(conn, "250 stub\r\n")
+		case strings.HasPrefix(cmd, "MAIL"):
+			s.mu.Lock()
+			reply := s.reply
+			s.mu.Unlock()
+			fmt.Fprint(conn, reply+"\r\n")
+		case cmd == "DATA":
+			fmt.Fprint(conn, "354 go ahead\r\n")
+			var msg strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				msg.WriteString(l)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, msg.String())
+			s.mu.Unlock()
+			fmt.Fprint(conn, "250 queued\r\n")
+		case cmd == "QUIT":
+			fmt.Fprint(conn, "221 bye\r\n")
+			return
+		default:
+			fmt.Fprint(conn, "250 OK\r\n")
+		}
+	}
+}
+
+// TestEmailDelivery sends the outbox to an SMTP stub: a message that goes
+// through is marked sent, and one the server keeps refusing is retried
+// with backoff until maxEmailAttempts, then marked failed.
+func TestEmailDelivery(t *testing.T) {
+	s := newTestServer(t)
+	stub := newSMTPStub(t)
+	s.mail.Addr = stub.addr
+	ctx := context.Background()
+
+	enqueue := func(subject string) int64 {
+		m, err := s.store.EnqueueEmail(ctx, db.EnqueueEmailParams{
+			ToEmail:       "team@example.com",
+			Subject:       subject,
+			Body:          "Hello team",
+			Event:         EventMeetUpdated,
+			NextAttemptAt: outboxTime(time.Now()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m.ID
+	}
+	outbox := func(id int64) db.EmailOutbox {
+		emails, err := s.store.GetOutbox(ctx, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range emails {
+			if m.ID == id {
+				return m
+			}
+		}
+		t.Fatalf("email %d not in the outbox", id)
+		return db.EmailOutbox{}
+	}
+
+	sent := enqueue("Schedule change")
+	s.drainOutbox()
+	if m := outbox(sent); m.Status != emailSent || !m.SentAt.Valid {
+		t.Errorf("delivered email: status %s, sentAt %v", m.Status, m.SentAt)
+	}
+	if got := stub.received(); len(got) != 1 || !strings.Contains(got[0], "Subject: Schedule change") {
+		t.Fatalf("stub received %q", got)
+	}
+
+	stub.setReply("451 try again later")
+	failing := enqueue("Never arrives")
+	s.drainOutbox()
+	for attempt := int64(1); ; attempt++ {
+		m := outbox(failing)
+		wantStatus := emailPending
+		if attempt == maxEmailAttempts {
+			wantStatus = emailFailed
+		}
+		if m.Attempts != attempt || m.Status != wantStatus || !strings.Contains(m.LastError.String, "451") {
+			t.Fatalf("after attempt %d: attempts %d, status %s, lastError %q", attempt, m.Attempts, m.Status, m.LastError.String)
+		}
+		next, err := time.Parse(time.RFC3339, m.NextAttemptAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wait := time.Until(next); wait < retryBackoff(attempt)-5*time.Second || wait > retryBackoff(attempt) {
+			t.Errorf("after attempt %d: next attempt in %s, want %s", attempt, wait, retryBackoff(attempt))
+		}
+		if m.Status == emailFailed {
+			break
+		}
+		// The retry is not due yet, so deliver it directly.
+		s.deliverEmail(m)
+	}
+	if got := stub.received(); len(got) != 1 {
+		t.Errorf("stub received %d messages, want only the first", len(got))
+	}
+}
+
+// TestBackupsDamaged checks that a damaged file shows up as an invalid
+// backup instead of failing the list, and that restoring over a damaged
+// database still saves a copy of it first.
+func TestBackupsDamaged(t *testing.T) {
+	f := newFixture(t)
+	s := newTestServer(t)
+	dir := t.TempDir()
+	t.Setenv("BACKUP_DIR", filepath.Join(dir, "backups"))
+	saved := dbPath
+	dbPath = filepath.Join(dir, "data.db")
+	t.Cleanup(func() { dbPath = saved })
+
+	f.mustDo("POST", "/api/backups", "")
+	garbage := []byte("not a database")
+	if err := os.WriteFile(filepath.Join(dir, "backups", "data-19990101-000000.db"), garbage, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var backups []BackupResponse
+	decode(t, f.do("GET", "/api/backups", "", "admin"), &backups)
+	if len(backups) != 2 || !backups[0].Valid || backups[1].Valid || backups[1].Error == nil {
+		t.Fatalf("backups = %+v, want a valid one and then an invalid one", backups)
+	}
+
+	good := filepath.Join(dir, "good.db")
+	if err := s.writeBackup(context.Background(), good); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dbPath, garbage, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runRestoreCommand(s, []string{good}); err != nil {
+		t.Fatalf("restore over a damaged database: %v", err)
+	}
+	if _, err := checkDatabaseFile(dbPath); err != nil {
+		t.Errorf("restored database: %v", err)
+	}
+	pre, _ := filepath.Glob(filepath.Join(dir, "backups", "pre-restore-*.db"))
+	if len(pre) != 1 {
+		t.Fatalf("pre-restore copies = %v, want 1", pre)
+	}
+	if got, _ := os.ReadFile(pre[0]); string(got) != string(garbage) {
+		t.Errorf("pre-restore copy = %q, want the damaged file", got)
+	}
+}
+
+// TestSeedDeterministic checks that seeding twice with the same seed gives
+// identical data, and that another seed does not.
+func TestSeedDeterministic(t *testing.T) {
+	dump := func(s *Server) string {
+		a, err := s.buildArchive(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.ExportedAt = ""
+		b, err := json.Marshal(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	first := dump(newSeededServer(t, 7))
+	if second := dump(newSeededServer(t, 7)); second != first {
+		t.Error("seeding twice with seed 7 gave different data")
+	}
+	if other := dump(newSeededServer(t, 8)); other == first {
+		t.Error("seeds 7 and 8 gave the same data")
+	}
+}
+
+// TestSeededSeason runs the leaderboards over a generated season: each
+// board is fastest first, with one entry per athlete of the requested
+// gender.
+func TestSeededSeason(t *testing.T) {
+	router := NewRouter(newSeededServer(t, 1))
+	for _, gender := range []string{"M", "F"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/leaderboards?limit=100&gender="+gender, nil))
+		var boards []LeaderboardResponse
+		decode(t, w, &boards)
+		if len(boards) == 0 {
+			t.Fatalf("gender %s: no leaderboards", gender)
+		}
+		for _, b := range boards {
+			seen := map[int64]bool{}
+			prev := 0.0
+			for _, e := range b.Entries {
+				secs, err := pace.Parse(e.Time)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if secs < prev || seen[e.AthleteID] || e.Gender == nil || *e.Gender != gender {
+					t.Errorf("%s %.0fm board: bad entry %+v", gender, b.DistanceMeters, e)
+				}
+				prev = secs
+				seen[e.AthleteID] = true
+			}
+		}
+	}
+}
@@ -0,0 +1,397 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+	"jones-county-xc/backend/pace"
+)
+
+type SplitResponse struct {
+	ID             int64   `json:"id"`
+	ResultID       int64   `json:"resultId"`
+	SplitIndex     int64   `json:"splitIndex"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	Time           string  `json:"time"`
+	SegmentTime    *string `json:"segmentTime"`
+	SegmentPace    *string `json:"segmentPace"`
+}
+
+type splitInput struct {
+	DistanceMeters float64 `json:"distanceMeters"`
+	Time           string  `json:"time"`
+}
+
+// --- Split handlers ---
+
+func (s *Server) GetResultSplits(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var resultID int64
+	if _, err := fmt.Sscanf(id, "%d", &resultID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid result ID"})
+		return
+	}
+
+	if _, err := s.store.GetResultByID(ctx, resultID); err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "result not found"})
+		return
+	} else if err != nil {
+		serverError(c, err)
+		return
+	}
+	splits, err := s.store.GetSplitsByResult(ctx, resultID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, splitResponses(splits))
+}
+
+// SetResultSplits replaces every split recorded for a result. Splits are
+// numbered in the order given.
+func (s *Server) SetResultSplits(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var resultID int64
+	if _, err := fmt.Sscanf(id, "%d", &resultID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid result ID"})
+		return
+	}
+
+	var input struct {
+		Splits []splitInput `json:"splits"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateSplits(input.Splits); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := s.store.GetResultByID(ctx, resultID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "result not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if !s.requireMeetInProgress(c, result.MeetID.Int64) {
+		return
+	}
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	splits, err := replaceSplits(ctx, tx, resultID, input.Splits)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, splitResponses(splits))
+}
+
+// --- CSV import ---
+
+// ImportMeetResults bulk-loads results for a meet from CSV. The header must
+// contain athleteId, place and time; any column named split_<meters> (for
+// example split_1609) is stored as a split at that distance. Blank split
+// cells are skipped. The whole file is written in one transaction.
+func (s *Server) ImportMeetResults(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var meetID int64
+	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid meet ID"})
+		return
+	}
+
+	if !s.requireMeetInProgress(c, meetID) {
+		return
+	}
+
+	var body io.Reader = c.Request.Body
+	if c.ContentType() == "multipart/form-data" {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		defer f.Close()
+		body = f
+	}
+
+	rows, err := parseResultsCSV(body)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	if msg, err := s.checkImportAthletes(ctx, rows); err != nil {
+		serverError(c, err)
+		return
+	} else if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	posted, splitCount, err := s.importResults(ctx, meetID, rows)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	records := posted.NewRecords
+	if records == nil {
+		records = []RecordBreakResponse{}
+	}
+	c.JSON(201, gin.H{"results": len(posted.Results), "splits": splitCount, "newRecords": records})
+}
+
+// importResults saves parsed CSV rows as the meet's results in one
+// transaction and checks them for school records. The admin CLI uses it too.
+func (s *Server) importResults(ctx context.Context, meetID int64, rows []csvResultRow) (ResultsPostedEvent, int, error) {
+	meters := s.meetDistance(ctx, meetID)
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		return ResultsPostedEvent{}, 0, err
+	}
+	defer tx.Rollback()
+
+	posted := make([]ResultResponse, 0, len(rows))
+	splitCount := 0
+	for _, row := range rows {
+		result, err := tx.CreateResult(ctx, db.CreateResultParams{
+			AthleteID: sql.NullInt64{Int64: row.AthleteID, Valid: true},
+			MeetID:    sql.NullInt64{Int64: meetID, Valid: true},
+			Time:      sql.NullString{String: row.Time, Valid: true},
+			Place:     sql.NullInt64{Int64: row.Place, Valid: true},
+		})
+		if err != nil {
+			return ResultsPostedEvent{}, 0, fmt.Errorf("line %d: %v", row.Line, err)
+		}
+		if _, err := replaceSplits(ctx, tx, result.ID, row.Splits); err != nil {
+			return ResultsPostedEvent{}, 0, fmt.Errorf("line %d: %v", row.Line, err)
+		}
+		splitCount += len(row.Splits)
+		posted = append(posted, ResultResponse{
+			ID:        result.ID,
+			AthleteID: nullInt64ToPtr(result.AthleteID),
+			MeetID:    nullInt64ToPtr(result.MeetID),
+			Time:      nullStringToPtr(result.Time),
+			Place:     nullInt64ToPtr(result.Place),
+
+			PaceResponse: paceResponse(result.Time, meters),
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ResultsPostedEvent{}, 0, err
+	}
+	event := ResultsPostedEvent{
+		MeetID:     meetID,
+		Results:    posted,
+		NewRecords: s.checkRecords(context.WithoutCancel(ctx), postedIDs(posted)...),
+	}
+	s.emitEvent(EventResultsPosted, event)
+	return event, splitCount, nil
+}
+
+// checkImportAthletes returns a message naming the first row whose athlete
+// does not exist, or "" when every row's athlete is on the roster.
+func (s *Server) checkImportAthletes(ctx context.Context, rows []csvResultRow) (string, error) {
+	athletes, err := s.store.GetAllAthletes(ctx)
+	if err != nil {
+		return "", err
+	}
+	known := make(map[int64]bool, len(athletes))
+	for _, a := range athletes {
+		known[a.ID] = true
+	}
+	for _, row := range rows {
+		if !known[row.AthleteID] {
+			return fmt.Sprintf("line %d: athlete %d not found", row.Line, row.AthleteID), nil
+		}
+	}
+	return "", nil
+}
+
+// postedIDs returns the IDs of results just written, for checkRecords.
+func postedIDs(results []ResultResponse) []int64 {
+	ids := make([]int64, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+type csvResultRow struct {
+	Line      int
+	AthleteID int64
+	Place     int64
+	Time      string
+	Splits    []splitInput
+}
+
+func parseResultsCSV(r io.Reader) ([]csvResultRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %v", err)
+	}
+
+	athleteCol, placeCol, timeCol := -1, -1, -1
+	splitCols := map[int]float64{}
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		switch {
+		case strings.EqualFold(name, "athleteId"):
+			athleteCol = i
+		case strings.EqualFold(name, "place"):
+			placeCol = i
+		case strings.EqualFold(name, "time"):
+			timeCol = i
+		case strings.HasPrefix(strings.ToLower(name), "split_"):
+			meters, err := strconv.ParseFloat(name[len("split_"):], 64)
+			if err != nil || meters <= 0 {
+				return nil, fmt.Errorf("invalid split column %q", name)
+			}
+			splitCols[i] = meters
+		}
+	}
+	if athleteCol < 0 || placeCol < 0 || timeCol < 0 {
+		return nil, fmt.Errorf("header must include athleteId, place and time")
+	}
+
+	var rows []csvResultRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := csvResultRow{Line: line, Time: strings.TrimSpace(record[timeCol])}
+		if row.AthleteID, err = strconv.ParseInt(strings.TrimSpace(record[athleteCol]), 10, 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid athleteId", line)
+		}
+		if row.Place, err = strconv.ParseInt(strings.TrimSpace(record[placeCol]), 10, 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid place", line)
+		}
+		if _, err := pace.Parse(row.Time); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		for i := range record {
+			meters, ok := splitCols[i]
+			value := strings.TrimSpace(record[i])
+			if !ok || value == "" {
+				continue
+			}
+			row.Splits = append(row.Splits, splitInput{DistanceMeters: meters, Time: value})
+		}
+		if err := validateSplits(row.Splits); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// --- Helpers ---
+
+// validateSplits checks that distances and cumulative times both increase.
+func validateSplits(splits []splitInput) error {
+	var lastMeters, lastSeconds float64
+	for i, s := range splits {
+		seconds, err := pace.Parse(s.Time)
+		if err != nil {
+			return fmt.Errorf("split %d: %v", i+1, err)
+		}
+		if s.DistanceMeters <= lastMeters {
+			return fmt.Errorf("split %d: distance must increase", i+1)
+		}
+		if seconds <= lastSeconds {
+			return fmt.Errorf("split %d: time must increase", i+1)
+		}
+		lastMeters, lastSeconds = s.DistanceMeters, seconds
+	}
+	return nil
+}
+
+func replaceSplits(ctx context.Context, q db.Querier, resultID int64, input []splitInput) ([]db.Split, error) {
+	if err := q.DeleteSplitsByResult(ctx, resultID); err != nil {
+		return nil, err
+	}
+
+	splits := make([]db.Split, len(input))
+	for i, s := range input {
+		split, err := q.CreateSplit(ctx, db.CreateSplitParams{
+			ResultID:       resultID,
+			SplitIndex:     int64(i + 1),
+			DistanceMeters: s.DistanceMeters,
+			Time:           strings.TrimSpace(s.Time),
+		})
+		if err != nil {
+			return nil, err
+		}
+		splits[i] = split
+	}
+	return splits, nil
+}
+
+// splitResponses derives each segment's elapsed time and per-mile pace from
+// the cumulative split before it.
+func splitResponses(splits []db.Split) []SplitResponse {
+	response := make([]SplitResponse, len(splits))
+	var lastMeters, lastSeconds float64
+	for i, s := range splits {
+		response[i] = SplitResponse{
+			ID:             s.ID,
+			ResultID:       s.ResultID,
+			SplitIndex:     s.SplitIndex,
+			DistanceMeters: s.DistanceMeters,
+			Time:           s.Time,
+		}
+
+		seconds, err := pace.Parse(s.Time)
+		if err != nil || s.DistanceMeters <= lastMeters {
+			continue
+		}
+		segment := seconds - lastSeconds
+		segmentTime := pace.Format(segment)
+		segmentPace := pace.Format(pace.PerMile(segment, s.DistanceMeters-lastMeters))
+		response[i].SegmentTime = &segmentTime
+		response[i].SegmentPace = &segmentPace
+		lastMeters, lastSeconds = s.DistanceMeters, seconds
+	}
+	return response
+}
@@ -0,0 +1,63 @@
+// Package stats holds the small amount of statistics behind athlete
+// analytics: averages, spread and a least-squares trend line.
+package stats
+
+import "math"
+
+// Mean returns the arithmetic mean, or 0 for no values.
+func Mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// StdDev returns the sample standard deviation, or 0 for fewer than two
+// values.
+func StdDev(xs []float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	mean := Mean(xs)
+	var sum float64
+	for _, x := range xs {
+		sum += (x - mean) * (x - mean)
+	}
+	return math.Sqrt(sum / float64(len(xs)-1))
+}
+
+// Line is y = Slope*x + Intercept.
+type Line struct {
+	Slope     float64
+	Intercept float64
+}
+
+// At evaluates the line at x.
+func (l Line) At(x float64) float64 {
+	return l.Slope*x + l.Intercept
+}
+
+// LinearFit returns the least-squares line through the points. It reports
+// false when there are fewer than two points or every x is the same, since
+// no line is defined.
+func LinearFit(xs, ys []float64) (Line, bool) {
+	if len(xs) != len(ys) || len(xs) < 2 {
+		return Line{}, false
+	}
+	meanX, meanY := Mean(xs), Mean(ys)
+	var num, den float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		num += dx * (ys[i] - meanY)
+		den += dx * dx
+	}
+	if den == 0 {
+		return Line{}, false
+	}
+	slope := num / den
+	return Line{Slope: slope, Intercept: meanY - slope*meanX}, true
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestMean(t *testing.T) {
+	tests := []struct {
+		name string
+		xs   []float64
+		want float64
+	}{
+		{"empty", nil, 0},
+		{"single", []float64{990}, 990},
+		{"several", []float64{990, 1000, 1010}, 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mean(tt.xs); !approxEqual(got, tt.want) {
+				t.Errorf("Mean(%v) = %v, want %v", tt.xs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStdDev(t *testing.T) {
+	tests := []struct {
+		name string
+		xs   []float64
+		want float64
+	}{
+		{"empty", nil, 0},
+		{"single", []float64{990}, 0},
+		{"identical", []float64{1000, 1000, 1000}, 0},
+		{"sample", []float64{2, 4, 4, 4, 5, 5, 7, 9}, math.Sqrt(32.0 / 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StdDev(tt.xs); !approxEqual(got, tt.want) {
+				t.Errorf("StdDev(%v) = %v, want %v", tt.xs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinearFit(t *testing.T) {
+	tests := []struct {
+		name   string
+		xs, ys []float64
+		want   Line
+		ok     bool
+	}{
+		{"too few points", []float64{0}, []float64{1000}, Line{}, false},
+		{"mismatched lengths", []float64{0, 7}, []float64{1000}, Line{}, false},
+		{"vertical", []float64{3, 3}, []float64{1000, 990}, Line{}, false},
+		{"exact", []float64{0, 7, 14}, []float64{1000, 993, 986}, Line{Slope: -1, Intercept: 1000}, true},
+		{"flat", []float64{0, 7, 14}, []float64{1000, 1000, 1000}, Line{Slope: 0, Intercept: 1000}, true},
+		{"noisy", []float64{0, 1, 2, 3}, []float64{1, 3, 2, 4}, Line{Slope: 0.8, Intercept: 1.3}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := LinearFit(tt.xs, tt.ys)
+			if ok != tt.ok {
+				t.Fatalf("LinearFit ok = %v, want %v", ok, tt.ok)
+			}
+			if !approxEqual(got.Slope, tt.want.Slope) || !approxEqual(got.Intercept, tt.want.Intercept) {
+				t.Errorf("LinearFit = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineAt(t *testing.T) {
+	l := Line{Slope: -2, Intercept: 1000}
+	if got := l.At(10); !approxEqual(got, 980) {
+		t.Errorf("At(10) = %v, want 980", got)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+
+	"jones-county-xc/backend/db"
+)
+
+// Store is the database as the handlers see it: the sqlc queries plus
+// transactions and online backups. sqlStore is the SQLite implementation;
+// the tests run the same one against an in-memory database.
+type Store interface {
+	db.Querier
+	// Begin starts a transaction. Callers defer Rollback, which is a no-op
+	// once Commit has succeeded. The transaction is rolled back if ctx is
+	// cancelled before Commit.
+	Begin(ctx context.Context) (Tx, error)
+	// Backup writes a consistent copy of the database to path while it
+	// stays in use.
+	Backup(ctx context.Context, path string) error
+	Close() error
+}
+
+// Tx runs the sqlc queries inside a transaction.
+type Tx interface {
+	db.Querier
+	Commit() error
+	Rollback() error
+}
+
+type sqlStore struct {
+	*db.Queries
+	conn *sql.DB
+}
+
+func newSQLStore(conn *sql.DB) *sqlStore {
+	return &sqlStore{Queries: db.New(conn), conn: conn}
+}
+
+func (s *sqlStore) Begin(ctx context.Context) (Tx, error) {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlTx{Queries: s.Queries.WithTx(tx), tx: tx}, nil
+}
+
+func (s *sqlStore) Backup(ctx context.Context, path string) error {
+	_, err := s.conn.ExecContext(ctx, "VACUUM INTO ?", path)
+	return err
+}
+
+func (s *sqlStore) Close() error {
+	return s.conn.Close()
+}
+
+type sqlTx struct {
+	*db.Queries
+	tx *sql.Tx
+}
+
+func (t *sqlTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *sqlTx) Rollback() error {
+	return t.tx.Rollback()
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+	"jones-county-xc/backend/pace"
+)
+
+type BibResponse struct {
+	ID          int64  `json:"id"`
+	MeetID      int64  `json:"meetId"`
+	AthleteID   int64  `json:"athleteId"`
+	Bib         int64  `json:"bib"`
+	AthleteName string `json:"athleteName,omitempty"`
+}
+
+// timingEdit inserts or deletes a single entry in one of the finish-order
+// lists before they are merged. Position is the 1-based finish position.
+type timingEdit struct {
+	Action   string `json:"action"`
+	List     string `json:"list"`
+	Position int    `json:"position"`
+	Time     string `json:"time"`
+	Bib      int64  `json:"bib"`
+}
+
+type timingInput struct {
+	Times   []string     `json:"times"`
+	Bibs    []int64      `json:"bibs"`
+	Edits   []timingEdit `json:"edits"`
+	Replace bool         `json:"replace"`
+}
+
+type TimingRow struct {
+	Place       int64   `json:"place"`
+	Time        *string `json:"time"`
+	Bib         *int64  `json:"bib"`
+	AthleteID   *int64  `json:"athleteId"`
+	AthleteName *string `json:"athleteName"`
+	Problem     string  `json:"problem,omitempty"`
+}
+
+type TimingPreview struct {
+	MeetID       int64       `json:"meetId"`
+	TimeCount    int         `json:"timeCount"`
+	BibCount     int         `json:"bibCount"`
+	Mismatch     bool        `json:"mismatch"`
+	ProblemCount int         `json:"problemCount"`
+	Rows         []TimingRow `json:"rows"`
+}
+
+// --- Bib handlers ---
+
+func (s *Server) GetMeetBibs(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var meetID int64
+	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid meet ID"})
+		return
+	}
+
+	bibs, err := s.store.GetBibsByMeet(ctx, meetID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]BibResponse, len(bibs))
+	for i, b := range bibs {
+		response[i] = BibResponse{
+			ID:          b.ID,
+			MeetID:      b.MeetID,
+			AthleteID:   b.AthleteID,
+			Bib:         b.Bib,
+			AthleteName: b.AthleteName,
+		}
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) AssignBib(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var meetID int64
+	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid meet ID"})
+		return
+	}
+
+	var input struct {
+		AthleteID int64 `json:"athleteId"`
+		Bib       int64 `json:"bib"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if input.AthleteID <= 0 || input.Bib <= 0 {
+		c.JSON(400, gin.H{"error": "athleteId and bib are required"})
+		return
+	}
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	holder, err := tx.GetBibByNumber(ctx, db.GetBibByNumberParams{MeetID: meetID, Bib: input.Bib})
+	if err == nil && holder.AthleteID != input.AthleteID {
+		c.JSON(409, gin.H{"error": "bib already assigned at this meet"})
+		return
+	}
+	if err != nil && err != sql.ErrNoRows {
+		serverError(c, err)
+		return
+	}
+	// Reassigning an athlete's bib replaces it; only a new assignment is 201.
+	status := 200
+	if _, err := tx.GetBibByAthlete(ctx, db.GetBibByAthleteParams{MeetID: meetID, AthleteID: input.AthleteID}); err == sql.ErrNoRows {
+		status = 201
+	} else if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	bib, err := tx.AssignBib(ctx, db.AssignBibParams{
+		MeetID:    meetID,
+		AthleteID: input.AthleteID,
+		Bib:       input.Bib,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	c.JSON(status, BibResponse{
+		ID:        bib.ID,
+		MeetID:    bib.MeetID,
+		AthleteID: bib.AthleteID,
+		Bib:       bib.Bib,
+	})
+}
+
+func (s *Server) DeleteBib(c *gin.Context) {
+	ctx := c.Request.Context()
+	var meetID, athleteID int64
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &meetID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid meet ID"})
+		return
+	}
+	if _, err := fmt.Sscanf(c.Param("athleteId"), "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+
+	n, err := s.store.DeleteBib(ctx, db.DeleteBibParams{
+		MeetID:    meetID,
+		AthleteID: athleteID,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "bib not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "bib removed"})
+}
+
+// --- Finish-line ingestion ---
+
+// PreviewTiming merges the stopwatch times and pulled bib tags without
+// writing anything, so a coach can fix a missed finisher before committing.
+func (s *Server) PreviewTiming(c *gin.Context) {
+	ctx := c.Request.Context()
+	meetID, input, ok := bindTimingInput(c)
+	if !ok {
+		return
+	}
+
+	preview, err := s.buildTimingPreview(ctx, meetID, input)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, preview)
+}
+
+// CommitTiming writes the merged finish order to results in one transaction.
+// It refuses while any row still has a problem, and refuses to overwrite
+// existing results for the meet unless replace is set.
+func (s *Server) CommitTiming(c *gin.Context) {
+	ctx := c.Request.Context()
+	meetID, input, ok := bindTimingInput(c)
+	if !ok {
+		return
+	}
+
+	if !s.requireMeetInProgress(c, meetID) {
+		return
+	}
+
+	preview, err := s.buildTimingPreview(ctx, meetID, input)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if preview.ProblemCount > 0 {
+		c.JSON(422, gin.H{"error": "finish order has unresolved problems", "preview": preview})
+		return
+	}
+	if len(preview.Rows) == 0 {
+		c.JSON(400, gin.H{"error": "no finishers to commit"})
+		return
+	}
+
+	meetParam := sql.NullInt64{Int64: meetID, Valid: true}
+	meters := s.meetDistance(ctx, meetID)
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Counted inside the transaction so no result can land between the
+	// check and the delete.
+	existing, err := tx.CountResultsByMeet(ctx, meetParam)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if existing > 0 && !input.Replace {
+		c.JSON(409, gin.H{"error": "meet already has results; set replace to overwrite them"})
+		return
+	}
+	if existing > 0 {
+		if err := tx.DeleteResultsByMeet(ctx, meetParam); err != nil {
+			serverError(c, err)
+			return
+		}
+	}
+
+	response := make([]ResultResponse, len(preview.Rows))
+	for i, row := range preview.Rows {
+		result, err := tx.CreateResult(ctx, db.CreateResultParams{
+			AthleteID: sql.NullInt64{Int64: *row.AthleteID, Valid: true},
+			MeetID:    meetParam,
+			Time:      sql.NullString{String: *row.Time, Valid: true},
+			Place:     sql.NullInt64{Int64: row.Place, Valid: true},
+		})
+		if err != nil {
+			serverError(c, err)
+			return
+		}
+		response[i] = ResultResponse{
+			ID:        result.ID,
+			AthleteID: nullInt64ToPtr(result.AthleteID),
+			MeetID:    nullInt64ToPtr(result.MeetID),
+			Time:      nullStringToPtr(result.Time),
+			Place:     nullInt64ToPtr(result.Place),
+
+			PaceResponse: paceResponse(result.Time, meters),
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+	s.emitEvent(EventResultsPosted, ResultsPostedEvent{
+		MeetID:     meetID,
+		Results:    response,
+		NewRecords: s.checkRecords(context.WithoutCancel(ctx), postedIDs(response)...),
+	})
+	c.JSON(201, response)
+}
+
+func bindTimingInput(c *gin.Context) (int64, timingInput, bool) {
+	var input timingInput
+	id := c.Param("id")
+	var meetID int64
+	if _, err := fmt.Sscanf(id, "%d", &meetID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid meet ID"})
+		return 0, input, false
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return 0, input, false
+	}
+	if err := applyTimingEdits(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return 0, input, false
+	}
+	return meetID, input, true
+}
+
+// applyTimingEdits applies the edits in order, so each position refers to the
+// lists as they stand after the previous edit.
+func applyTimingEdits(input *timingInput) error {
+	for i, e := range input.Edits {
+		var length int
+		switch e.List {
+		case "times":
+			length = len(input.Times)
+		case "bibs":
+			length = len(input.Bibs)
+		default:
+			return fmt.Errorf("edit %d: list must be \"times\" or \"bibs\"", i+1)
+		}
+
+		idx := e.Position - 1
+		switch e.Action {
+		case "insert":
+			if idx < 0 || idx > length {
+				return fmt.Errorf("edit %d: position %d out of range", i+1, e.Position)
+			}
+			if e.List == "times" {
+				input.Times = append(input.Times[:idx], append([]string{e.Time}, input.Times[idx:]...)...)
+			} else {
+				input.Bibs = append(input.Bibs[:idx], append([]int64{e.Bib}, input.Bibs[idx:]...)...)
+			}
+		case "delete":
+			if idx < 0 || idx >= length {
+				return fmt.Errorf("edit %d: position %d out of range", i+1, e.Position)
+			}
+			if e.List == "times" {
+				input.Times = append(input.Times[:idx], input.Times[idx+1:]...)
+			} else {
+				input.Bibs = append(input.Bibs[:idx], input.Bibs[idx+1:]...)
+			}
+		default:
+			return fmt.Errorf("edit %d: action must be \"insert\" or \"delete\"", i+1)
+		}
+	}
+	return nil
+}
+
+// buildTimingPreview pairs the nth time with the nth bib and resolves each
+// bib against the meet's assignments, flagging anything that can't be saved.
+func (s *Server) buildTimingPreview(ctx context.Context, meetID int64, input timingInput) (TimingPreview, error) {
+	bibs, err := s.store.GetBibsByMeet(ctx, meetID)
+	if err != nil {
+		return TimingPreview{}, err
+	}
+	assigned := make(map[int64]db.GetBibsByMeetRow, len(bibs))
+	for _, b := range bibs {
+		assigned[b.Bib] = b
+	}
+
+	preview := TimingPreview{
+		MeetID:    meetID,
+		TimeCount: len(input.Times),
+		BibCount:  len(input.Bibs),
+		Mismatch:  len(input.Times) != len(input.Bibs),
+	}
+
+	count := max(len(input.Times), len(input.Bibs))
+	preview.Rows = make([]TimingRow, count)
+	seen := make(map[int64]int)
+	var lastSeconds float64
+
+	for i := 0; i < count; i++ {
+		row := TimingRow{Place: int64(i + 1)}
+		var problems []string
+
+		if i < len(input.Times) {
+			t := strings.TrimSpace(input.Times[i])
+			row.Time = &t
+			seconds, err := pace.Parse(t)
+			switch {
+			case err != nil:
+				problems = append(problems, "invalid time")
+			case seconds < lastSeconds:
+				problems = append(problems, "time is faster than the previous finisher")
+			default:
+				lastSeconds = seconds
+			}
+		} else {
+			problems = append(problems, "missing time")
+		}
+
+		if i < len(input.Bibs) {
+			bib := input.Bibs[i]
+			row.Bib = &bib
+			if prev, dup := seen[bib]; dup {
+				problems = append(problems, "duplicate bib (also place "+strconv.Itoa(prev)+")")
+			} else {
+				seen[bib] = i + 1
+			}
+			if a, ok := assigned[bib]; ok {
+				row.AthleteID = &a.AthleteID
+				row.AthleteName = &a.AthleteName
+			} else {
+				problems = append(problems, "bib not assigned at this meet")
+			}
+		} else {
+			problems = append(problems, "missing bib")
+		}
+
+		if len(problems) > 0 {
+			row.Problem = strings.Join(problems, "; ")
+			preview.ProblemCount++
+		}
+		preview.Rows[i] = row
+	}
+	return preview, nil
+}
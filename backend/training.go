@@ -0,0 +1,812 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+	"jones-county-xc/backend/pace"
+)
+
+const (
+	defaultMileageWeeks = 8
+	maxMileageWeeks     = 52
+	maxWorkoutMiles     = 50.0
+)
+
+var workoutTypes = []string{"easy", "long", "tempo", "interval", "hill", "recovery", "race", "cross-training", "strength", "other"}
+
+type WorkoutResponse struct {
+	ID            int64    `json:"id"`
+	AthleteID     int64    `json:"athleteId"`
+	PlanID        *int64   `json:"planId"`
+	Date          string   `json:"date"`
+	Type          string   `json:"type"`
+	DistanceMiles *float64 `json:"distanceMiles"`
+	Duration      *string  `json:"duration"`
+	PacePerMile   *string  `json:"pacePerMile"`
+	RPE           *int64   `json:"rpe"`
+	Notes         *string  `json:"notes"`
+	CreatedAt     string   `json:"createdAt"`
+}
+
+type WorkoutPlanResponse struct {
+	ID            int64    `json:"id"`
+	Date          string   `json:"date"`
+	Type          string   `json:"type"`
+	Title         string   `json:"title"`
+	DistanceMiles *float64 `json:"distanceMiles"`
+	Duration      *string  `json:"duration"`
+	Description   *string  `json:"description"`
+	AthleteIDs    []int64  `json:"athleteIds"`
+}
+
+// AthletePlanResponse is a plan as seen by one athlete. WorkoutID links the
+// workout logged against it, if any.
+type AthletePlanResponse struct {
+	WorkoutPlanResponse
+	Completed bool   `json:"completed"`
+	WorkoutID *int64 `json:"workoutId"`
+}
+
+type WeeklyMileage struct {
+	WeekStart string  `json:"weekStart"`
+	Miles     float64 `json:"miles"`
+	Workouts  int     `json:"workouts"`
+	Duration  string  `json:"duration"`
+}
+
+type AthleteMileage struct {
+	AthleteID   int64   `json:"athleteId"`
+	AthleteName string  `json:"athleteName"`
+	Miles       float64 `json:"miles"`
+	Workouts    int     `json:"workouts"`
+	Duration    string  `json:"duration"`
+}
+
+type TeamMileageResponse struct {
+	WeekStart string           `json:"weekStart"`
+	WeekEnd   string           `json:"weekEnd"`
+	Athletes  []AthleteMileage `json:"athletes"`
+}
+
+// workoutInput is shared by workouts and plans; RPE and Notes apply only to
+// logged workouts, Title and Description only to plans.
+type workoutInput struct {
+	PlanID        *int64   `json:"planId"`
+	Date          string   `json:"date"`
+	Type          string   `json:"type"`
+	Title         string   `json:"title"`
+	DistanceMiles *float64 `json:"distanceMiles"`
+	Duration      *string  `json:"duration"`
+	RPE           *int64   `json:"rpe"`
+	Notes         *string  `json:"notes"`
+	Description   *string  `json:"description"`
+	AthleteIDs    []int64  `json:"athleteIds"`
+
+	durationSeconds sql.NullInt64
+}
+
+// --- Workout handlers ---
+
+// GetAthleteWorkouts returns an athlete's training log, newest first,
+// optionally limited to ?from and ?to (YYYY-MM-DD, inclusive).
+func (s *Server) GetAthleteWorkouts(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+	from, to, msg := dateRangeQuery(c)
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	workouts, err := s.store.GetWorkoutsByAthlete(ctx, db.GetWorkoutsByAthleteParams{
+		AthleteID: athleteID,
+		FromDate:  from,
+		ToDate:    to,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]WorkoutResponse, len(workouts))
+	for i, w := range workouts {
+		response[i] = workoutResponse(w)
+	}
+	c.JSON(200, response)
+}
+
+// CreateWorkout logs a workout for an athlete. The date defaults to today.
+// A planId must be a plan assigned to the athlete.
+func (s *Server) CreateWorkout(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+	if _, err := s.store.GetAthleteByID(ctx, athleteID); err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "athlete not found"})
+		return
+	} else if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	var input workoutInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg, err := input.validateWorkout(ctx, s.store, athleteID); err != nil {
+		serverError(c, err)
+		return
+	} else if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	workout, err := s.store.CreateWorkout(ctx, db.CreateWorkoutParams{
+		AthleteID:       athleteID,
+		PlanID:          ptrToNullInt64(input.PlanID),
+		Date:            input.Date,
+		Type:            input.Type,
+		DistanceMiles:   ptrToNullFloat64(input.DistanceMiles),
+		DurationSeconds: input.durationSeconds,
+		Rpe:             ptrToNullInt64(input.RPE),
+		Notes:           ptrToNullString(input.Notes),
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(201, workoutResponse(workout))
+}
+
+func (s *Server) UpdateWorkout(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var workoutID int64
+	if _, err := fmt.Sscanf(id, "%d", &workoutID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid workout ID"})
+		return
+	}
+	existing, err := s.store.GetWorkoutByID(ctx, workoutID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "workout not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	var input workoutInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg, err := input.validateWorkout(ctx, s.store, existing.AthleteID); err != nil {
+		serverError(c, err)
+		return
+	} else if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	workout, err := s.store.UpdateWorkout(ctx, db.UpdateWorkoutParams{
+		PlanID:          ptrToNullInt64(input.PlanID),
+		Date:            input.Date,
+		Type:            input.Type,
+		DistanceMiles:   ptrToNullFloat64(input.DistanceMiles),
+		DurationSeconds: input.durationSeconds,
+		Rpe:             ptrToNullInt64(input.RPE),
+		Notes:           ptrToNullString(input.Notes),
+		ID:              workoutID,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, workoutResponse(workout))
+}
+
+func (s *Server) DeleteWorkout(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var workoutID int64
+	if _, err := fmt.Sscanf(id, "%d", &workoutID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid workout ID"})
+		return
+	}
+
+	n, err := s.store.DeleteWorkout(ctx, workoutID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "workout not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "workout deleted"})
+}
+
+// --- Mileage handlers ---
+
+// GetAthleteMileage totals an athlete's workouts by Monday-to-Sunday week
+// for the last ?weeks weeks (default 8), oldest first. Weeks without
+// workouts are included with zero miles.
+func (s *Server) GetAthleteMileage(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+	weeks := defaultMileageWeeks
+	if v := c.Query("weeks"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxMileageWeeks {
+			c.JSON(400, gin.H{"error": "weeks must be between 1 and 52"})
+			return
+		}
+		weeks = n
+	}
+
+	current := weekStart(time.Now().In(meetLocation))
+	first := current.AddDate(0, 0, -7*(weeks-1))
+	workouts, err := s.store.GetWorkoutsByAthlete(ctx, db.GetWorkoutsByAthleteParams{
+		AthleteID: athleteID,
+		FromDate:  first.Format(meetDateLayout),
+		ToDate:    current.AddDate(0, 0, 6).Format(meetDateLayout),
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	totals := map[string]*mileageTotal{}
+	for _, w := range workouts {
+		day, err := time.Parse(meetDateLayout, w.Date)
+		if err != nil {
+			continue
+		}
+		key := weekStart(day).Format(meetDateLayout)
+		if totals[key] == nil {
+			totals[key] = &mileageTotal{}
+		}
+		totals[key].add(w)
+	}
+
+	response := make([]WeeklyMileage, weeks)
+	for i := range response {
+		key := first.AddDate(0, 0, 7*i).Format(meetDateLayout)
+		t := totals[key]
+		if t == nil {
+			t = &mileageTotal{}
+		}
+		response[i] = WeeklyMileage{
+			WeekStart: key,
+			Miles:     roundTenths(t.miles),
+			Workouts:  t.workouts,
+			Duration:  pace.Format(float64(t.seconds)),
+		}
+	}
+	c.JSON(200, response)
+}
+
+// GetTeamMileage totals every athlete's workouts for the week containing
+// ?week (YYYY-MM-DD, default today), highest mileage first. Athletes who
+// logged nothing that week are left out.
+func (s *Server) GetTeamMileage(c *gin.Context) {
+	ctx := c.Request.Context()
+	day := time.Now().In(meetLocation)
+	if v := c.Query("week"); v != "" {
+		t, err := time.Parse(meetDateLayout, v)
+		if err != nil {
+			c.JSON(400, gin.H{"error": "week must be YYYY-MM-DD"})
+			return
+		}
+		day = t
+	}
+	start := weekStart(day)
+	end := start.AddDate(0, 0, 6)
+
+	workouts, err := s.store.GetWorkoutsBetween(ctx, db.GetWorkoutsBetweenParams{
+		FromDate: start.Format(meetDateLayout),
+		ToDate:   end.Format(meetDateLayout),
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	athletes, err := s.store.GetAllAthletes(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	names := map[int64]string{}
+	for _, a := range athletes {
+		names[a.ID] = a.Name
+	}
+
+	totals := map[int64]*mileageTotal{}
+	for _, w := range workouts {
+		if totals[w.AthleteID] == nil {
+			totals[w.AthleteID] = &mileageTotal{}
+		}
+		totals[w.AthleteID].add(w)
+	}
+
+	response := TeamMileageResponse{
+		WeekStart: start.Format(meetDateLayout),
+		WeekEnd:   end.Format(meetDateLayout),
+		Athletes:  []AthleteMileage{},
+	}
+	for athleteID, t := range totals {
+		response.Athletes = append(response.Athletes, AthleteMileage{
+			AthleteID:   athleteID,
+			AthleteName: names[athleteID],
+			Miles:       roundTenths(t.miles),
+			Workouts:    t.workouts,
+			Duration:    pace.Format(float64(t.seconds)),
+		})
+	}
+	sort.Slice(response.Athletes, func(i, j int) bool {
+		a, b := response.Athletes[i], response.Athletes[j]
+		if a.Miles != b.Miles {
+			return a.Miles > b.Miles
+		}
+		return a.AthleteName < b.AthleteName
+	})
+	c.JSON(200, response)
+}
+
+// --- Plan handlers ---
+
+// GetWorkoutPlans lists coach-assigned plans by date, optionally limited to
+// ?from and ?to.
+func (s *Server) GetWorkoutPlans(c *gin.Context) {
+	ctx := c.Request.Context()
+	from, to, msg := dateRangeQuery(c)
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	plans, err := s.store.GetWorkoutPlans(ctx, db.GetWorkoutPlansParams{
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	assigned, err := s.planAthletes(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]WorkoutPlanResponse, len(plans))
+	for i, p := range plans {
+		response[i] = workoutPlanResponse(p, assigned[p.ID])
+	}
+	c.JSON(200, response)
+}
+
+// GetAthletePlans lists the plans assigned to an athlete, marking those with
+// a workout logged against them.
+func (s *Server) GetAthletePlans(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var athleteID int64
+	if _, err := fmt.Sscanf(id, "%d", &athleteID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid athlete ID"})
+		return
+	}
+	from, to, msg := dateRangeQuery(c)
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	plans, err := s.store.GetWorkoutPlansByAthlete(ctx, db.GetWorkoutPlansByAthleteParams{
+		AthleteID: athleteID,
+		FromDate:  from,
+		ToDate:    to,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	// Workouts may be logged on a different day than planned, so look
+	// through the whole log rather than the requested range.
+	workouts, err := s.store.GetWorkoutsByAthlete(ctx, db.GetWorkoutsByAthleteParams{
+		AthleteID: athleteID,
+		FromDate:  minDate,
+		ToDate:    maxDate,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	logged := map[int64]int64{}
+	for _, w := range workouts {
+		if w.PlanID.Valid {
+			logged[w.PlanID.Int64] = w.ID
+		}
+	}
+
+	assigned, err := s.planAthletes(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]AthletePlanResponse, len(plans))
+	for i, p := range plans {
+		response[i] = AthletePlanResponse{WorkoutPlanResponse: workoutPlanResponse(p, assigned[p.ID])}
+		if workoutID, ok := logged[p.ID]; ok {
+			response[i].Completed = true
+			response[i].WorkoutID = &workoutID
+		}
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) CreateWorkoutPlan(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input workoutInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := input.validatePlan(); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	plan, err := tx.CreateWorkoutPlan(ctx, db.CreateWorkoutPlanParams{
+		Date:            input.Date,
+		Type:            input.Type,
+		Title:           input.Title,
+		DistanceMiles:   ptrToNullFloat64(input.DistanceMiles),
+		DurationSeconds: input.durationSeconds,
+		Description:     ptrToNullString(input.Description),
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	athleteIDs, msg, err := setPlanAthletes(ctx, tx, plan.ID, input.AthleteIDs)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(201, workoutPlanResponse(plan, athleteIDs))
+}
+
+// UpdateWorkoutPlan replaces a plan and its assigned athletes.
+func (s *Server) UpdateWorkoutPlan(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var planID int64
+	if _, err := fmt.Sscanf(id, "%d", &planID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid plan ID"})
+		return
+	}
+
+	var input workoutInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := input.validatePlan(); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	plan, err := tx.UpdateWorkoutPlan(ctx, db.UpdateWorkoutPlanParams{
+		Date:            input.Date,
+		Type:            input.Type,
+		Title:           input.Title,
+		DistanceMiles:   ptrToNullFloat64(input.DistanceMiles),
+		DurationSeconds: input.durationSeconds,
+		Description:     ptrToNullString(input.Description),
+		ID:              planID,
+	})
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "plan not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := tx.DeleteWorkoutPlanAthletes(ctx, planID); err != nil {
+		serverError(c, err)
+		return
+	}
+	athleteIDs, msg, err := setPlanAthletes(ctx, tx, planID, input.AthleteIDs)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+	c.JSON(200, workoutPlanResponse(plan, athleteIDs))
+}
+
+// DeleteWorkoutPlan removes a plan. Workouts logged against it are kept
+// and lose the link.
+func (s *Server) DeleteWorkoutPlan(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var planID int64
+	if _, err := fmt.Sscanf(id, "%d", &planID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid plan ID"})
+		return
+	}
+
+	n, err := s.store.DeleteWorkoutPlan(ctx, planID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "plan not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "plan deleted"})
+}
+
+// --- Helpers ---
+
+// minDate and maxDate bound open-ended date ranges; ISO dates compare as text.
+const (
+	minDate = "0000-01-01"
+	maxDate = "9999-12-31"
+)
+
+type mileageTotal struct {
+	miles    float64
+	seconds  int64
+	workouts int
+}
+
+func (t *mileageTotal) add(w db.Workout) {
+	t.miles += w.DistanceMiles.Float64
+	t.seconds += w.DurationSeconds.Int64
+	t.workouts++
+}
+
+// weekStart returns the Monday on or before t.
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -offset)
+}
+
+// dateRangeQuery reads optional ?from and ?to dates, defaulting to an open
+// range. It returns an error message, or "" if both are valid.
+func dateRangeQuery(c *gin.Context) (string, string, string) {
+	from, to := minDate, maxDate
+	if v := c.Query("from"); v != "" {
+		if _, err := time.Parse(meetDateLayout, v); err != nil {
+			return "", "", "from must be YYYY-MM-DD"
+		}
+		from = v
+	}
+	if v := c.Query("to"); v != "" {
+		if _, err := time.Parse(meetDateLayout, v); err != nil {
+			return "", "", "to must be YYYY-MM-DD"
+		}
+		to = v
+	}
+	return from, to, ""
+}
+
+// validateWorkout checks a logged workout, defaulting its date to today.
+// It returns an error message, or "" if the input is valid; the error is
+// only set when the plan's athletes couldn't be loaded.
+func (in *workoutInput) validateWorkout(ctx context.Context, q db.Querier, athleteID int64) (string, error) {
+	if msg := in.validateCommon(); msg != "" {
+		return msg, nil
+	}
+	if in.DistanceMiles == nil && in.Duration == nil {
+		return "distanceMiles or duration is required", nil
+	}
+	if in.RPE != nil && (*in.RPE < 1 || *in.RPE > 10) {
+		return "rpe must be between 1 and 10", nil
+	}
+	if in.PlanID != nil {
+		assigned, err := q.GetWorkoutPlanAthleteIDs(ctx, *in.PlanID)
+		if err != nil {
+			return "", err
+		}
+		if !containsID(assigned, athleteID) {
+			return "planId is not a plan assigned to this athlete", nil
+		}
+	}
+	return "", nil
+}
+
+// validatePlan checks a workout plan, defaulting its date to today.
+func (in *workoutInput) validatePlan() string {
+	if in.Title == "" {
+		return "title is required"
+	}
+	return in.validateCommon()
+}
+
+func (in *workoutInput) validateCommon() string {
+	if in.Date == "" {
+		in.Date = meetToday().String
+	}
+	date, err := normalizeDate(in.Date)
+	if err != nil {
+		return err.Error()
+	}
+	in.Date = date
+	if !validWorkoutType(in.Type) {
+		return "type must be one of easy, long, tempo, interval, hill, recovery, race, cross-training, strength, other"
+	}
+	if in.DistanceMiles != nil && (*in.DistanceMiles <= 0 || *in.DistanceMiles > maxWorkoutMiles) {
+		return "distanceMiles must be between 0 and 50"
+	}
+	if in.Duration != nil {
+		seconds, err := pace.Parse(*in.Duration)
+		if err != nil || seconds <= 0 {
+			return "duration must be m:ss or h:mm:ss"
+		}
+		in.durationSeconds = sql.NullInt64{Int64: int64(math.Round(seconds)), Valid: true}
+	}
+	return ""
+}
+
+func validWorkoutType(t string) bool {
+	for _, v := range workoutTypes {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// planAthletes loads every plan assignment in one query and returns the
+// athlete IDs of each plan, in ascending order.
+func (s *Server) planAthletes(ctx context.Context) (map[int64][]int64, error) {
+	rows, err := s.store.GetAllWorkoutPlanAthletes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assigned := map[int64][]int64{}
+	for _, r := range rows {
+		assigned[r.PlanID] = append(assigned[r.PlanID], r.AthleteID)
+	}
+	return assigned, nil
+}
+
+// setPlanAthletes assigns a plan to each listed athlete. It returns the
+// assigned IDs, or a message for the client if an athlete doesn't exist.
+func setPlanAthletes(ctx context.Context, q db.Querier, planID int64, athleteIDs []int64) ([]int64, string, error) {
+	assigned := []int64{}
+	for _, id := range athleteIDs {
+		if containsID(assigned, id) {
+			continue
+		}
+		if _, err := q.GetAthleteByID(ctx, id); err == sql.ErrNoRows {
+			return nil, fmt.Sprintf("athlete %d not found", id), nil
+		} else if err != nil {
+			return nil, "", err
+		}
+		if err := q.AddWorkoutPlanAthlete(ctx, db.AddWorkoutPlanAthleteParams{
+			PlanID:    planID,
+			AthleteID: id,
+		}); err != nil {
+			return nil, "", err
+		}
+		assigned = append(assigned, id)
+	}
+	sort.Slice(assigned, func(i, j int) bool { return assigned[i] < assigned[j] })
+	return assigned, "", nil
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func workoutResponse(w db.Workout) WorkoutResponse {
+	r := WorkoutResponse{
+		ID:            w.ID,
+		AthleteID:     w.AthleteID,
+		PlanID:        nullInt64ToPtr(w.PlanID),
+		Date:          w.Date,
+		Type:          w.Type,
+		DistanceMiles: nullFloat64ToPtr(w.DistanceMiles),
+		Duration:      durationPtr(w.DurationSeconds),
+		RPE:           nullInt64ToPtr(w.Rpe),
+		Notes:         nullStringToPtr(w.Notes),
+		CreatedAt:     w.CreatedAt,
+	}
+	if w.DistanceMiles.Valid && w.DurationSeconds.Valid && w.DistanceMiles.Float64 > 0 {
+		p := pace.Format(float64(w.DurationSeconds.Int64) / w.DistanceMiles.Float64)
+		r.PacePerMile = &p
+	}
+	return r
+}
+
+func workoutPlanResponse(p db.WorkoutPlan, athleteIDs []int64) WorkoutPlanResponse {
+	if athleteIDs == nil {
+		athleteIDs = []int64{}
+	}
+	return WorkoutPlanResponse{
+		ID:            p.ID,
+		Date:          p.Date,
+		Type:          p.Type,
+		Title:         p.Title,
+		DistanceMiles: nullFloat64ToPtr(p.DistanceMiles),
+		Duration:      durationPtr(p.DurationSeconds),
+		Description:   nullStringToPtr(p.Description),
+		AthleteIDs:    athleteIDs,
+	}
+}
+
+func durationPtr(seconds sql.NullInt64) *string {
+	if !seconds.Valid {
+		return nil
+	}
+	s := pace.Format(float64(seconds.Int64))
+	return &s
+}
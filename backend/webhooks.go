@@ -0,0 +1,592 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jones-county-xc/backend/db"
+)
+
+// Webhooks POST a signed JSON body to subscriber URLs when an event is
+// emitted. Each emitted event is written to webhook_deliveries first and
+// sent by a background worker, which retries failures with backoff.
+
+const (
+	deliveryPending   = "pending"
+	deliveryDelivered = "delivered"
+	deliveryFailed    = "failed"
+
+	maxWebhookAttempts  = 6
+	webhookBatchSize    = 20
+	webhookPollInterval = 15 * time.Second
+	webhookTimeout      = 10 * time.Second
+	minWebhookSecret    = 16
+
+	// EventPing is only sent by PingWebhook and cannot be subscribed to.
+	EventPing = "ping"
+)
+
+var (
+	webhookWake   = make(chan struct{}, 1)
+	webhookClient = &http.Client{Timeout: webhookTimeout}
+
+	// errWebhookGone fails a delivery at once, without retries.
+	errWebhookGone = errors.New("webhook is inactive or deleted")
+)
+
+type WebhookResponse struct {
+	ID        int64    `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	CreatedAt string   `json:"createdAt"`
+	// Secret is only returned when a webhook is created or its secret is
+	// changed.
+	Secret string `json:"secret,omitempty"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhookId"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int64           `json:"attempts"`
+	NextAttemptAt  string          `json:"nextAttemptAt"`
+	ResponseStatus *int64          `json:"responseStatus"`
+	LastError      *string         `json:"lastError"`
+	CreatedAt      string          `json:"createdAt"`
+	DeliveredAt    *string         `json:"deliveredAt"`
+}
+
+// webhookBody is the JSON posted to subscribers. ID is the delivery ID and
+// stays the same across retries, so receivers can drop duplicates.
+type webhookBody struct {
+	ID        int64           `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt string          `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type webhookInput struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+	Secret *string  `json:"secret"`
+}
+
+// validate returns an error message, or "" when the input is valid.
+// Duplicate events are removed.
+func (in *webhookInput) validate() string {
+	u, err := url.Parse(in.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "url must be an absolute http or https URL"
+	}
+	if len(in.Events) == 0 {
+		return "events must list at least one event"
+	}
+	for _, e := range in.Events {
+		if !slices.Contains(eventNames, e) {
+			return fmt.Sprintf("unknown event %q; use one of %s", e, strings.Join(eventNames, ", "))
+		}
+	}
+	slices.Sort(in.Events)
+	in.Events = slices.Compact(in.Events)
+	if in.Secret != nil && len(*in.Secret) < minWebhookSecret {
+		return fmt.Sprintf("secret must be at least %d characters", minWebhookSecret)
+	}
+	return ""
+}
+
+// --- Event listener ---
+
+// queueWebhooks is registered by NewServer. It records one delivery for
+// each active webhook subscribed to the event.
+func (s *Server) queueWebhooks(name string, payload any) {
+	hooks, err := s.store.GetWebhooksForEvent(context.Background(), name)
+	if err != nil {
+		log.Printf("Loading webhooks for %s failed: %v", name, err)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Encoding %s payload failed: %v", name, err)
+		return
+	}
+	for _, h := range hooks {
+		s.queueDelivery(context.Background(), h.ID, name, data)
+	}
+	wake(webhookWake)
+}
+
+func (s *Server) queueDelivery(ctx context.Context, webhookID int64, event string, data []byte) (db.WebhookDelivery, error) {
+	d, err := s.store.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+		WebhookID:     webhookID,
+		Event:         event,
+		Payload:       string(data),
+		NextAttemptAt: outboxTime(time.Now()),
+	})
+	if err != nil {
+		log.Printf("Queueing %s for webhook %d failed: %v", event, webhookID, err)
+	}
+	return d, err
+}
+
+// --- Delivery worker ---
+
+func (s *Server) startWebhookWorker() {
+	go runWorker(webhookPollInterval, webhookWake, s.drainWebhooks)
+}
+
+func (s *Server) drainWebhooks() {
+	for {
+		due, err := s.store.GetDueWebhookDeliveries(context.Background(), db.GetDueWebhookDeliveriesParams{
+			NextAttemptAt: outboxTime(time.Now()),
+			Limit:         webhookBatchSize,
+		})
+		if err != nil {
+			log.Printf("Loading webhook deliveries failed: %v", err)
+			return
+		}
+		for _, d := range due {
+			s.deliverWebhook(d)
+		}
+		if len(due) < webhookBatchSize {
+			return
+		}
+	}
+}
+
+func (s *Server) deliverWebhook(d db.WebhookDelivery) {
+	status, err := s.postWebhook(d)
+	responseStatus := sql.NullInt64{Int64: int64(status), Valid: status != 0}
+	if err == nil {
+		if err := s.store.MarkWebhookDelivered(context.Background(), db.MarkWebhookDeliveredParams{
+			ResponseStatus: responseStatus,
+			DeliveredAt:    sql.NullString{String: outboxTime(time.Now()), Valid: true},
+			ID:             d.ID,
+		}); err != nil {
+			log.Printf("Marking webhook delivery %d delivered failed: %v", d.ID, err)
+		}
+		return
+	}
+
+	attempts := d.Attempts + 1
+	next := deliveryPending
+	if attempts >= maxWebhookAttempts || err == errWebhookGone {
+		next = deliveryFailed
+	}
+	log.Printf("Webhook delivery %d (%s) failed (attempt %d): %v", d.ID, d.Event, attempts, err)
+	if err := s.store.MarkWebhookAttemptFailed(context.Background(), db.MarkWebhookAttemptFailedParams{
+		Status:         next,
+		NextAttemptAt:  outboxTime(time.Now().Add(retryBackoff(attempts))),
+		ResponseStatus: responseStatus,
+		LastError:      sql.NullString{String: err.Error(), Valid: true},
+		ID:             d.ID,
+	}); err != nil {
+		log.Printf("Recording webhook delivery %d failure failed: %v", d.ID, err)
+	}
+}
+
+// postWebhook sends one delivery and returns the HTTP status, or 0 if no
+// response was received. Any 2xx status counts as delivered.
+func (s *Server) postWebhook(d db.WebhookDelivery) (int, error) {
+	hook, err := s.store.GetWebhookByID(context.Background(), d.WebhookID)
+	if err == sql.ErrNoRows || (err == nil && hook.Active == 0) {
+		return 0, errWebhookGone
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := json.Marshal(webhookBody{
+		ID:        d.ID,
+		Event:     d.Event,
+		CreatedAt: d.CreatedAt,
+		Data:      json.RawMessage(d.Payload),
+	})
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequest("POST", hook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "jones-county-xc-webhooks")
+	req.Header.Set("X-Webhook-Event", d.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(d.ID, 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+signWebhook(hook.Secret, timestamp, body))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return resp.StatusCode, nil
+}
+
+// signWebhook is the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with
+// the webhook's secret. Signing the timestamp lets receivers reject
+// replayed requests.
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// --- Admin handlers ---
+
+func (s *Server) GetWebhooks(c *gin.Context) {
+	ctx := c.Request.Context()
+	hooks, err := s.store.GetAllWebhooks(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]WebhookResponse, len(hooks))
+	for i, h := range hooks {
+		events, err := s.store.GetWebhookEvents(ctx, h.ID)
+		if err != nil {
+			serverError(c, err)
+			return
+		}
+		response[i] = webhookResponse(h, events)
+	}
+	c.JSON(200, response)
+}
+
+// CreateWebhook adds a subscription. A secret is generated when none is
+// given; it is only shown in this response.
+func (s *Server) CreateWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input webhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := input.validate(); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+	if input.Secret == nil {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			serverError(c, err)
+			return
+		}
+		input.Secret = &secret
+	}
+	active := input.Active == nil || *input.Active
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	hook, err := tx.CreateWebhook(ctx, db.CreateWebhookParams{
+		Url:    input.URL,
+		Secret: *input.Secret,
+		Active: boolToInt64(active),
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := setWebhookEvents(ctx, tx, hook.ID, input.Events); err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := webhookResponse(hook, input.Events)
+	response.Secret = hook.Secret
+	c.JSON(201, response)
+}
+
+// UpdateWebhook replaces the URL, events and active flag. The secret is
+// kept unless a new one is given.
+func (s *Server) UpdateWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var webhookID int64
+	if _, err := fmt.Sscanf(id, "%d", &webhookID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+
+	var input webhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := input.validate(); msg != "" {
+		c.JSON(400, gin.H{"error": msg})
+		return
+	}
+
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	defer tx.Rollback()
+
+	existing, err := tx.GetWebhookByID(ctx, webhookID)
+	if err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "webhook not found"})
+		return
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	secret := existing.Secret
+	if input.Secret != nil {
+		secret = *input.Secret
+	}
+	active := existing.Active != 0
+	if input.Active != nil {
+		active = *input.Active
+	}
+
+	hook, err := tx.UpdateWebhook(ctx, db.UpdateWebhookParams{
+		Url:    input.URL,
+		Secret: secret,
+		Active: boolToInt64(active),
+		ID:     webhookID,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := tx.DeleteWebhookEvents(ctx, webhookID); err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := setWebhookEvents(ctx, tx, webhookID, input.Events); err != nil {
+		serverError(c, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := webhookResponse(hook, input.Events)
+	if input.Secret != nil {
+		response.Secret = hook.Secret
+	}
+	c.JSON(200, response)
+}
+
+func (s *Server) DeleteWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var webhookID int64
+	if _, err := fmt.Sscanf(id, "%d", &webhookID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+
+	n, err := s.store.DeleteWebhook(ctx, webhookID)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "webhook not found"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "webhook deleted"})
+}
+
+// PingWebhook queues a "ping" delivery so a receiver can be tested
+// without changing any data.
+func (s *Server) PingWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var webhookID int64
+	if _, err := fmt.Sscanf(id, "%d", &webhookID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+
+	if _, err := s.store.GetWebhookByID(ctx, webhookID); err == sql.ErrNoRows {
+		c.JSON(404, gin.H{"error": "webhook not found"})
+		return
+	} else if err != nil {
+		serverError(c, err)
+		return
+	}
+	data, _ := json.Marshal(gin.H{"webhookId": webhookID})
+	d, err := s.queueDelivery(ctx, webhookID, EventPing, data)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	wake(webhookWake)
+	c.JSON(202, webhookDeliveryResponse(d))
+}
+
+// GetWebhookDeliveries is the delivery log for one webhook, newest first.
+func (s *Server) GetWebhookDeliveries(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var webhookID int64
+	if _, err := fmt.Sscanf(id, "%d", &webhookID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+	limit := int64(defaultOutboxSize)
+	if raw := c.Query("limit"); raw != "" {
+		if _, err := fmt.Sscanf(raw, "%d", &limit); err != nil || limit < 1 || limit > maxOutboxSize {
+			c.JSON(400, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", maxOutboxSize)})
+			return
+		}
+	}
+	status := c.Query("status")
+	if status != "" && !slices.Contains([]string{deliveryPending, deliveryDelivered, deliveryFailed}, status) {
+		c.JSON(400, gin.H{"error": "status must be pending, delivered or failed"})
+		return
+	}
+
+	var deliveries []db.WebhookDelivery
+	var err error
+	if status != "" {
+		deliveries, err = s.store.GetWebhookDeliveriesByStatus(ctx, db.GetWebhookDeliveriesByStatusParams{
+			WebhookID: webhookID,
+			Status:    status,
+			Limit:     limit,
+		})
+	} else {
+		deliveries, err = s.store.GetWebhookDeliveries(ctx, db.GetWebhookDeliveriesParams{
+			WebhookID: webhookID,
+			Limit:     limit,
+		})
+	}
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	response := make([]WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		response[i] = webhookDeliveryResponse(d)
+	}
+	c.JSON(200, response)
+}
+
+// RetryWebhookDelivery queues a failed delivery again with its attempts
+// reset.
+func (s *Server) RetryWebhookDelivery(c *gin.Context) {
+	ctx := c.Request.Context()
+	var webhookID, deliveryID int64
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &webhookID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+	if _, err := fmt.Sscanf(c.Param("deliveryId"), "%d", &deliveryID); err != nil {
+		c.JSON(400, gin.H{"error": "invalid delivery ID"})
+		return
+	}
+
+	n, err := s.store.RetryWebhookDelivery(ctx, db.RetryWebhookDeliveryParams{
+		NextAttemptAt: outboxTime(time.Now()),
+		ID:            deliveryID,
+		WebhookID:     webhookID,
+	})
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	if n == 0 {
+		c.JSON(404, gin.H{"error": "failed delivery not found"})
+		return
+	}
+	wake(webhookWake)
+	c.JSON(200, gin.H{"message": "delivery queued for retry"})
+}
+
+// --- Helpers ---
+
+func setWebhookEvents(ctx context.Context, q db.Querier, webhookID int64, events []string) error {
+	for _, e := range events {
+		if err := q.AddWebhookEvent(ctx, db.AddWebhookEventParams{
+			WebhookID: webhookID,
+			Event:     e,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func webhookResponse(h db.Webhook, events []string) WebhookResponse {
+	return WebhookResponse{
+		ID:        h.ID,
+		URL:       h.Url,
+		Events:    events,
+		Active:    h.Active != 0,
+		CreatedAt: h.CreatedAt,
+	}
+}
+
+func webhookDeliveryResponse(d db.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		Event:          d.Event,
+		Payload:        json.RawMessage(d.Payload),
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		ResponseStatus: nullInt64ToPtr(d.ResponseStatus),
+		LastError:      nullStringToPtr(d.LastError),
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    nullStringToPtr(d.DeliveredAt),
+	}
+}
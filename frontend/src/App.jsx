@@ -7,6 +7,8 @@ import AthletesPage from './pages/AthletesPage'
 import MeetsPage from './pages/MeetsPage'
 import ResultsPage from './pages/ResultsPage'
 import LoginPage from './pages/LoginPage'
+import RegisterPage from './pages/RegisterPage'
+import AccountPage from './pages/AccountPage'
 import AdminDashboard from './pages/admin/AdminDashboard'
 
 function App() {
@@ -24,12 +26,23 @@ function App() {
 
           {/* Auth */}
           <Route path="/login" element={<LoginPage />} />
+          <Route path="/register" element={<RegisterPage />} />
+
+          {/* Athlete and parent accounts — protected */}
+          <Route
+            path="/me"
+            element={
+              <ProtectedRoute allow="account">
+                <AccountPage />
+              </ProtectedRoute>
+            }
+          />
 
           {/* Admin — protected */}
           <Route
             path="/admin"
             element={
-              <ProtectedRoute>
+              <ProtectedRoute allow="admin">
                 <AdminDashboard />
               </ProtectedRoute>
             }
@@ -118,14 +118,13 @@ function AllMeets() {
     queryFn: fetchMeets,
   })
 
-  const todayStart = new Date()
-  todayStart.setHours(0, 0, 0, 0)
-  const todayEnd = new Date()
-  todayEnd.setHours(23, 59, 59, 999)
-
-  const todayMeets    = meets.filter(m => { const d = new Date(m.date); return d >= todayStart && d <= todayEnd })
-  const upcomingMeets = meets.filter(m => new Date(m.date) > todayEnd).sort((a, b) => new Date(a.date) - new Date(b.date))
-  const pastMeets     = meets.filter(m => new Date(m.date) < todayStart).sort((a, b) => new Date(b.date) - new Date(a.date))
+  // Meet dates are ISO YYYY-MM-DD strings, so they compare as text.
+  // new Date('YYYY-MM-DD') would read them as UTC midnight.
+  const today = new Date().toLocaleDateString('en-CA')
+
+  const todayMeets    = meets.filter(m => m.date === today)
+  const upcomingMeets = meets.filter(m => m.date > today)
+  const pastMeets     = meets.filter(m => m.date && m.date < today).reverse()
 
   return (
     <section aria-labelledby="all-meets-heading" className="w-full max-w-2xl px-4 pb-12">
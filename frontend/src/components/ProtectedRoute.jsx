@@ -1,13 +1,22 @@
 import { Navigate, useLocation } from 'react-router-dom'
 import { useAuth } from '../context/AuthContext'
 
-function ProtectedRoute({ children }) {
-  const { isAuthenticated } = useAuth()
+// ProtectedRoute requires a signed-in user. `allow` is "admin" for the
+// dashboard or "account" for athlete and parent pages; anyone else signed
+// in is sent to their own home page.
+function ProtectedRoute({ allow = 'admin', children }) {
+  const { isAuthenticated, isAdmin } = useAuth()
   const location = useLocation()
 
   if (!isAuthenticated) {
     return <Navigate to="/login" state={{ from: location }} replace />
   }
+  if (allow === 'admin' && !isAdmin) {
+    return <Navigate to="/me" replace />
+  }
+  if (allow === 'account' && isAdmin) {
+    return <Navigate to="/admin" replace />
+  }
 
   return children
 }
@@ -1,7 +1,7 @@
 import { useQuery } from '@tanstack/react-query'
 
 async function fetchMeets() {
-  const res = await fetch('/api/meets')
+  const res = await fetch('/api/meets?upcoming=true')
   if (!res.ok) throw new Error('Failed to fetch meets')
   return res.json()
 }
@@ -41,16 +41,10 @@ function MeetCardSkeleton() {
 
 function UpcomingMeets() {
   const { data: meets = [], isPending, isError, error, refetch } = useQuery({
-    queryKey: ['meets'],
+    queryKey: ['meets', 'upcoming'],
     queryFn: fetchMeets,
   })
 
-  const today = new Date()
-  today.setHours(0, 0, 0, 0)
-  const upcomingMeets = meets
-    .filter(meet => new Date(meet.date) >= today)
-    .sort((a, b) => new Date(a.date) - new Date(b.date))
-
   return (
     <section aria-labelledby="upcoming-meets-heading" className="w-full max-w-2xl px-4 pb-12">
       <h2 id="upcoming-meets-heading" className="text-2xl font-bold tracking-tight text-green-700 mb-4">Upcoming Meets</h2>
@@ -78,13 +72,13 @@ function UpcomingMeets() {
         </div>
       )}
 
-      {!isPending && !isError && upcomingMeets.length === 0 && (
+      {!isPending && !isError && meets.length === 0 && (
         <p role="status" className="text-gray-500">No upcoming meets scheduled.</p>
       )}
 
-      {!isPending && !isError && upcomingMeets.length > 0 && (
+      {!isPending && !isError && meets.length > 0 && (
         <ul className="flex flex-col gap-3 list-none animate-in fade-in duration-300">
-          {upcomingMeets.map(meet => (
+          {meets.map(meet => (
             <li key={meet.id}>
               <article
                 aria-label={`${meet.name}, ${meet.date}, ${meet.location}`}
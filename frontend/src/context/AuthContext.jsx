@@ -4,19 +4,24 @@ const AuthContext = createContext(null)
 
 export function AuthProvider({ children }) {
   const [token, setToken] = useState(() => localStorage.getItem('jcxc_admin_token'))
+  // Tokens saved before accounts existed were always the admin's.
+  const [role, setRole] = useState(() => localStorage.getItem('jcxc_role') ?? 'admin')
 
-  function login(newToken) {
+  function login(newToken, newRole = 'admin') {
     localStorage.setItem('jcxc_admin_token', newToken)
+    localStorage.setItem('jcxc_role', newRole)
     setToken(newToken)
+    setRole(newRole)
   }
 
   function logout() {
     localStorage.removeItem('jcxc_admin_token')
+    localStorage.removeItem('jcxc_role')
     setToken(null)
   }
 
   return (
-    <AuthContext.Provider value={{ token, login, logout, isAuthenticated: !!token }}>
+    <AuthContext.Provider value={{ token, role, login, logout, isAuthenticated: !!token, isAdmin: !!token && role === 'admin' }}>
       {children}
     </AuthContext.Provider>
   )
@@ -0,0 +1,29 @@
+// Fetch helpers for the signed-in pages. Every request carries the bearer
+// token from AuthContext.
+
+export function authFetch(url, options = {}, token) {
+  return fetch(url, {
+    ...options,
+    headers: {
+      'Content-Type': 'application/json',
+      Authorization: `Bearer ${token}`,
+      ...options.headers,
+    },
+  })
+}
+
+export async function apiFetch(url, token) {
+  const res = await authFetch(url, {}, token)
+  if (!res.ok) throw new Error(`Failed to fetch ${url}`)
+  return res.json()
+}
+
+export async function parseError(res) {
+  const text = await res.text()
+  try {
+    const data = JSON.parse(text)
+    return data.error ?? data.message ?? text
+  } catch {
+    return text || 'Something went wrong'
+  }
+}
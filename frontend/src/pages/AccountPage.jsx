@@ -0,0 +1,452 @@
+import { useState } from 'react'
+import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query'
+import { useNavigate } from 'react-router-dom'
+import { useAuth } from '../context/AuthContext'
+import { authFetch, apiFetch, parseError } from '../lib/api'
+import jcLogo from '../assets/jc-logo.png'
+
+// ─── Shared helpers ──────────────────────────────────────────────────────────
+
+const WORKOUT_TYPES = ['easy', 'long', 'tempo', 'interval', 'hill', 'recovery', 'race', 'cross-training', 'strength', 'other']
+
+// today is the local date as YYYY-MM-DD, the form the API stores.
+function today() {
+  return new Date().toLocaleDateString('en-CA')
+}
+
+const inputClass = `border border-gray-300 rounded-lg px-3 py-2 text-sm text-gray-900
+  focus:outline-none focus:ring-2 focus:ring-green-600 focus:border-transparent w-full`
+
+const selectClass = `border border-gray-300 rounded-lg px-3 py-2 text-sm text-gray-900 bg-white
+  focus:outline-none focus:ring-2 focus:ring-green-600 focus:border-transparent w-full`
+
+const buttonClass = `bg-green-600 text-white text-sm font-medium px-4 py-2 rounded-lg hover:bg-green-700
+  disabled:opacity-60 disabled:cursor-not-allowed transition-colors
+  focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-green-600 focus-visible:ring-offset-2`
+
+function Field({ label, id, children }) {
+  return (
+    <div className="flex flex-col gap-1.5">
+      <label htmlFor={id} className="text-sm font-medium text-gray-700">{label}</label>
+      {children}
+    </div>
+  )
+}
+
+function Section({ title, children }) {
+  return (
+    <section className="bg-white border border-gray-200 rounded-xl shadow-sm p-6 mb-6">
+      <h3 className="text-lg font-semibold text-gray-900 mb-4">{title}</h3>
+      {children}
+    </section>
+  )
+}
+
+function Message({ error, success }) {
+  if (error) return <p role="alert" className="text-red-600 text-sm mb-4">{error}</p>
+  if (success) return <p role="status" className="text-green-700 text-sm mb-4">{success}</p>
+  return null
+}
+
+// ─── Training tab ─────────────────────────────────────────────────────────────
+
+const EMPTY_WORKOUT = { date: '', type: 'easy', distanceMiles: '', duration: '', rpe: '', notes: '', planId: '' }
+
+// workoutBody turns the form into the API's shape, leaving blank fields out.
+function workoutBody(form) {
+  const body = { date: form.date || today(), type: form.type }
+  if (form.distanceMiles !== '') body.distanceMiles = Number(form.distanceMiles)
+  if (form.duration !== '') body.duration = form.duration
+  if (form.rpe !== '') body.rpe = Number(form.rpe)
+  if (form.notes !== '') body.notes = form.notes
+  if (form.planId !== '') body.planId = Number(form.planId)
+  return body
+}
+
+function TrainingTab({ token, athleteId }) {
+  const qc = useQueryClient()
+  const [form, setForm] = useState(EMPTY_WORKOUT)
+  const [error, setError] = useState('')
+  const [success, setSuccess] = useState('')
+
+  const { data: plans = [] } = useQuery({
+    queryKey: ['plans', athleteId],
+    queryFn: () => apiFetch(`/api/athletes/${athleteId}/plans?from=${today()}`, token),
+  })
+  const { data: workouts = [] } = useQuery({
+    queryKey: ['workouts', athleteId],
+    queryFn: () => apiFetch(`/api/athletes/${athleteId}/workouts`, token),
+  })
+  const { data: mileage = [] } = useQuery({
+    queryKey: ['mileage', athleteId],
+    queryFn: () => apiFetch(`/api/athletes/${athleteId}/mileage`, token),
+  })
+
+  const logMutation = useMutation({
+    mutationFn: async (data) => {
+      const res = await authFetch(`/api/athletes/${athleteId}/workouts`, { method: 'POST', body: JSON.stringify(data) }, token)
+      if (!res.ok) throw new Error(await parseError(res))
+    },
+    onSuccess: () => {
+      for (const key of ['plans', 'workouts', 'mileage']) {
+        qc.invalidateQueries({ queryKey: [key, athleteId] })
+      }
+      setForm(EMPTY_WORKOUT)
+      setSuccess('Workout logged.')
+      setTimeout(() => setSuccess(''), 4000)
+    },
+    onError: (err) => setError(err.message),
+  })
+
+  function logPlan(plan) {
+    setForm({ ...EMPTY_WORKOUT, date: plan.date, type: plan.type, distanceMiles: plan.distanceMiles ?? '', planId: String(plan.id) })
+  }
+
+  function handleSubmit(e) {
+    e.preventDefault()
+    setError('')
+    logMutation.mutate(workoutBody(form))
+  }
+
+  return (
+    <div>
+      <Section title="Upcoming Plans">
+        {plans.length === 0
+          ? <p className="text-sm text-gray-500">No plans assigned.</p>
+          : (
+            <ul className="divide-y divide-gray-100">
+              {plans.map(p => (
+                <li key={p.id} className="py-3 flex items-center justify-between gap-4">
+                  <div>
+                    <p className="font-medium text-gray-900">{p.title}</p>
+                    <p className="text-sm text-gray-500">
+                      {p.date} · {p.type}{p.distanceMiles != null && ` · ${p.distanceMiles} mi`}{p.duration && ` · ${p.duration}`}
+                    </p>
+                  </div>
+                  {p.completed
+                    ? <span className="text-sm font-medium text-green-700">Done</span>
+                    : <button onClick={() => logPlan(p)} className="text-sm font-medium text-green-700 hover:text-green-800 hover:underline">Log it</button>
+                  }
+                </li>
+              ))}
+            </ul>
+          )
+        }
+      </Section>
+
+      <Section title="Log a Workout">
+        <Message error={error} success={success} />
+        <form onSubmit={handleSubmit} className="grid grid-cols-1 sm:grid-cols-2 gap-4">
+          <Field label="Date" id="w-date">
+            <input id="w-date" type="date" className={inputClass} value={form.date} onChange={e => setForm(f => ({ ...f, date: e.target.value }))} />
+          </Field>
+          <Field label="Type" id="w-type">
+            <select id="w-type" className={selectClass} value={form.type} onChange={e => setForm(f => ({ ...f, type: e.target.value }))}>
+              {WORKOUT_TYPES.map(t => <option key={t} value={t}>{t}</option>)}
+            </select>
+          </Field>
+          <Field label="Distance (miles)" id="w-distance">
+            <input id="w-distance" type="number" min="0" step="0.1" className={inputClass} value={form.distanceMiles} onChange={e => setForm(f => ({ ...f, distanceMiles: e.target.value }))} />
+          </Field>
+          <Field label="Duration (mm:ss or h:mm:ss)" id="w-duration">
+            <input id="w-duration" type="text" className={inputClass} value={form.duration} onChange={e => setForm(f => ({ ...f, duration: e.target.value }))} />
+          </Field>
+          <Field label="Effort (1–10)" id="w-rpe">
+            <input id="w-rpe" type="number" min="1" max="10" className={inputClass} value={form.rpe} onChange={e => setForm(f => ({ ...f, rpe: e.target.value }))} />
+          </Field>
+          <Field label="Notes" id="w-notes">
+            <input id="w-notes" type="text" className={inputClass} value={form.notes} onChange={e => setForm(f => ({ ...f, notes: e.target.value }))} />
+          </Field>
+          <div className="sm:col-span-2">
+            <button type="submit" disabled={logMutation.isPending} className={buttonClass}>
+              {logMutation.isPending ? 'Saving…' : 'Log workout'}
+            </button>
+          </div>
+        </form>
+      </Section>
+
+      <Section title="Weekly Mileage">
+        <ul className="grid grid-cols-2 sm:grid-cols-4 gap-3">
+          {mileage.map(w => (
+            <li key={w.weekStart} className="border border-gray-200 rounded-lg px-3 py-2">
+              <p className="text-xs text-gray-500">Week of {w.weekStart}</p>
+              <p className="font-semibold text-gray-900">{w.miles} mi</p>
+            </li>
+          ))}
+        </ul>
+      </Section>
+
+      <Section title="Recent Workouts">
+        {workouts.length === 0
+          ? <p className="text-sm text-gray-500">No workouts logged yet.</p>
+          : (
+            <ul className="divide-y divide-gray-100">
+              {workouts.slice(0, 20).map(w => (
+                <li key={w.id} className="py-2 text-sm text-gray-700">
+                  <span className="font-medium text-gray-900">{w.date}</span> · {w.type}
+                  {w.distanceMiles != null && ` · ${w.distanceMiles} mi`}
+                  {w.duration && ` · ${w.duration}`}
+                  {w.pacePerMile && ` · ${w.pacePerMile}/mi`}
+                </li>
+              ))}
+            </ul>
+          )
+        }
+      </Section>
+    </div>
+  )
+}
+
+// ─── Results tab ──────────────────────────────────────────────────────────────
+
+function ResultsTab({ athleteId }) {
+  const { data: results = [], isPending } = useQuery({
+    queryKey: ['athlete-results', athleteId],
+    queryFn: async () => {
+      const res = await fetch(`/api/athletes/${athleteId}/results`)
+      if (!res.ok) throw new Error('Failed to fetch results')
+      return res.json()
+    },
+  })
+
+  if (isPending) return <p className="text-sm text-gray-500">Loading results…</p>
+  if (results.length === 0) return <p className="text-sm text-gray-500">No race results yet.</p>
+
+  return (
+    <div className="bg-white border border-gray-200 rounded-xl overflow-hidden shadow-sm overflow-x-auto">
+      <table className="w-full text-left text-sm">
+        <thead>
+          <tr className="bg-gray-50 border-b border-gray-200 text-green-700 text-xs uppercase tracking-wider">
+            {['Meet', 'Date', 'Time', 'Place', 'Pace'].map(col => (
+              <th key={col} scope="col" className="px-4 py-3 font-semibold">{col}</th>
+            ))}
+          </tr>
+        </thead>
+        <tbody>
+          {results.map(r => (
+            <tr key={r.id} className="border-t border-gray-100">
+              <td className="px-4 py-3 font-medium text-gray-900">{r.meetName}</td>
+              <td className="px-4 py-3 text-gray-600">{r.meetDate}</td>
+              <td className="px-4 py-3 text-gray-900">{r.time}</td>
+              <td className="px-4 py-3 text-gray-600">{r.place}</td>
+              <td className="px-4 py-3 text-gray-600">{r.pacePerMile && `${r.pacePerMile}/mi`}</td>
+            </tr>
+          ))}
+        </tbody>
+      </table>
+    </div>
+  )
+}
+
+// ─── Profile tab ──────────────────────────────────────────────────────────────
+
+function ProfileForm({ token, athleteId, profile }) {
+  const qc = useQueryClient()
+  const [form, setForm] = useState({
+    bio: profile.bio ?? '',
+    emergencyContact: profile.emergencyContact ?? '',
+    emergencyPhone: profile.emergencyPhone ?? '',
+  })
+  const [error, setError] = useState('')
+  const [success, setSuccess] = useState('')
+
+  const saveMutation = useMutation({
+    mutationFn: async (data) => {
+      const res = await authFetch(`/api/athletes/${athleteId}/profile`, { method: 'PUT', body: JSON.stringify(data) }, token)
+      if (!res.ok) throw new Error(await parseError(res))
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ['profile', athleteId] })
+      setSuccess('Profile saved.')
+      setTimeout(() => setSuccess(''), 4000)
+    },
+    onError: (err) => setError(err.message),
+  })
+
+  function handleSubmit(e) {
+    e.preventDefault()
+    setError('')
+    saveMutation.mutate(form)
+  }
+
+  return (
+    <Section title="Profile">
+      <p className="text-sm text-gray-500 mb-4">Only you and the coaches can see these details.</p>
+      <Message error={error} success={success} />
+      <form onSubmit={handleSubmit} className="flex flex-col gap-4">
+        <Field label="Bio" id="p-bio">
+          <textarea id="p-bio" rows={3} className={inputClass} value={form.bio} onChange={e => setForm(f => ({ ...f, bio: e.target.value }))} />
+        </Field>
+        <Field label="Emergency contact" id="p-contact">
+          <input id="p-contact" type="text" className={inputClass} value={form.emergencyContact} onChange={e => setForm(f => ({ ...f, emergencyContact: e.target.value }))} />
+        </Field>
+        <Field label="Emergency phone" id="p-phone">
+          <input id="p-phone" type="tel" className={inputClass} value={form.emergencyPhone} onChange={e => setForm(f => ({ ...f, emergencyPhone: e.target.value }))} />
+        </Field>
+        <div>
+          <button type="submit" disabled={saveMutation.isPending} className={buttonClass}>
+            {saveMutation.isPending ? 'Saving…' : 'Save profile'}
+          </button>
+        </div>
+      </form>
+    </Section>
+  )
+}
+
+function ProfileTab({ token, athleteId }) {
+  const { data: profile, isPending } = useQuery({
+    queryKey: ['profile', athleteId],
+    queryFn: () => apiFetch(`/api/athletes/${athleteId}/profile`, token),
+  })
+  if (isPending || !profile) return <p className="text-sm text-gray-500">Loading profile…</p>
+  // Keyed so switching athletes resets the form to that athlete's profile.
+  return <ProfileForm key={athleteId} token={token} athleteId={athleteId} profile={profile} />
+}
+
+// ─── Link athlete (parents) ──────────────────────────────────────────────────
+
+function LinkAthleteForm({ token }) {
+  const qc = useQueryClient()
+  const [code, setCode] = useState('')
+  const [error, setError] = useState('')
+  const [success, setSuccess] = useState('')
+
+  const linkMutation = useMutation({
+    mutationFn: async (inviteCode) => {
+      const res = await authFetch('/api/me/athletes', { method: 'POST', body: JSON.stringify({ code: inviteCode }) }, token)
+      if (!res.ok) throw new Error(await parseError(res))
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ['me'] })
+      setCode('')
+      setSuccess('Athlete linked.')
+      setTimeout(() => setSuccess(''), 4000)
+    },
+    onError: (err) => setError(err.message),
+  })
+
+  function handleSubmit(e) {
+    e.preventDefault()
+    setError('')
+    linkMutation.mutate(code)
+  }
+
+  return (
+    <Section title="Link Another Athlete">
+      <Message error={error} success={success} />
+      <form onSubmit={handleSubmit} className="flex flex-col sm:flex-row gap-3 sm:items-end">
+        <div className="flex-1">
+          <Field label="Parent invite code" id="link-code">
+            <input id="link-code" type="text" required className={`${inputClass} uppercase tracking-widest`} value={code} onChange={e => setCode(e.target.value)} />
+          </Field>
+        </div>
+        <button type="submit" disabled={linkMutation.isPending} className={buttonClass}>Link</button>
+      </form>
+    </Section>
+  )
+}
+
+// ─── Account page ─────────────────────────────────────────────────────────────
+
+const TABS = ['Training', 'Results', 'Profile']
+
+// AccountPage is the home page for athlete and parent accounts: training,
+// race results and the private profile of each linked athlete.
+function AccountPage() {
+  const { token, logout } = useAuth()
+  const navigate = useNavigate()
+  const [activeTab, setActiveTab] = useState('Training')
+  const [selectedId, setSelectedId] = useState(null)
+
+  const { data: me, isPending, isError, error } = useQuery({
+    queryKey: ['me'],
+    queryFn: () => apiFetch('/api/me', token),
+  })
+
+  function handleLogout() {
+    logout()
+    navigate('/login', { replace: true })
+  }
+
+  const athletes = me?.athletes ?? []
+  const athlete = athletes.find(a => a.id === selectedId) ?? athletes[0]
+
+  return (
+    <div className="min-h-screen bg-gray-50 flex flex-col">
+      {/* Top bar */}
+      <header className="bg-green-950 border-b border-green-900 px-6 py-3 flex items-center justify-between">
+        <div className="flex items-center gap-3">
+          <img src={jcLogo} alt="" aria-hidden="true" className="w-8 h-8 object-contain" />
+          <div>
+            <p className="text-white font-semibold text-sm leading-tight">Jones County XC</p>
+            <p className="text-green-400 text-xs">{me ? me.account.username : 'My Account'}</p>
+          </div>
+        </div>
+        <div className="flex items-center gap-3">
+          <a
+            href="/"
+            className="text-green-300 hover:text-white text-sm font-medium transition-colors
+                       focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-green-400 rounded px-2 py-1"
+          >
+            ← View site
+          </a>
+          <button
+            onClick={handleLogout}
+            className="text-green-300 hover:text-white text-sm font-medium transition-colors
+                       focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-green-400 rounded px-2 py-1"
+          >
+            Sign out
+          </button>
+        </div>
+      </header>
+
+      {/* Tab bar */}
+      <div className="bg-white border-b border-gray-200 px-6 flex items-center justify-between gap-4">
+        <nav aria-label="Account sections" className="flex gap-1">
+          {TABS.map(tab => (
+            <button
+              key={tab}
+              onClick={() => setActiveTab(tab)}
+              aria-current={activeTab === tab ? 'page' : undefined}
+              className={`px-4 py-3 text-sm font-medium border-b-2 transition-colors focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-green-600
+                ${activeTab === tab
+                  ? 'border-green-600 text-green-700'
+                  : 'border-transparent text-gray-500 hover:text-gray-700 hover:border-gray-300'
+                }`}
+            >
+              {tab}
+            </button>
+          ))}
+        </nav>
+        {athletes.length > 1 && (
+          <select
+            aria-label="Athlete"
+            className={`${selectClass} max-w-xs`}
+            value={athlete?.id ?? ''}
+            onChange={e => setSelectedId(Number(e.target.value))}
+          >
+            {athletes.map(a => <option key={a.id} value={a.id}>{a.name}</option>)}
+          </select>
+        )}
+      </div>
+
+      {/* Content */}
+      <main className="flex-1 max-w-5xl w-full mx-auto px-6 py-8">
+        {isPending && <p className="text-sm text-gray-500">Loading…</p>}
+        {isError && <p role="alert" className="text-red-600 text-sm">{error.message}</p>}
+        {me && !athlete && <p className="text-sm text-gray-500">No athletes are linked to this account.</p>}
+        {athlete && (
+          <>
+            <h2 className="text-2xl font-bold tracking-tight text-green-700 mb-6">{athlete.name}</h2>
+            {activeTab === 'Training' && <TrainingTab key={athlete.id} token={token} athleteId={athlete.id} />}
+            {activeTab === 'Results'  && <ResultsTab athleteId={athlete.id} />}
+            {activeTab === 'Profile'  && <ProfileTab token={token} athleteId={athlete.id} />}
+          </>
+        )}
+        {me?.account.role === 'parent' && <LinkAthleteForm token={token} />}
+      </main>
+    </div>
+  )
+}
+
+export default AccountPage
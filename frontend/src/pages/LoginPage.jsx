@@ -1,5 +1,5 @@
 import { useState } from 'react'
-import { useNavigate, useLocation } from 'react-router-dom'
+import { Link, useNavigate, useLocation } from 'react-router-dom'
 import { Eye, EyeOff } from 'lucide-react'
 import { useAuth } from '../context/AuthContext'
 import jcLogo from '../assets/jc-logo.png'
@@ -8,7 +8,7 @@ function LoginPage() {
   const { login } = useAuth()
   const navigate = useNavigate()
   const location = useLocation()
-  const from = location.state?.from?.pathname ?? '/admin'
+  const from = location.state?.from?.pathname
 
   const [username, setUsername] = useState('')
   const [password, setPassword] = useState('')
@@ -33,9 +33,11 @@ function LoginPage() {
         throw new Error(data.message ?? 'Invalid username or password')
       }
 
-      const { token } = await res.json()
-      login(token)
-      navigate(from, { replace: true })
+      const { token, role = 'admin' } = await res.json()
+      login(token, role)
+      // Athlete and parent accounts go to their own page, not the dashboard.
+      const home = role === 'admin' ? '/admin' : '/me'
+      navigate(from?.startsWith(home) ? from : home, { replace: true })
     } catch (err) {
       setError(err.message)
     } finally {
@@ -49,7 +51,7 @@ function LoginPage() {
         {/* Logo */}
         <div className="flex flex-col items-center mb-8">
           <img src={jcLogo} alt="Jones County Greyhounds" className="w-20 h-20 object-contain mb-4 drop-shadow-xl" />
-          <h1 className="text-white text-2xl font-extrabold tracking-tight">Admin Login</h1>
+          <h1 className="text-white text-2xl font-extrabold tracking-tight">Sign In</h1>
           <p className="text-green-200 text-sm mt-1">Jones County Cross Country</p>
         </div>
 
@@ -117,6 +119,12 @@ function LoginPage() {
               {isLoading ? 'Signing in…' : 'Sign in'}
             </button>
           </form>
+          <p className="text-center text-sm text-gray-500 mt-6">
+            Have an invite code?{' '}
+            <Link to="/register" className="font-medium text-green-700 hover:text-green-800 hover:underline">
+              Create an account
+            </Link>
+          </p>
         </div>
       </div>
     </div>
@@ -0,0 +1,126 @@
+import { useState } from 'react'
+import { Link, useNavigate } from 'react-router-dom'
+import { useAuth } from '../context/AuthContext'
+import { parseError } from '../lib/api'
+import jcLogo from '../assets/jc-logo.png'
+
+const inputClass = `border border-gray-300 rounded-lg px-3 py-2 text-sm text-gray-900 placeholder-gray-400
+  focus:outline-none focus:ring-2 focus:ring-green-600 focus:border-transparent`
+
+// RegisterPage creates an athlete or parent account from the invite code a
+// coach handed out, then signs the new account in.
+function RegisterPage() {
+  const { login } = useAuth()
+  const navigate = useNavigate()
+
+  const [form, setForm] = useState({ code: '', username: '', password: '' })
+  const [error, setError] = useState('')
+  const [isLoading, setIsLoading] = useState(false)
+
+  async function handleSubmit(e) {
+    e.preventDefault()
+    setError('')
+    setIsLoading(true)
+
+    try {
+      const res = await fetch('/api/accounts', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify(form),
+      })
+      if (!res.ok) throw new Error(await parseError(res))
+
+      const { token, role } = await res.json()
+      login(token, role)
+      navigate('/me', { replace: true })
+    } catch (err) {
+      setError(err.message)
+    } finally {
+      setIsLoading(false)
+    }
+  }
+
+  return (
+    <div className="min-h-screen bg-gradient-to-br from-green-950 via-green-800 to-green-600 flex items-center justify-center px-4">
+      <div className="w-full max-w-sm">
+        {/* Logo */}
+        <div className="flex flex-col items-center mb-8">
+          <img src={jcLogo} alt="Jones County Greyhounds" className="w-20 h-20 object-contain mb-4 drop-shadow-xl" />
+          <h1 className="text-white text-2xl font-extrabold tracking-tight">Create an Account</h1>
+          <p className="text-green-200 text-sm mt-1">For athletes and parents with an invite code</p>
+        </div>
+
+        {/* Card */}
+        <div className="bg-white rounded-2xl shadow-2xl px-8 py-8">
+          <form onSubmit={handleSubmit} noValidate className="flex flex-col gap-5">
+            {error && (
+              <div role="alert" className="bg-red-50 border border-red-200 rounded-lg px-4 py-3 text-red-700 text-sm">
+                {error}
+              </div>
+            )}
+
+            <div className="flex flex-col gap-1.5">
+              <label htmlFor="code" className="text-sm font-medium text-gray-700">Invite code</label>
+              <input
+                id="code"
+                type="text"
+                required
+                autoComplete="off"
+                value={form.code}
+                onChange={e => setForm(f => ({ ...f, code: e.target.value }))}
+                className={`${inputClass} uppercase tracking-widest`}
+                placeholder="K7QX-M2PD"
+              />
+            </div>
+
+            <div className="flex flex-col gap-1.5">
+              <label htmlFor="username" className="text-sm font-medium text-gray-700">Username</label>
+              <input
+                id="username"
+                type="text"
+                required
+                autoComplete="username"
+                value={form.username}
+                onChange={e => setForm(f => ({ ...f, username: e.target.value }))}
+                className={inputClass}
+              />
+            </div>
+
+            <div className="flex flex-col gap-1.5">
+              <label htmlFor="password" className="text-sm font-medium text-gray-700">Password</label>
+              <input
+                id="password"
+                type="password"
+                required
+                autoComplete="new-password"
+                value={form.password}
+                onChange={e => setForm(f => ({ ...f, password: e.target.value }))}
+                className={inputClass}
+                placeholder="••••••••"
+              />
+            </div>
+
+            <button
+              type="submit"
+              disabled={isLoading}
+              className="w-full bg-green-600 text-white font-semibold py-2.5 rounded-lg
+                         hover:bg-green-700 disabled:opacity-60 disabled:cursor-not-allowed
+                         transition-colors focus-visible:outline-none focus-visible:ring-2
+                         focus-visible:ring-green-600 focus-visible:ring-offset-2"
+            >
+              {isLoading ? 'Creating account…' : 'Create account'}
+            </button>
+          </form>
+          <p className="text-center text-sm text-gray-500 mt-6">
+            Already have an account?{' '}
+            <Link to="/login" className="font-medium text-green-700 hover:text-green-800 hover:underline">
+              Sign in
+            </Link>
+          </p>
+        </div>
+      </div>
+    </div>
+  )
+}
+
+export default RegisterPage
@@ -2,39 +2,9 @@ import { useState } from 'react'
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query'
 import { useNavigate } from 'react-router-dom'
 import { useAuth } from '../../context/AuthContext'
+import { authFetch, apiFetch, parseError } from '../../lib/api'
 import jcLogo from '../../assets/jc-logo.png'
 
-// ─── Helpers ────────────────────────────────────────────────────────────────
-
-function authFetch(url, options = {}, token) {
-  return fetch(url, {
-    ...options,
-    headers: {
-      'Content-Type': 'application/json',
-      Authorization: `Bearer ${token}`,
-      ...options.headers,
-    },
-  })
-}
-
-async function apiFetch(url, token) {
-  const res = await authFetch(url, {}, token)
-  if (!res.ok) throw new Error(`Failed to fetch ${url}`)
-  return res.json()
-}
-
-// ─── Shared helpers ──────────────────────────────────────────────────────────
-
-async function parseError(res) {
-  const text = await res.text()
-  try {
-    const data = JSON.parse(text)
-    return data.error ?? data.message ?? text
-  } catch {
-    return text || 'Something went wrong'
-  }
-}
-
 // ─── Icons ───────────────────────────────────────────────────────────────────
 
 function CheckIcon() {
@@ -220,7 +190,7 @@ function AthletesTab({ token }) {
   })
 
   function openAdd() { setForm(EMPTY_ATHLETE); setFormError(''); setModal({ mode: 'add' }) }
-  function openEdit(item) { setForm({ name: item.name, grade: item.grade, personal_record: item.personal_record }); setFormError(''); setModal({ mode: 'edit', item }) }
+  function openEdit(item) { setForm({ name: item.name, grade: item.grade ?? '', personal_record: item.personal_record ?? '' }); setFormError(''); setModal({ mode: 'edit', item }) }
   function closeModal() { setModal(null) }
 
   function handleDelete(item) {
@@ -232,10 +202,10 @@ function AthletesTab({ token }) {
   function handleSubmit(e) {
     e.preventDefault()
     setFormError('')
-    saveMutation.mutate({
-      ...form,
-      grade: form.grade !== '' ? Number(form.grade) : null,
-    })
+    // Fields left out of an update keep their values, so a blank grade
+    // (an alumnus) is not sent rather than clearing the graduation year.
+    const { grade, ...rest } = form
+    saveMutation.mutate(grade !== '' && grade != null ? { ...rest, grade: Number(grade) } : rest)
   }
 
   return (
@@ -276,7 +246,7 @@ function AthletesTab({ token }) {
               <input id="a-name" type="text" required className={inputClass} value={form.name} onChange={e => setForm(f => ({ ...f, name: e.target.value }))} />
             </Field>
             <Field label="Grade" id="a-grade">
-              <input id="a-grade" type="number" min="9" max="12" required className={inputClass} value={form.grade} onChange={e => setForm(f => ({ ...f, grade: e.target.value }))} />
+              <input id="a-grade" type="number" min="9" max="12" required={modal.mode === 'add'} className={inputClass} value={form.grade} onChange={e => setForm(f => ({ ...f, grade: e.target.value }))} />
             </Field>
             <Field label="5K PR (e.g. 18:45)" id="a-pr">
               <input id="a-pr" type="text" className={inputClass} placeholder="18:45" value={form.personal_record} onChange={e => setForm(f => ({ ...f, personal_record: e.target.value }))} />
@@ -291,7 +261,16 @@ function AthletesTab({ token }) {
 
 // ─── Meets tab ────────────────────────────────────────────────────────────────
 
-const EMPTY_MEET = { name: '', date: '', location: '' }
+const EMPTY_MEET = { name: '', date: '', location: '', courseId: '', startTime: '', busDeparture: '', hostSchool: '' }
+
+// Allowed status changes, mirroring meetTransitions in the backend.
+const MEET_TRANSITIONS = {
+  scheduled:     ['postponed', 'cancelled', 'in-progress'],
+  postponed:     ['scheduled', 'cancelled', 'in-progress'],
+  cancelled:     ['scheduled'],
+  'in-progress': ['final', 'postponed'],
+  final:         ['in-progress'],
+}
 
 function MeetsTab({ token }) {
   const qc = useQueryClient()
@@ -299,11 +278,16 @@ function MeetsTab({ token }) {
   const [form, setForm] = useState(EMPTY_MEET)
   const [formError, setFormError] = useState('')
   const [successMsg, setSuccessMsg] = useState('')
+  const [statusError, setStatusError] = useState('')
 
   const { data: meets = [], isPending } = useQuery({
     queryKey: ['meets'],
     queryFn: () => apiFetch('/api/meets', token),
   })
+  const { data: courses = [] } = useQuery({
+    queryKey: ['courses'],
+    queryFn: () => apiFetch('/api/courses', token),
+  })
 
   const saveMutation = useMutation({
     mutationFn: async (data) => {
@@ -330,8 +314,35 @@ function MeetsTab({ token }) {
     onSuccess: () => qc.invalidateQueries({ queryKey: ['meets'] }),
   })
 
+  const statusMutation = useMutation({
+    mutationFn: async ({ id, status }) => {
+      const res = await authFetch(`/api/meets/${id}/status`, { method: 'POST', body: JSON.stringify({ status }) }, token)
+      if (!res.ok) throw new Error(await parseError(res))
+      return status
+    },
+    onSuccess: (status) => {
+      qc.invalidateQueries({ queryKey: ['meets'] })
+      setStatusError('')
+      setSuccessMsg(`Meet marked ${status}.`)
+      setTimeout(() => setSuccessMsg(''), 4000)
+    },
+    onError: (err) => setStatusError(err.message),
+  })
+
   function openAdd() { setForm(EMPTY_MEET); setFormError(''); setModal({ mode: 'add' }) }
-  function openEdit(item) { setForm({ name: item.name, date: item.date, location: item.location }); setFormError(''); setModal({ mode: 'edit', item }) }
+  function openEdit(item) {
+    setForm({
+      name: item.name,
+      date: item.date ?? '',
+      location: item.location ?? '',
+      courseId: item.courseId ?? '',
+      startTime: item.startTime ?? '',
+      busDeparture: item.busDeparture ?? '',
+      hostSchool: item.hostSchool ?? '',
+    })
+    setFormError('')
+    setModal({ mode: 'edit', item })
+  }
   function closeModal() { setModal(null) }
 
   function handleDelete(item) {
@@ -340,10 +351,25 @@ function MeetsTab({ token }) {
     }
   }
 
+  function handleStatus(item, status) {
+    if (status === 'final' && !window.confirm(`Finalize "${item.name}"? Its results will be locked.`)) return
+    if (item.status === 'final' && !window.confirm(`Reopen "${item.name}" so its results can be corrected?`)) return
+    statusMutation.mutate({ id: item.id, status })
+  }
+
   function handleSubmit(e) {
     e.preventDefault()
     setFormError('')
-    saveMutation.mutate(form)
+    // Send every field so clearing one in the form clears it on the meet.
+    saveMutation.mutate({
+      name: form.name,
+      date: form.date,
+      location: form.location || null,
+      courseId: form.courseId !== '' ? Number(form.courseId) : null,
+      startTime: form.startTime,
+      busDeparture: form.busDeparture,
+      hostSchool: form.hostSchool || null,
+    })
   }
 
   return (
@@ -359,16 +385,30 @@ function MeetsTab({ token }) {
         </button>
       </div>
       <SuccessBanner message={successMsg} />
+      {statusError && <p role="alert" className="text-red-600 text-sm mb-4">{statusError}</p>}
 
       {isPending
-        ? <TableSkeleton cols={3} />
+        ? <TableSkeleton cols={4} />
         : (
-          <AdminTable columns={['Name', 'Date', 'Location']} isEmpty={meets.length === 0} emptyLabel="No meets yet.">
+          <AdminTable columns={['Name', 'Date', 'Location', 'Status']} isEmpty={meets.length === 0} emptyLabel="No meets yet.">
             {meets.map(m => (
               <tr key={m.id} className="border-t border-gray-100 hover:bg-gray-50 transition-colors">
                 <td className="px-4 py-3 font-medium text-gray-900">{m.name}</td>
                 <td className="px-4 py-3 text-gray-600">{m.date}</td>
                 <td className="px-4 py-3 text-gray-600">{m.location}</td>
+                <td className="px-4 py-3">
+                  <select
+                    aria-label={`Status of ${m.name}`}
+                    className={selectClass}
+                    value={m.status}
+                    disabled={statusMutation.isPending}
+                    onChange={e => handleStatus(m, e.target.value)}
+                  >
+                    {[m.status, ...(MEET_TRANSITIONS[m.status] ?? [])].map(s => (
+                      <option key={s} value={s}>{s}</option>
+                    ))}
+                  </select>
+                </td>
                 <ActionButtons onEdit={() => openEdit(m)} onDelete={() => handleDelete(m)} />
               </tr>
             ))}
@@ -389,6 +429,23 @@ function MeetsTab({ token }) {
             <Field label="Location" id="m-location">
               <input id="m-location" type="text" required className={inputClass} value={form.location} onChange={e => setForm(f => ({ ...f, location: e.target.value }))} />
             </Field>
+            <Field label="Course" id="m-course">
+              <select id="m-course" className={selectClass} value={form.courseId} onChange={e => setForm(f => ({ ...f, courseId: e.target.value }))}>
+                <option value="">No course</option>
+                {courses.map(c => <option key={c.id} value={c.id}>{c.name}</option>)}
+              </select>
+            </Field>
+            <div className="grid grid-cols-2 gap-3">
+              <Field label="Start Time" id="m-start">
+                <input id="m-start" type="time" className={inputClass} value={form.startTime} onChange={e => setForm(f => ({ ...f, startTime: e.target.value }))} />
+              </Field>
+              <Field label="Bus Departure" id="m-bus">
+                <input id="m-bus" type="time" className={inputClass} value={form.busDeparture} onChange={e => setForm(f => ({ ...f, busDeparture: e.target.value }))} />
+              </Field>
+            </div>
+            <Field label="Host School" id="m-host">
+              <input id="m-host" type="text" className={inputClass} value={form.hostSchool} onChange={e => setForm(f => ({ ...f, hostSchool: e.target.value }))} />
+            </Field>
             <ModalActions onCancel={closeModal} isPending={saveMutation.isPending} />
           </form>
         </Modal>